@@ -7,6 +7,7 @@ import (
 
 	//	"git.cs.kau.se/hamzchah/opencnc_kafka-exporter/logger/pkg/logger"
 
+	"tsn-service/pkg/RAE/dataStructures/yangSchema"
 	server "tsn-service/pkg/notificationServer"
 	store "tsn-service/pkg/storewrapper"
 )
@@ -20,6 +21,13 @@ func main() {
 		//log.Fatalf("Failed creating default schedule: %v", err)
 		return
 	}
+
+	// Load the YANG modules the updates are validated against, without them the updates are not validated
+	if err := yangSchema.LoadModules("configs/yang"); err != nil {
+		fmt.Printf("Failed loading YANG modules from configs/yang (see configs/yang/README.md), updates are not validated: %v\n", err)
+		//log.Warnf("Failed loading YANG modules: %v", err)
+	}
+
 	fmt.Println("Lets start the server!")
 	// Used to get device configuration and config+state data from a device
 	// go test()
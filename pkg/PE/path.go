@@ -0,0 +1,16 @@
+package pe
+
+/*
+The Path Entity (PE), manages the forwarding process of the streams through the network
+*/
+
+// One bridge that a stream passes on its way from the talker to the listener
+type Hop struct {
+	Node        string // name of the node in the topology
+	DeviceIp    string // address the configuration of the node is sent to
+	IngressPort string // port where the stream is received
+	EgressPort  string // port where the stream is transmitted
+}
+
+// The bridges a stream passes, ordered from the talker to the listener
+type Path []Hop
@@ -0,0 +1,322 @@
+package pe
+
+/*
+Compute the paths of the streams through the topology
+*/
+
+import (
+	"errors"
+	"fmt"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/topology"
+)
+
+// One direction of a link, seen from the node it leaves
+type edge struct {
+	linkId     string
+	localPort  string
+	neighbour  string
+	remotePort string
+}
+
+// The nodes and the links of the topology, links are used in both directions
+type graph struct {
+	nodes map[string]*topology.Node
+	edges map[string][]edge
+}
+
+// A path as the nodes it visits, from the talker to the listener, and the edges between them
+type route struct {
+	nodes []string
+	edges []edge
+}
+
+func newGraph(topo *topology.Topology) *graph {
+	g := &graph{nodes: map[string]*topology.Node{}, edges: map[string][]edge{}}
+	for _, node := range topo.GetNodes() {
+		g.nodes[node.Name] = node
+	}
+	for _, link := range topo.GetLinks() {
+		g.edges[link.SourceNode] = append(g.edges[link.SourceNode],
+			edge{linkId: link.Id, localPort: link.SourcePort, neighbour: link.TargetNode, remotePort: link.TargetPort})
+		g.edges[link.TargetNode] = append(g.edges[link.TargetNode],
+			edge{linkId: link.Id, localPort: link.TargetPort, neighbour: link.SourceNode, remotePort: link.SourcePort})
+	}
+	return g
+}
+
+/*
+Get the name of the node in the topology that an end station of a stream request is
+The end station is found by the mac address of its interfaces
+
+input:
+
+	topo: the topology of the network
+	interfaces: the end station interfaces of the talker or listener
+*/
+func GetEndStationNode(topo *topology.Topology, interfaces []*configuration.Interface) (string, error) {
+	for _, intf := range interfaces {
+		macAddress := intf.GetInterfaceId().GetMacAddress()
+		if macAddress == "" {
+			continue
+		}
+		for _, node := range topo.GetNodes() {
+			for _, port := range node.Ports {
+				if port.MacAddress == macAddress {
+					return node.Name, nil
+				}
+			}
+		}
+	}
+	return "", errors.New("no node in the topology has the mac address of the end station")
+}
+
+/*
+Get the shortest path, in number of hops, from the talker to the listener
+
+input:
+
+	topo: the topology of the network
+	talker, listener: the names of the end station nodes
+*/
+func GetPath(topo *topology.Topology, talker string, listener string) (Path, error) {
+	g := newGraph(topo)
+	r, err := g.shortestRoute(talker, listener, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return g.toPath(r)
+}
+
+/*
+Get the shortest path from the talker of a stream request to each of its listeners
+
+input:
+
+	topo: the topology of the network
+	req: the stream request, the end stations are found by their mac addresses
+
+output:
+
+	paths: the path to each listener, by listener index
+*/
+func GetStreamPaths(topo *topology.Topology, req *configuration.Request) (map[uint32]Path, error) {
+	talker := req.GetTalker()
+	if talker == nil {
+		return nil, errors.New("the request has no talker")
+	}
+
+	talkerNode, err := GetEndStationNode(topo, talker.EndStationInterfaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed finding the talker of stream %s: %w", talker.GetStrId().GetUniqueId(), err)
+	}
+
+	paths := map[uint32]Path{}
+	for _, listener := range req.ListenerList {
+		listenerNode, err := GetEndStationNode(topo, listener.EndStationInterfaces)
+		if err != nil {
+			return nil, fmt.Errorf("failed finding listener %d of stream %s: %w", listener.Index, talker.GetStrId().GetUniqueId(), err)
+		}
+
+		path, err := GetPath(topo, talkerNode, listenerNode)
+		if err != nil {
+			return nil, err
+		}
+		paths[listener.Index] = path
+	}
+	return paths, nil
+}
+
+/*
+Get paths from the talker to the listener that only share the first and the last bridge,
+where the stream is split and merged again for seamless redundancy (IEEE 802.1CB)
+
+The first path is the shortest path, every other path is the shortest path that avoids the bridges and links between
+the split and merge bridge of the paths found before, so fewer paths than requested is an error.
+
+input:
+
+	topo: the topology of the network
+	talker, listener: the names of the end station nodes
+	numPaths: the number of disjoint paths
+*/
+func GetDisjointPaths(topo *topology.Topology, talker string, listener string, numPaths uint) ([]Path, error) {
+	g := newGraph(topo)
+	first, err := g.shortestRoute(talker, listener, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := []route{first}
+	if numPaths > 1 {
+		// the talker and listener are connected to one bridge each, which are the split and merge points
+		if len(first.nodes) < 4 {
+			return nil, errors.New("the stream passes only one bridge, it can not be sent over disjoint paths")
+		}
+		split := 1
+		merge := len(first.nodes) - 2
+
+		usedNodes := map[string]bool{talker: true, listener: true}
+		usedLinks := map[string]bool{}
+		addRoute := func(r route) {
+			for _, node := range r.nodes[split+1 : len(r.nodes)-2] {
+				usedNodes[node] = true
+			}
+			for _, e := range r.edges[split : len(r.edges)-1] {
+				usedLinks[e.linkId] = true
+			}
+		}
+		addRoute(first)
+
+		for len(routes) < int(numPaths) {
+			segment, err := g.shortestRoute(first.nodes[split], first.nodes[merge], usedNodes, usedLinks)
+			if err != nil {
+				return nil, fmt.Errorf("found only %d of %d disjoint paths between %s and %s: %w",
+					len(routes), numPaths, first.nodes[split], first.nodes[merge], err)
+			}
+
+			r := route{
+				nodes: append(append(append([]string{}, first.nodes[:split]...), segment.nodes...), first.nodes[merge+1:]...),
+				edges: append(append(append([]edge{}, first.edges[:split]...), segment.edges...), first.edges[merge:]...),
+			}
+			addRoute(r)
+			routes = append(routes, r)
+		}
+	}
+
+	var paths []Path
+	for _, r := range routes {
+		path, err := g.toPath(r)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+/*
+Get the disjoint paths from the talker of a stream request to each listener that requests seamless redundancy,
+the listeners that do not request it are left out (see GetStreamPaths)
+
+input:
+
+	topo: the topology of the network
+	req: the stream request, the end stations are found by their mac addresses
+
+output:
+
+	paths: the disjoint paths to each listener with more than one seamless tree, by listener index
+*/
+func GetStreamDisjointPaths(topo *topology.Topology, req *configuration.Request) (map[uint32][]Path, error) {
+	talker := req.GetTalker()
+	if talker == nil {
+		return nil, errors.New("the request has no talker")
+	}
+
+	paths := map[uint32][]Path{}
+	var talkerNode string
+	for _, listener := range req.ListenerList {
+		numPaths := GetNumSeamlessTrees(talker, listener)
+		if numPaths < 2 {
+			continue
+		}
+
+		if talkerNode == "" {
+			node, err := GetEndStationNode(topo, talker.EndStationInterfaces)
+			if err != nil {
+				return nil, fmt.Errorf("failed finding the talker of stream %s: %w", talker.GetStrId().GetUniqueId(), err)
+			}
+			talkerNode = node
+		}
+		listenerNode, err := GetEndStationNode(topo, listener.EndStationInterfaces)
+		if err != nil {
+			return nil, fmt.Errorf("failed finding listener %d of stream %s: %w", listener.Index, talker.GetStrId().GetUniqueId(), err)
+		}
+
+		listenerPaths, err := GetDisjointPaths(topo, talkerNode, listenerNode, numPaths)
+		if err != nil {
+			return nil, fmt.Errorf("failed finding %d disjoint paths to listener %d of stream %s: %w",
+				numPaths, listener.Index, talker.GetStrId().GetUniqueId(), err)
+		}
+		paths[listener.Index] = listenerPaths
+	}
+	return paths, nil
+}
+
+/*
+Get the number of disjoint paths the listener requests, the talker decides if the listener does not say
+Ref: IEEE 802.1Qcc-2018 46.2.3.6.1
+*/
+func GetNumSeamlessTrees(talker *configuration.TalkerGroup, listener *configuration.ListenerGroup) uint {
+	if listener.GetUserToNetReq().GetNumSeamlessTrees() > 0 {
+		return uint(listener.GetUserToNetReq().GetNumSeamlessTrees())
+	}
+	return uint(talker.GetUserToNetReq().GetNumSeamlessTrees())
+}
+
+/*
+Breadth first search for the route with the fewest hops, that does not pass the excluded nodes and links
+*/
+func (g *graph) shortestRoute(from string, to string, excludedNodes map[string]bool, excludedLinks map[string]bool) (route, error) {
+	if _, ok := g.nodes[from]; !ok {
+		return route{}, errors.New("node " + from + " is not in the topology")
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return route{}, errors.New("node " + to + " is not in the topology")
+	}
+
+	previous := map[string]edge{}
+	previousNode := map[string]string{}
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+
+	for len(queue) > 0 && !visited[to] {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, e := range g.edges[node] {
+			if visited[e.neighbour] || excludedLinks[e.linkId] || (excludedNodes[e.neighbour] && e.neighbour != to) {
+				continue
+			}
+			visited[e.neighbour] = true
+			previous[e.neighbour] = e
+			previousNode[e.neighbour] = node
+			queue = append(queue, e.neighbour)
+		}
+	}
+
+	if !visited[to] {
+		return route{}, errors.New("no path from " + from + " to " + to)
+	}
+
+	// walk back from the destination
+	r := route{nodes: []string{to}}
+	for node := to; node != from; node = previousNode[node] {
+		r.nodes = append([]string{previousNode[node]}, r.nodes...)
+		r.edges = append([]edge{previous[node]}, r.edges...)
+	}
+	return r, nil
+}
+
+/*
+Convert a route to the hops of the bridges between the talker and the listener
+*/
+func (g *graph) toPath(r route) (Path, error) {
+	var path Path
+	for i := 1; i < len(r.nodes)-1; i++ {
+		node := g.nodes[r.nodes[i]]
+		deviceIp := node.GetManagementInfo().GetIpAddress()
+		if deviceIp == "" {
+			return nil, errors.New("node " + node.Name + " has no management ip address")
+		}
+
+		path = append(path, Hop{
+			Node:        node.Name,
+			DeviceIp:    deviceIp,
+			IngressPort: r.edges[i-1].remotePort,
+			EgressPort:  r.edges[i].localPort,
+		})
+	}
+	return path, nil
+}
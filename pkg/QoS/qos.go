@@ -0,0 +1,277 @@
+package qos
+
+/*
+Plan how the priorities (PCP) of the frames are mapped to the traffic classes of the schedule and to the queues of the ports.
+The schedule opens the gates of a traffic class in its window, which are the queues its priorities are put in at the port:
+
+	schedule traffic class -> priorities (PCP) -> traffic class table of the port -> queues -> gate states
+
+Ref:
+	IEEE 802.1Q-2018 6.9.3 (priority code point encoding)
+	IEEE 802.1Q-2018 8.6.6 (queuing frames, traffic class table)
+	IEEE 802.1Q-2018 8.6.8.4 (enhancements for scheduled traffic)
+*/
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	pcp "tsn-service/pkg/RAE/PCP"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// The priorities of the predefined traffic classes of the schedule
+var DefaultPriorities = map[string][]int{
+	"isochronous":     {7},
+	"cyclic-sync":     {6},
+	"cyclic-async":    {5},
+	"alarms-events":   {4},
+	"config-diag":     {3},
+	"network-control": {2},
+	"best-effort":     {1, 0},
+}
+
+// The traffic class that gets the priorities no other traffic class of the schedule uses
+const DefaultTrafficClass = "best-effort"
+
+// The priorities of every traffic class of the schedule and the queue of every priority at a port
+type PortPlan struct {
+	Priorities     map[string][]int // by name of the traffic class of the schedule
+	TrafficClasses []int            // the queue of each priority, the index is the priority (0-7)
+}
+
+/*
+Assign the priorities (PCP) to the traffic classes of the schedule
+The predefined traffic classes get their DefaultPriorities, other traffic classes get the highest priority
+that is not used, in the order of the schedule. The priorities that are left go to DefaultTrafficClass if it is in the schedule.
+
+input:
+
+	sched: the schedule
+
+output:
+
+	priorities: the priorities of each traffic class of the schedule
+*/
+func GetPriorityMapping(sched *schedule.Schedule) (priorities map[string][]int, err error) {
+	priorities = map[string][]int{}
+	used := map[int]string{}
+
+	assign := func(name string, priority int) error {
+		if other, ok := used[priority]; ok {
+			return errors.New("Priority " + fmt.Sprint(priority) + " is used by both " + other + " and " + name)
+		}
+		used[priority] = name
+		priorities[name] = append(priorities[name], priority)
+		return nil
+	}
+
+	for _, trafficClass := range sched.GetTrafficClasses() {
+		if _, ok := priorities[trafficClass.Name]; ok {
+			return nil, errors.New("traffic class " + trafficClass.Name + " is more than once in the schedule")
+		}
+		for _, priority := range DefaultPriorities[trafficClass.Name] {
+			if err = assign(trafficClass.Name, priority); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, trafficClass := range sched.GetTrafficClasses() {
+		if _, ok := DefaultPriorities[trafficClass.Name]; ok {
+			continue
+		}
+		priority := getHighestUnusedPriority(used)
+		if priority < 0 {
+			return nil, errors.New("no priority is left for traffic class " + trafficClass.Name)
+		}
+		if err = assign(trafficClass.Name, priority); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := priorities[DefaultTrafficClass]; ok {
+		for priority := getHighestUnusedPriority(used); priority >= 0; priority = getHighestUnusedPriority(used) {
+			if err = assign(DefaultTrafficClass, priority); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return priorities, nil
+}
+
+// Get the traffic class of the schedule the frames of a priority are sent in
+func GetTrafficClassOfPriority(sched *schedule.Schedule, priority int) (string, error) {
+	priorities, err := GetPriorityMapping(sched)
+	if err != nil {
+		return "", err
+	}
+	for name, classPriorities := range priorities {
+		for _, p := range classPriorities {
+			if p == priority {
+				return name, nil
+			}
+		}
+	}
+	return "", errors.New("priority " + fmt.Sprint(priority) + " is not in a traffic class of the schedule")
+}
+
+/*
+Map the priorities to the queues of a port
+The default traffic class table is used when it puts the priorities of different traffic classes of the schedule in
+different queues. Otherwise every traffic class gets its own queue, from the highest priority down, and the traffic classes
+that do not fit share queue 0, so their gates open together.
+
+input:
+
+	priorities: the priorities of each traffic class of the schedule
+	nrTrafficClasses: nr of queues at the port (must be 1-8)
+
+output:
+
+	trafficClasses: the queue of each priority, the index is the priority (0-7)
+*/
+func GetTrafficClassTable(priorities map[string][]int, nrTrafficClasses int) (trafficClasses []int, err error) {
+	trafficClasses, err = pcp.GetDefaultTrafficClasses(nrTrafficClasses)
+	if err != nil {
+		return nil, err
+	}
+
+	queueOwners := map[int]string{}
+	shared := false
+	for name, classPriorities := range priorities {
+		for _, priority := range classPriorities {
+			queue := trafficClasses[priority]
+			if owner, ok := queueOwners[queue]; ok && owner != name {
+				shared = true
+			}
+			queueOwners[queue] = name
+		}
+	}
+	if !shared {
+		return trafficClasses, nil
+	}
+
+	// The traffic classes from the one with the highest priority down
+	var names []string
+	for name := range priorities {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return getHighestPriority(priorities[names[i]]) > getHighestPriority(priorities[names[j]])
+	})
+
+	trafficClasses = make([]int, 8)
+	for i, name := range names {
+		queue := max(nrTrafficClasses-1-i, 0)
+		for _, priority := range priorities[name] {
+			trafficClasses[priority] = queue
+		}
+	}
+	return trafficClasses, nil
+}
+
+/*
+Get the gate states that open the queues of the priorities of a traffic class
+
+input:
+
+	priorities: the priorities of the traffic class
+	trafficClasses: the queue of each priority at the port
+
+output:
+
+	gateStates: bit n is set if queue n is open
+*/
+func GetGateStates(priorities []int, trafficClasses []int) uint64 {
+	var gateStates uint64
+	for _, priority := range priorities {
+		gateStates |= 1 << trafficClasses[priority]
+	}
+	return gateStates
+}
+
+/*
+Plan the priorities and queues of a port from the schedule
+
+input:
+
+	sched: the schedule
+	nrTrafficClasses: nr of queues at the port (must be 1-8)
+*/
+func GetPortPlan(sched *schedule.Schedule, nrTrafficClasses int) (*PortPlan, error) {
+	priorities, err := GetPriorityMapping(sched)
+	if err != nil {
+		return nil, err
+	}
+
+	trafficClasses, err := GetTrafficClassTable(priorities, nrTrafficClasses)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PortPlan{Priorities: priorities, TrafficClasses: trafficClasses}, nil
+}
+
+// Get the gate states of every traffic class of the schedule, by name
+func (plan *PortPlan) GetGateStates() map[string]uint64 {
+	gateStates := map[string]uint64{}
+	for name, priorities := range plan.Priorities {
+		gateStates[name] = GetGateStates(priorities, plan.TrafficClasses)
+	}
+	return gateStates
+}
+
+/*
+Plan the priorities and queues of a port from the schedule and set its traffic class table
+
+input:
+
+	root: the configuration of the device
+	deviceIp: the device the port belongs to
+	port: the port in the topology, its number of queues is used
+	sched: the schedule
+
+output:
+
+	plan: the priorities and queues, the gate states of the schedule are derived from it
+	updates: the updates of the traffic class table
+*/
+func ComposePortQoS(root *st.SchemaTree, deviceIp string, port *topology.Port, sched *schedule.Schedule) (
+	plan *PortPlan, updates []*pb.Update, err error) {
+
+	plan, err = GetPortPlan(sched, int(port.NumberOfQueues))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed planning the priorities of port %s: %w", port.Name, err)
+	}
+
+	updates, err = pcp.SetTrafficClassTable(root, port.Name, deviceIp, plan.TrafficClasses, int(port.NumberOfQueues))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plan, updates, nil
+}
+
+// Get the highest priority that no traffic class uses, -1 if all are used
+func getHighestUnusedPriority(used map[int]string) int {
+	for priority := 7; priority >= 0; priority-- {
+		if _, ok := used[priority]; !ok {
+			return priority
+		}
+	}
+	return -1
+}
+
+func getHighestPriority(priorities []int) int {
+	highest := -1
+	for _, priority := range priorities {
+		highest = max(highest, priority)
+	}
+	return highest
+}
@@ -0,0 +1,216 @@
+package cbs
+
+/*
+Compute and set the Credit-Based Shaper (CBS) of the traffic classes at a port.
+The idle slope of a traffic class is the bandwidth reserved for its streams, and the send slope is what is left
+of the port speed. The reservations of all shaped traffic classes together must stay below a share of the port speed
+(75% for class A and B in IEEE 802.1Q), which is the admission check.
+
+Ref:
+	IEEE 802.1Q-2018 8.6.8.2 (credit-based shaper algorithm)
+	IEEE 802.1Q-2018 34 (forwarding and queuing for time-sensitive streams)
+	IEEE 802.1Q-2018 Annex L (hiCredit and loCredit)
+*/
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/topology"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Octets sent with every frame besides the frame itself: preamble, start frame delimiter and inter frame gap
+const PerFrameOverhead = 20
+
+// The TransmissionSelection of a talker whose stream is sent with the credit-based shaper, Ref: IEEE 802.1Q-2018 Table 8-6
+const TransmissionSelectionCbs = 1
+
+// Limits of the shapers at a port
+type Limits struct {
+	MaxReservedPercent  uint // share of the port speed all shaped traffic classes may reserve together
+	MaxInterferenceSize uint // largest frame of a lower traffic class that can delay a frame (octets)
+}
+
+// Limits used when nothing else is configured, class A and B style with 1522 octet frames of best effort traffic
+var DefaultLimits = Limits{MaxReservedPercent: 75, MaxInterferenceSize: 1522}
+
+// The bandwidth a stream reserves in a traffic class at a port
+type Reservation struct {
+	TrafficClass uint
+	Bandwidth    uint // bits/sec
+	MaxFrameSize uint // octets
+}
+
+// The credit-based shaper of one traffic class
+type Shaper struct {
+	TrafficClass uint
+	IdleSlope    uint // bits/sec
+	SendSlope    int  // bits/sec
+	HiCredit     int  // bits
+	LoCredit     int  // bits
+}
+
+/*
+Get the bandwidth a stream needs from its traffic specification, including the per frame overhead
+
+input:
+
+	trafficSpec: the traffic specification of the talker
+
+output:
+
+	bandwidth: bits/sec
+*/
+func GetStreamBandwidth(trafficSpec *configuration.TrafficSpecification) (uint, error) {
+	interval := trafficSpec.GetInterval()
+	if interval == nil || interval.Numerator == 0 || interval.Denominator == 0 {
+		return 0, errors.New("the traffic specification must have an interval larger than 0")
+	}
+
+	bitsPerInterval := uint(trafficSpec.MaxFramesPerInterval) * (uint(trafficSpec.MaxFrameSize) + PerFrameOverhead) * 8
+	return ceilDiv(bitsPerInterval*uint(interval.Denominator), uint(interval.Numerator)), nil
+}
+
+/*
+Compute the shaper of every traffic class that has reservations at a port
+
+input:
+
+	portSpeed: the speed of the port (Mbps)
+	reservations: the streams that are sent through the port
+	limits: how much of the port may be reserved and the largest interfering frame
+
+output:
+
+	shapers: one shaper per traffic class, from the highest traffic class to the lowest
+*/
+func ComputeShapers(portSpeed uint, reservations []Reservation, limits Limits) (shapers []Shaper, err error) {
+	if portSpeed == 0 {
+		return nil, errors.New("the port speed must be larger than 0")
+	}
+	if limits.MaxReservedPercent >= 100 {
+		return nil, errors.New("Invalid reserved share of the port. Value: " + fmt.Sprint(limits.MaxReservedPercent) +
+			"%. Some bandwidth must be left for the other traffic classes")
+	}
+	portRate := portSpeed * 1000000
+
+	idleSlopes := map[uint]uint{}
+	maxFrameSizes := map[uint]uint{}
+	var reserved uint
+	for _, reservation := range reservations {
+		idleSlopes[reservation.TrafficClass] += reservation.Bandwidth
+		maxFrameSizes[reservation.TrafficClass] = max(maxFrameSizes[reservation.TrafficClass], reservation.MaxFrameSize)
+		reserved += reservation.Bandwidth
+	}
+
+	// Admission check of all reservations together
+	maxReserved := portRate / 100 * limits.MaxReservedPercent
+	if reserved > maxReserved {
+		return nil, errors.New("Reserved bandwidth exceeds the limit of the port. Reserved: " + fmt.Sprint(reserved) +
+			" bits/sec, limit: " + fmt.Sprint(maxReserved) + " bits/sec (" + fmt.Sprint(limits.MaxReservedPercent) + "% of the port speed)")
+	}
+
+	// The higher traffic classes can delay the lower ones, so they are handled first
+	var trafficClasses []uint
+	for trafficClass := range idleSlopes {
+		trafficClasses = append(trafficClasses, trafficClass)
+	}
+	sort.Slice(trafficClasses, func(i, j int) bool { return trafficClasses[i] > trafficClasses[j] })
+
+	var higherIdleSlopes, higherFrameSizes uint
+	for _, trafficClass := range trafficClasses {
+		idleSlope := idleSlopes[trafficClass]
+		sendSlope := int(idleSlope) - int(portRate)
+
+		// a frame of a lower class and a frame of each higher class can be sent while the credit builds up
+		interference := (limits.MaxInterferenceSize + higherFrameSizes) * 8
+		hiCredit := int(ceilDiv(interference*idleSlope, portRate-higherIdleSlopes))
+		loCredit := int(maxFrameSizes[trafficClass]*8) * sendSlope / int(portRate)
+
+		shapers = append(shapers, Shaper{
+			TrafficClass: trafficClass,
+			IdleSlope:    idleSlope,
+			SendSlope:    sendSlope,
+			HiCredit:     hiCredit,
+			LoCredit:     loCredit,
+		})
+
+		higherIdleSlopes += idleSlope
+		higherFrameSizes += maxFrameSizes[trafficClass]
+	}
+
+	return shapers, nil
+}
+
+/*
+Set the credit-based shaper of one traffic class
+
+key parameters:
+
+	port, deviceIp
+	shaper.TrafficClass
+
+Parameters to set:
+
+	shaper: idle slope, send slope, hi credit and lo credit
+*/
+func SetCreditBasedShaper(root *st.SchemaTree, port string, deviceIp string, shaper Shaper) (updates []*pb.Update) {
+	updates = append(updates, setTrafficClass(root, port, deviceIp, shaper.TrafficClass))
+	updates = append(updates, setIdleSlope(root, port, deviceIp, shaper.TrafficClass, shaper.IdleSlope))
+	updates = append(updates, setSendSlope(root, port, deviceIp, shaper.TrafficClass, shaper.SendSlope))
+	updates = append(updates, setHiCredit(root, port, deviceIp, shaper.TrafficClass, shaper.HiCredit))
+	updates = append(updates, setLoCredit(root, port, deviceIp, shaper.TrafficClass, shaper.LoCredit))
+	return updates
+}
+
+/*
+Compute and set the shapers of a port from the streams that are sent through it
+The shapers of the traffic classes that have no reservations any more are deleted
+
+input:
+
+	root: the configuration of the device
+	deviceIp: the device the port belongs to
+	port: the port in the topology, its speed and capabilities are used
+	reservations: the streams that are sent through the port
+	limits: how much of the port may be reserved and the largest interfering frame
+
+output:
+
+	shapers: one shaper per traffic class with reservations
+	updates: the updates of the shapers
+	deletes: the shapers that were set before and are not needed any more
+*/
+func ComposePortCBS(root *st.SchemaTree, deviceIp string, port *topology.Port, reservations []Reservation, limits Limits) (
+	shapers []Shaper, updates []*pb.Update, deletes []*pb.Path, err error) {
+
+	if !port.GetCapabilities().GetSupportsCbs() {
+		return nil, nil, nil, errors.New("port " + port.Name + " does not support the credit-based shaper")
+	}
+
+	shapers, err = ComputeShapers(uint(port.GetCapabilities().GetPortSpeed()), reservations, limits)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed computing the shapers of port %s: %w", port.Name, err)
+	}
+
+	shaped := map[uint]bool{}
+	for _, shaper := range shapers {
+		shaped[shaper.TrafficClass] = true
+		updates = append(updates, SetCreditBasedShaper(root, port.Name, deviceIp, shaper)...)
+	}
+	for _, trafficClass := range getShaperTrafficClasses(root, port.Name) {
+		if !shaped[trafficClass] {
+			deletes = append(deletes, DeleteCreditBasedShaper(root, port.Name, deviceIp, trafficClass))
+		}
+	}
+
+	return shapers, updates, deletes, nil
+}
+
+func ceilDiv(numerator uint, denominator uint) uint {
+	return (numerator + denominator - 1) / denominator
+}
@@ -0,0 +1,131 @@
+package cbs
+
+import (
+	"slices"
+	"testing"
+
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/topology"
+)
+
+func TestGetStreamBandwidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval *configuration.Interval
+		frames   uint32
+		want     uint
+	}{
+		// (1000 + 20) octets * 8 every ms
+		{name: "one frame every ms", interval: &configuration.Interval{Numerator: 1, Denominator: 1000}, frames: 1, want: 8160000},
+		{name: "two frames every ms", interval: &configuration.Interval{Numerator: 1, Denominator: 1000}, frames: 2, want: 16320000},
+		// 8160 bits every 7 s is 1165.7 bits/sec, rounded up
+		{name: "rounded up", interval: &configuration.Interval{Numerator: 7, Denominator: 1}, frames: 1, want: 1166},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trafficSpec := &configuration.TrafficSpecification{Interval: tt.interval, MaxFramesPerInterval: tt.frames, MaxFrameSize: 1000}
+			bandwidth, err := GetStreamBandwidth(trafficSpec)
+			if err != nil {
+				t.Fatalf("GetStreamBandwidth() error: %v", err)
+			}
+			if bandwidth != tt.want {
+				t.Errorf("GetStreamBandwidth() = %d bits/sec, want %d", bandwidth, tt.want)
+			}
+		})
+	}
+
+	if _, err := GetStreamBandwidth(&configuration.TrafficSpecification{MaxFramesPerInterval: 1, MaxFrameSize: 1000}); err == nil {
+		t.Errorf("GetStreamBandwidth() without interval gave no error")
+	}
+}
+
+func TestComputeShapers(t *testing.T) {
+	reservations := []Reservation{
+		{TrafficClass: 2, Bandwidth: 15000000, MaxFrameSize: 500},
+		{TrafficClass: 3, Bandwidth: 10000000, MaxFrameSize: 1000},
+		{TrafficClass: 2, Bandwidth: 5000000, MaxFrameSize: 300},
+	}
+
+	// At 100 Mbps, traffic class 3 first:
+	//	hi credit 3: 1522 * 8 * 10 / 100 = 1217.6 bits, lo credit 3: 1000 * 8 * -90 / 100 bits
+	//	hi credit 2: (1522 + 1000) * 8 * 20 / (100 - 10) = 4483.6 bits, lo credit 2: 500 * 8 * -80 / 100 bits
+	want := []Shaper{
+		{TrafficClass: 3, IdleSlope: 10000000, SendSlope: -90000000, HiCredit: 1218, LoCredit: -7200},
+		{TrafficClass: 2, IdleSlope: 20000000, SendSlope: -80000000, HiCredit: 4484, LoCredit: -3200},
+	}
+
+	shapers, err := ComputeShapers(100, reservations, DefaultLimits)
+	if err != nil {
+		t.Fatalf("ComputeShapers() error: %v", err)
+	}
+	if !slices.Equal(shapers, want) {
+		t.Errorf("ComputeShapers() = %+v, want %+v", shapers, want)
+	}
+}
+
+// The reservations of all traffic classes together may take 75% of the port speed
+func TestComputeShapersLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  Limits
+		second  uint // bits/sec, besides 50000000 in traffic class 3
+		wantErr bool
+	}{
+		{name: "at the limit", limits: DefaultLimits, second: 25000000},
+		{name: "above the limit", limits: DefaultLimits, second: 25000001, wantErr: true},
+		{name: "other limit", limits: Limits{MaxReservedPercent: 50, MaxInterferenceSize: 1522}, second: 1, wantErr: true},
+		{name: "nothing left for the other traffic classes", limits: Limits{MaxReservedPercent: 100, MaxInterferenceSize: 1522}, second: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservations := []Reservation{
+				{TrafficClass: 3, Bandwidth: 50000000, MaxFrameSize: 1000},
+				{TrafficClass: 2, Bandwidth: tt.second, MaxFrameSize: 1000},
+			}
+			if shapers, err := ComputeShapers(100, reservations, tt.limits); (err != nil) != tt.wantErr {
+				t.Errorf("ComputeShapers() = %+v, %v, want error %v", shapers, err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ComputeShapers(0, nil, DefaultLimits); err == nil {
+		t.Errorf("ComputeShapers() of a port without speed gave no error")
+	}
+}
+
+// A traffic class whose streams are gone loses its shaper
+func TestComposePortCBS(t *testing.T) {
+	root := &st.SchemaTree{Name: "data", Kind: st.KindContainer}
+	port := &topology.Port{Name: "sw0p1", Capabilities: &topology.InterfaceCapabilities{PortSpeed: 100, SupportsCbs: true}}
+
+	reservations := []Reservation{
+		{TrafficClass: 3, Bandwidth: 10000000, MaxFrameSize: 1000},
+		{TrafficClass: 2, Bandwidth: 20000000, MaxFrameSize: 500},
+	}
+	shapers, updates, deletes, err := ComposePortCBS(root, "10.0.0.1", port, reservations, DefaultLimits)
+	if err != nil {
+		t.Fatalf("ComposePortCBS() error: %v", err)
+	}
+	if len(shapers) != 2 || len(updates) != 10 || len(deletes) != 0 {
+		t.Errorf("ComposePortCBS() = %d shapers, %d updates and %d deletes, want 2 shapers of 5 updates each", len(shapers), len(updates), len(deletes))
+	}
+
+	_, updates, deletes, err = ComposePortCBS(root, "10.0.0.1", port, reservations[:1], DefaultLimits)
+	if err != nil {
+		t.Fatalf("ComposePortCBS() of traffic class 3 error: %v", err)
+	}
+	if len(updates) != 5 || len(deletes) != 1 {
+		t.Errorf("ComposePortCBS() of traffic class 3 = %d updates and %d deletes, want 5 and the shaper of traffic class 2 deleted", len(updates), len(deletes))
+	}
+	if trafficClasses := getShaperTrafficClasses(root, port.Name); !slices.Equal(trafficClasses, []uint{3}) {
+		t.Errorf("shapers at the port = %v, want [3]", trafficClasses)
+	}
+
+	port.Capabilities.SupportsCbs = false
+	if _, _, _, err := ComposePortCBS(root, "10.0.0.1", port, reservations, DefaultLimits); err == nil {
+		t.Errorf("ComposePortCBS() at a port without CBS gave no error")
+	}
+}
@@ -0,0 +1,153 @@
+package cbs
+
+/*
+Functions to set each value of the credit-based shaper of a traffic class
+*/
+
+import (
+	"fmt"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	"tsn-service/pkg/RAE/dataStructures/pbMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Get both the tree and the pb path to the shaper of one traffic class
+
+key parameters:
+
+	port, trafficClass
+*/
+func getShaperPath(root *st.SchemaTree, port string, trafficClass uint) (*st.SchemaTree, []*pb.PathElem) {
+	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
+	return path.GetParamKeys(bridgePathTree, bridgePathPb, "", "credit-based-shaper", map[string]string{"traffic-class": fmt.Sprint(trafficClass)})
+}
+
+/*
+Get the traffic classes that have a shaper at a port in the configuration of the device
+
+key parameters:
+
+	port
+*/
+func getShaperTrafficClasses(root *st.SchemaTree, port string) (trafficClasses []uint) {
+	bridgePathTree, _ := path.GetPath2Bridge(root, port)
+	for _, value := range st.GetAllKeyValues(bridgePathTree, "credit-based-shaper", "traffic-class") {
+		var trafficClass uint
+		if _, err := fmt.Sscan(value, &trafficClass); err == nil {
+			trafficClasses = append(trafficClasses, trafficClass)
+		}
+	}
+	return trafficClasses
+}
+
+/*
+Delete the shaper of one traffic class, the traffic class is sent without shaping again
+
+key parameters:
+
+	port, deviceIp, trafficClass
+*/
+func DeleteCreditBasedShaper(root *st.SchemaTree, port string, deviceIp string, trafficClass uint) (deletePath *pb.Path) {
+	pathTree, pathPb := getShaperPath(root, port, trafficClass)
+	st.RemoveFromParent(pathTree)
+	return pbMethods.GetDelete(deviceIp, pathPb)
+}
+
+/*
+	set traffic-class, the key of the shaper
+
+key parameters:
+
+	port, deviceIp, trafficClass
+*/
+func setTrafficClass(root *st.SchemaTree, port string, deviceIp string, trafficClass uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getShaperPath(root, port, trafficClass)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "traffic-class")
+
+	pathLvl2Tree.SetUint64(uint64(trafficClass))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(trafficClass))
+	return update
+}
+
+/*
+	set idle-slope, the rate the credit increases while frames wait (bits/sec)
+
+key parameters:
+
+	port, deviceIp, trafficClass
+
+Parameters to set:
+
+	idleSlope
+*/
+func setIdleSlope(root *st.SchemaTree, port string, deviceIp string, trafficClass uint, idleSlope uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getShaperPath(root, port, trafficClass)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "idle-slope")
+
+	pathLvl2Tree.SetUint64(uint64(idleSlope))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(idleSlope))
+	return update
+}
+
+/*
+	set send-slope, the rate the credit decreases while a frame is sent (bits/sec, negative)
+
+key parameters:
+
+	port, deviceIp, trafficClass
+
+Parameters to set:
+
+	sendSlope
+*/
+func setSendSlope(root *st.SchemaTree, port string, deviceIp string, trafficClass uint, sendSlope int) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getShaperPath(root, port, trafficClass)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "send-slope")
+
+	pathLvl2Tree.SetInt64(int64(sendSlope))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbIntTypeVal(sendSlope))
+	return update
+}
+
+/*
+	set hi-credit, the highest credit the traffic class can reach (bits)
+
+key parameters:
+
+	port, deviceIp, trafficClass
+
+Parameters to set:
+
+	hiCredit
+*/
+func setHiCredit(root *st.SchemaTree, port string, deviceIp string, trafficClass uint, hiCredit int) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getShaperPath(root, port, trafficClass)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "hi-credit")
+
+	pathLvl2Tree.SetInt64(int64(hiCredit))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbIntTypeVal(hiCredit))
+	return update
+}
+
+/*
+	set lo-credit, the lowest credit the traffic class can reach (bits, negative)
+
+key parameters:
+
+	port, deviceIp, trafficClass
+
+Parameters to set:
+
+	loCredit
+*/
+func setLoCredit(root *st.SchemaTree, port string, deviceIp string, trafficClass uint, loCredit int) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getShaperPath(root, port, trafficClass)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "lo-credit")
+
+	pathLvl2Tree.SetInt64(int64(loCredit))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbIntTypeVal(loCredit))
+	return update
+}
@@ -0,0 +1,283 @@
+package frer
+
+/*
+Compose the Frame Replication and Elimination for Reliability (FRER) configuration of a stream.
+A stream that requests seamless redundancy is sent over disjoint paths: the first bridge numbers and copies
+the frames (sequence generation and stream split), and the last bridge discards the duplicates (sequence recovery).
+
+	talker -> split bridge ==path 1==> merge bridge -> listener
+	                       ==path 2==>
+
+Ref:
+	IEEE 802.1CB-2017 7
+	IEEE 802.1CB-2017 10
+	IEEE 802.1Qcc-2018 46.2.3.6.1 (NumSeamlessTrees)
+	ieee802-dot1cb-frer.yang
+*/
+
+import (
+	"errors"
+	"fmt"
+	pe "tsn-service/pkg/PE"
+	"tsn-service/pkg/RAE/PSFP/streamIdTable"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/topology"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Values of the sequence recovery algorithm
+const (
+	VectorAlgorithm = "vector"
+	MatchAlgorithm  = "match"
+)
+
+// Encapsulation of the sequence number in the frames
+const RTagEncapsulation = "r-tag"
+
+// How the merge bridge decides which frames are duplicates
+type RecoveryParameters struct {
+	Algorithm     string // VectorAlgorithm or MatchAlgorithm
+	HistoryLength uint   // number of sequence numbers the vector algorithm remembers
+	ResetTimeout  uint   // ms without frames before the recovery function is reset
+}
+
+// Recovery parameters used when nothing else is configured, Ref: IEEE 802.1CB-2017 10.4.1
+var DefaultRecoveryParameters = RecoveryParameters{Algorithm: VectorAlgorithm, HistoryLength: 2, ResetTimeout: 1000}
+
+// The FRER configuration of a stream to one listener
+type StreamFRER struct {
+	Listener        uint32    // index of the listener in the request
+	Paths           []pe.Path // the disjoint paths, all start at the split bridge and end at the merge bridge
+	SplitDeviceIp   string
+	GenerationIndex uint   // index of the sequence generation function at the split bridge
+	InputHandle     uint   // stream handle of the stream received from the talker
+	OutputHandles   []uint // stream handle of the copy sent over each path
+	MergeDeviceIp   string
+	RecoveryIndex   uint // index of the sequence recovery function at the merge bridge
+	RecoveryHandle  uint // stream handle of the copies received at the merge bridge
+}
+
+// The identifiers in use on one device
+type deviceAllocator struct {
+	streamHandles     idAllocator
+	generationIndexes idAllocator
+	recoveryIndexes   idAllocator
+}
+
+func newDeviceAllocator(root *st.SchemaTree) *deviceAllocator {
+	return &deviceAllocator{
+		streamHandles:     newIdAllocator(streamIdTable.GetStreamHandles(root)),
+		generationIndexes: newIdAllocator(getFrerKeyValues(root, "sequence-generation", "index")),
+		recoveryIndexes:   newIdAllocator(getFrerKeyValues(root, "sequence-recovery", "index")),
+	}
+}
+
+/*
+Build the FRER configuration for every listener of a stream request that asks for more than one seamless tree
+
+input:
+
+	req: the stream request
+	topo: the topology the disjoint paths are computed in
+	roots: the configuration of each device, by device ip
+	recovery: the parameters of the sequence recovery at the merge bridges
+
+output:
+
+	entries: the paths and identifiers for each listener with redundancy
+	updates: the updates for the split and merge bridges
+*/
+func ComposeStreamFRER(req *configuration.Request, topo *topology.Topology, roots map[string]*st.SchemaTree,
+	recovery RecoveryParameters) (entries []StreamFRER, updates []*pb.Update, err error) {
+
+	if err = validateRecoveryParameters(recovery); err != nil {
+		return nil, nil, err
+	}
+
+	disjointPaths, err := pe.GetStreamDisjointPaths(topo, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	talker := req.GetTalker()
+
+	allocators := map[string]*deviceAllocator{}
+	getAllocator := func(deviceIp string) (*st.SchemaTree, *deviceAllocator, error) {
+		root, ok := roots[deviceIp]
+		if !ok {
+			return nil, nil, errors.New("no configuration for device " + deviceIp)
+		}
+		if _, ok := allocators[deviceIp]; !ok {
+			allocators[deviceIp] = newDeviceAllocator(root)
+		}
+		return root, allocators[deviceIp], nil
+	}
+
+	for _, listener := range req.ListenerList {
+		paths, ok := disjointPaths[listener.Index]
+		if !ok {
+			continue
+		}
+
+		entry := StreamFRER{Listener: listener.Index, Paths: paths}
+
+		splitUpdates, err := setSplit(&entry, talker, getAllocator)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed setting the stream split at %s: %w", paths[0][0].Node, err)
+		}
+
+		mergeUpdates, err := setMerge(&entry, talker, recovery, getAllocator)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed setting the sequence recovery at %s: %w", paths[0][len(paths[0])-1].Node, err)
+		}
+
+		entries = append(entries, entry)
+		updates = append(updates, splitUpdates...)
+		updates = append(updates, mergeUpdates...)
+	}
+
+	return entries, updates, nil
+}
+
+/*
+Remove the FRER configuration of a stream, the stream handles of the stream are taken out of the stream split and
+sequence identification entries it shares with other streams at the same ports
+
+input:
+
+	entries: what ComposeStreamFRER set for the stream
+	roots: the configuration of each device, by device ip
+
+output:
+
+	updates: the shared entries with the stream handles of the other streams
+	deletes: the paths to delete at the split and merge bridges
+*/
+func ReleaseStreamFRER(entries []StreamFRER, roots map[string]*st.SchemaTree) (updates []*pb.Update, deletes []*pb.Path, err error) {
+	for _, entry := range entries {
+		splitRoot, ok := roots[entry.SplitDeviceIp]
+		if !ok {
+			return nil, nil, errors.New("no configuration for device " + entry.SplitDeviceIp)
+		}
+		mergeRoot, ok := roots[entry.MergeDeviceIp]
+		if !ok {
+			return nil, nil, errors.New("no configuration for device " + entry.MergeDeviceIp)
+		}
+
+		split := entry.Paths[0][0]
+		deletes = append(deletes,
+			streamIdTable.DeleteStreamHandle(splitRoot, split.IngressPort, entry.SplitDeviceIp, fmt.Sprint(entry.InputHandle)),
+			DeleteSequenceGeneration(splitRoot, entry.SplitDeviceIp, entry.GenerationIndex))
+		splitUpdates, splitDeletes := RemoveStreamSplit(splitRoot, entry.SplitDeviceIp, split.IngressPort, false,
+			[]uint{entry.InputHandle}, entry.OutputHandles)
+		updates = append(updates, splitUpdates...)
+		deletes = append(deletes, splitDeletes...)
+
+		for i, path := range entry.Paths {
+			encodeUpdates, encodeDeletes := RemoveSequenceIdentification(splitRoot, entry.SplitDeviceIp, path[0].EgressPort, true,
+				[]uint{entry.OutputHandles[i]})
+			updates = append(updates, encodeUpdates...)
+			deletes = append(deletes, encodeDeletes...)
+
+			port := path[len(path)-1].IngressPort
+			deletes = append(deletes, streamIdTable.DeleteStreamHandle(mergeRoot, port, entry.MergeDeviceIp, fmt.Sprint(entry.RecoveryHandle)))
+			decodeUpdates, decodeDeletes := RemoveSequenceIdentification(mergeRoot, entry.MergeDeviceIp, port, true,
+				[]uint{entry.RecoveryHandle})
+			updates = append(updates, decodeUpdates...)
+			deletes = append(deletes, decodeDeletes...)
+		}
+
+		deletes = append(deletes, DeleteSequenceRecovery(mergeRoot, entry.MergeDeviceIp, entry.RecoveryIndex))
+	}
+	return updates, deletes, nil
+}
+
+/*
+Configure the first bridge of the paths: identify the stream from the talker, number its frames,
+copy it to one stream handle per path and add the R-TAG at each egress port
+*/
+func setSplit(entry *StreamFRER, talker *configuration.TalkerGroup,
+	getAllocator func(string) (*st.SchemaTree, *deviceAllocator, error)) (updates []*pb.Update, err error) {
+
+	split := entry.Paths[0][0]
+	root, allocator, err := getAllocator(split.DeviceIp)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.SplitDeviceIp = split.DeviceIp
+	entry.InputHandle = allocator.streamHandles.next()
+	entry.GenerationIndex = allocator.generationIndexes.next()
+	for range entry.Paths {
+		entry.OutputHandles = append(entry.OutputHandles, allocator.streamHandles.next())
+	}
+
+	streamHandle, err := streamIdTable.NewStreamHandles(fmt.Sprint(entry.InputHandle), []string{split.IngressPort},
+		talker.DataFrameSpecification)
+	if err != nil {
+		return nil, err
+	}
+	streamIdUpdates, err := streamIdTable.SetStreamHandle(root, streamHandle, split.IngressPort, split.DeviceIp)
+	if err != nil {
+		return nil, err
+	}
+	updates = append(updates, streamIdUpdates...)
+
+	updates = append(updates, SetSequenceGeneration(root, split.DeviceIp, entry.GenerationIndex, []uint{entry.InputHandle}, false)...)
+	updates = append(updates, SetStreamSplit(root, split.DeviceIp, split.IngressPort, false, []uint{entry.InputHandle}, entry.OutputHandles)...)
+
+	for i, path := range entry.Paths {
+		updates = append(updates, SetSequenceIdentification(root, split.DeviceIp, path[0].EgressPort, true,
+			[]uint{entry.OutputHandles[i]}, true)...)
+	}
+
+	return updates, nil
+}
+
+/*
+Configure the last bridge of the paths: identify the copies at each ingress port, read the R-TAG
+and discard the duplicates before the stream is sent to the listener
+*/
+func setMerge(entry *StreamFRER, talker *configuration.TalkerGroup, recovery RecoveryParameters,
+	getAllocator func(string) (*st.SchemaTree, *deviceAllocator, error)) (updates []*pb.Update, err error) {
+
+	merge := entry.Paths[0][len(entry.Paths[0])-1]
+	root, allocator, err := getAllocator(merge.DeviceIp)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.MergeDeviceIp = merge.DeviceIp
+	entry.RecoveryHandle = allocator.streamHandles.next()
+	entry.RecoveryIndex = allocator.recoveryIndexes.next()
+
+	var ingressPorts []string
+	for _, path := range entry.Paths {
+		ingressPorts = append(ingressPorts, path[len(path)-1].IngressPort)
+	}
+
+	streamHandle, err := streamIdTable.NewStreamHandles(fmt.Sprint(entry.RecoveryHandle), ingressPorts,
+		talker.DataFrameSpecification)
+	if err != nil {
+		return nil, err
+	}
+	for _, port := range ingressPorts {
+		streamIdUpdates, err := streamIdTable.SetStreamHandle(root, streamHandle, port, merge.DeviceIp)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, streamIdUpdates...)
+
+		updates = append(updates, SetSequenceIdentification(root, merge.DeviceIp, port, true, []uint{entry.RecoveryHandle}, false)...)
+	}
+
+	recoveryUpdates, err := SetSequenceRecovery(root, merge.DeviceIp, entry.RecoveryIndex, []uint{entry.RecoveryHandle},
+		[]string{merge.EgressPort}, true, recovery)
+	if err != nil {
+		return nil, err
+	}
+	updates = append(updates, recoveryUpdates...)
+
+	return updates, nil
+}
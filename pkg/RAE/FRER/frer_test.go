@@ -0,0 +1,79 @@
+package frer
+
+import (
+	"fmt"
+	"testing"
+
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+)
+
+// Two streams that are split at the same port and sent at the same egress port share the entries of the port
+func TestTwoStreamsOnOnePort(t *testing.T) {
+	root := &st.SchemaTree{Name: "data", Kind: st.KindContainer}
+
+	SetStreamSplit(root, "10.0.0.1", "sw0p1", false, []uint{1}, []uint{2, 3})
+	SetSequenceIdentification(root, "10.0.0.1", "sw0p2", true, []uint{2}, true)
+	updates := SetStreamSplit(root, "10.0.0.1", "sw0p1", false, []uint{4}, []uint{5, 6})
+	SetSequenceIdentification(root, "10.0.0.1", "sw0p2", true, []uint{5}, true)
+
+	splitTree, _ := getStreamSplitPath(root, "sw0p1", false)
+	identificationTree, _ := getSequenceIdentificationPath(root, "sw0p2", true)
+	tests := []struct {
+		name  string
+		entry *st.SchemaTree
+		list  string
+		want  []string
+	}{
+		{name: "split input", entry: splitTree, list: "input-id", want: []string{"1", "4"}},
+		{name: "split output", entry: splitTree, list: "output-id", want: []string{"2", "3", "5", "6"}},
+		{name: "sequence identification", entry: identificationTree, list: "stream", want: []string{"2", "5"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if values := st.GetLeafList(tt.entry, tt.list); fmt.Sprint(values) != fmt.Sprint(tt.want) {
+				t.Errorf("%s = %v, want %v", tt.list, values, tt.want)
+			}
+		})
+	}
+
+	// The update of the second stream holds the handles of both streams
+	for _, update := range updates {
+		elems := update.GetPath().GetElem()
+		if elems[len(elems)-1].GetName() != "input-id" {
+			continue
+		}
+		if n := len(update.GetVal().GetLeaflistVal().GetElement()); n != 2 {
+			t.Errorf("input-id update has %d values, want 2", n)
+		}
+	}
+
+	// Removing the first stream keeps the entries of the second
+	updates, deletes := RemoveStreamSplit(root, "10.0.0.1", "sw0p1", false, []uint{1}, []uint{2, 3})
+	if values := st.GetLeafList(splitTree, "input-id"); fmt.Sprint(values) != "[4]" {
+		t.Errorf("input-id after removing the first stream = %v, want [4]", values)
+	}
+	if values := st.GetLeafList(splitTree, "output-id"); fmt.Sprint(values) != "[5 6]" {
+		t.Errorf("output-id after removing the first stream = %v, want [5 6]", values)
+	}
+	if len(updates) != 2 || len(deletes) != 2 {
+		t.Errorf("RemoveStreamSplit() = %d updates and %d deletes, want 2 leaf-lists deleted and set again", len(updates), len(deletes))
+	}
+
+	// Removing the last stream deletes the entry
+	updates, deletes = RemoveSequenceIdentification(root, "10.0.0.1", "sw0p2", true, []uint{2})
+	if len(updates) != 1 || len(deletes) != 1 {
+		t.Errorf("RemoveSequenceIdentification() of the first stream = %d updates and %d deletes, want 1 and 1", len(updates), len(deletes))
+	}
+	frerTree := st.OneLvlDown0Keys(root, frerContainer)
+	if entries := st.GetAllKeyValues(frerTree, "sequence-identification", "port"); len(entries) != 1 {
+		t.Errorf("sequence identification entries with one stream left: %v, want [sw0p2]", entries)
+	}
+
+	updates, deletes = RemoveSequenceIdentification(root, "10.0.0.1", "sw0p2", true, []uint{5})
+	if len(updates) != 0 || len(deletes) != 1 {
+		t.Errorf("RemoveSequenceIdentification() of the last stream = %d updates and %d deletes, want only the entry deleted", len(updates), len(deletes))
+	}
+	if entries := st.GetAllKeyValues(frerTree, "sequence-identification", "port"); len(entries) != 0 {
+		t.Errorf("sequence identification entries after removing both streams: %v, want none", entries)
+	}
+}
@@ -0,0 +1,118 @@
+package frer
+
+/*
+Functions to set the sequence generation function, which numbers the frames of a stream
+Ref: IEEE 802.1CB-2017 7.4.1, 10.3
+*/
+
+import (
+	"fmt"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	"tsn-service/pkg/RAE/dataStructures/pbMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Set an entry in the sequence generation table
+
+key parameters:
+
+	deviceIp
+	index: identifier of the sequence generation function
+
+Parameters to set:
+
+	streams: the stream handles the function numbers
+	directionOutFacing: true if the function is on the out-facing side of the bridge port
+*/
+func SetSequenceGeneration(root *st.SchemaTree, deviceIp string, index uint, streams []uint, directionOutFacing bool) (updates []*pb.Update) {
+	updates = append(updates, setSequenceGenerationIndex(root, deviceIp, index, index))
+	updates = append(updates, setSequenceGenerationStreams(root, deviceIp, index, streams)...)
+	updates = append(updates, setSequenceGenerationDirection(root, deviceIp, index, directionOutFacing))
+	updates = append(updates, setSequenceGenerationReset(root, deviceIp, index, false))
+	return updates
+}
+
+/*
+Delete an entry of the sequence generation table, the index is free to use again
+
+key parameters:
+
+	deviceIp, index
+*/
+func DeleteSequenceGeneration(root *st.SchemaTree, deviceIp string, index uint) (deletePath *pb.Path) {
+	entryTree, entryPb := getSequenceGenerationPath(root, index)
+	st.RemoveFromParent(entryTree)
+	return pbMethods.GetDelete(deviceIp, entryPb)
+}
+
+/*
+Get both the tree and the pb path to one entry of the sequence generation table
+*/
+func getSequenceGenerationPath(root *st.SchemaTree, index uint) (*st.SchemaTree, []*pb.PathElem) {
+	frerTree, frerPb := getFrerPath(root)
+	return path.GetParamKeys(frerTree, frerPb, "", "sequence-generation", map[string]string{"index": fmt.Sprint(index)})
+}
+
+/*
+Set index, the key of the sequence generation entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceGenerationIndex(root *st.SchemaTree, deviceIp string, index uint, value uint) (update *pb.Update) {
+	entryTree, entryPb := getSequenceGenerationPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "index")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set the stream handle list of the sequence generation entry, a leaf-list that is set as one
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceGenerationStreams(root *st.SchemaTree, deviceIp string, index uint, values []uint) (updates []*pb.Update) {
+	entryTree, entryPb := getSequenceGenerationPath(root, index)
+	_, pathPb := path.SetParamLeafList(entryTree, entryPb, "stream", getUintStrings(values))
+	return []*pb.Update{pbMethods.GetTypedValUpdate(deviceIp, pathPb, pbMethods.GetPbUintLeafListTypeVal(values))}
+}
+
+/*
+Set direction-out-facing of the sequence generation entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceGenerationDirection(root *st.SchemaTree, deviceIp string, index uint, value bool) (update *pb.Update) {
+	entryTree, entryPb := getSequenceGenerationPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "direction-out-facing")
+
+	pathTree.SetBool(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(value))
+	return update
+}
+
+/*
+Set reset of the sequence generation entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceGenerationReset(root *st.SchemaTree, deviceIp string, index uint, value bool) (update *pb.Update) {
+	entryTree, entryPb := getSequenceGenerationPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "reset")
+
+	pathTree.SetBool(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(value))
+	return update
+}
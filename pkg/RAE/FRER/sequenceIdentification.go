@@ -0,0 +1,149 @@
+package frer
+
+/*
+Functions to set the sequence encode/decode function, which adds or reads the R-TAG with the sequence number
+Ref: IEEE 802.1CB-2017 7.6, 10.5
+*/
+
+import (
+	"fmt"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	"tsn-service/pkg/RAE/dataStructures/pbMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Set an entry in the sequence identification table
+
+key parameters:
+
+	deviceIp, port
+	directionOutFacing: true if the function is on the out-facing side of the bridge port
+
+Parameters to set:
+
+	streams: the stream handles the function is for
+	active: true to encode the sequence number into the frames, false to only decode it
+*/
+func SetSequenceIdentification(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, streams []uint, active bool) (updates []*pb.Update) {
+	updates = append(updates, setSequenceIdentificationPort(root, deviceIp, port, directionOutFacing, port))
+	updates = append(updates, setSequenceIdentificationDirection(root, deviceIp, port, directionOutFacing, directionOutFacing))
+	updates = append(updates, setSequenceIdentificationStreams(root, deviceIp, port, directionOutFacing, streams)...)
+	updates = append(updates, setSequenceIdentificationActive(root, deviceIp, port, directionOutFacing, active))
+	updates = append(updates, setSequenceIdentificationEncapsulation(root, deviceIp, port, directionOutFacing, RTagEncapsulation))
+	return updates
+}
+
+/*
+Remove the stream handles of a stream from the sequence identification entry it shares with other streams,
+the entry is deleted when no stream handle is left
+
+key parameters:
+
+	deviceIp, port
+	directionOutFacing: true if the function is on the out-facing side of the bridge port
+
+Parameters to remove:
+
+	streams: the stream handles of the stream
+*/
+func RemoveSequenceIdentification(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, streams []uint) (
+	updates []*pb.Update, deletes []*pb.Path) {
+
+	entryTree, entryPb := getSequenceIdentificationPath(root, port, directionOutFacing)
+	streams = getRemainingHandles(entryTree, "stream", streams)
+	if len(streams) == 0 {
+		st.RemoveFromParent(entryTree)
+		return nil, []*pb.Path{pbMethods.GetDelete(deviceIp, entryPb)}
+	}
+	return setRemainingHandles(entryTree, entryPb, deviceIp, "stream", streams)
+}
+
+/*
+Get both the tree and the pb path to one entry of the sequence identification table
+*/
+func getSequenceIdentificationPath(root *st.SchemaTree, port string, directionOutFacing bool) (*st.SchemaTree, []*pb.PathElem) {
+	frerTree, frerPb := getFrerPath(root)
+	return path.GetParamKeys(frerTree, frerPb, "", "sequence-identification", map[string]string{"port": port, "direction-out-facing": fmt.Sprint(directionOutFacing)})
+}
+
+/*
+Set port, the first key of the sequence identification entry
+
+key parameters:
+
+	deviceIp, port, directionOutFacing
+*/
+func setSequenceIdentificationPort(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, value string) (update *pb.Update) {
+	entryTree, entryPb := getSequenceIdentificationPath(root, port, directionOutFacing)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "port")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set direction-out-facing, the second key of the sequence identification entry
+
+key parameters:
+
+	deviceIp, port, directionOutFacing
+*/
+func setSequenceIdentificationDirection(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, value bool) (update *pb.Update) {
+	entryTree, entryPb := getSequenceIdentificationPath(root, port, directionOutFacing)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "direction-out-facing")
+
+	pathTree.SetBool(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(value))
+	return update
+}
+
+/*
+Add stream handles to the stream handle list of the sequence identification entry, a leaf-list that is set as one
+The streams that pass the same port share the entry, the handles already in the list stay
+
+key parameters:
+
+	deviceIp, port, directionOutFacing
+*/
+func setSequenceIdentificationStreams(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, values []uint) (updates []*pb.Update) {
+	entryTree, entryPb := getSequenceIdentificationPath(root, port, directionOutFacing)
+	values = getMergedHandles(entryTree, "stream", values)
+	_, pathPb := path.SetParamLeafList(entryTree, entryPb, "stream", getUintStrings(values))
+	return []*pb.Update{pbMethods.GetTypedValUpdate(deviceIp, pathPb, pbMethods.GetPbUintLeafListTypeVal(values))}
+}
+
+/*
+Set active of the sequence identification entry
+
+key parameters:
+
+	deviceIp, port, directionOutFacing
+*/
+func setSequenceIdentificationActive(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, value bool) (update *pb.Update) {
+	entryTree, entryPb := getSequenceIdentificationPath(root, port, directionOutFacing)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "active")
+
+	pathTree.SetBool(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(value))
+	return update
+}
+
+/*
+Set encapsulation of the sequence identification entry
+
+key parameters:
+
+	deviceIp, port, directionOutFacing
+*/
+func setSequenceIdentificationEncapsulation(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, value string) (update *pb.Update) {
+	entryTree, entryPb := getSequenceIdentificationPath(root, port, directionOutFacing)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "encapsulation")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
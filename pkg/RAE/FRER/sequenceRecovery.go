@@ -0,0 +1,242 @@
+package frer
+
+/*
+Functions to set the sequence recovery function, which discards the duplicate frames of a stream
+Ref: IEEE 802.1CB-2017 7.4.3, 10.4
+*/
+
+import (
+	"fmt"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	"tsn-service/pkg/RAE/dataStructures/pbMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Set an entry in the sequence recovery table
+
+key parameters:
+
+	deviceIp
+	index: identifier of the sequence recovery function
+
+Parameters to set:
+
+	streams: the stream handles the function recovers
+	ports: the ports the recovered stream is transmitted at
+	directionOutFacing: true if the function is on the out-facing side of the bridge port
+	recovery: algorithm, history length and reset timeout
+*/
+func SetSequenceRecovery(root *st.SchemaTree, deviceIp string, index uint, streams []uint, ports []string,
+	directionOutFacing bool, recovery RecoveryParameters) (updates []*pb.Update, err error) {
+
+	if err = validateRecoveryParameters(recovery); err != nil {
+		return nil, err
+	}
+
+	updates = append(updates, setSequenceRecoveryIndex(root, deviceIp, index, index))
+	updates = append(updates, setSequenceRecoveryStreams(root, deviceIp, index, streams)...)
+	updates = append(updates, setSequenceRecoveryPorts(root, deviceIp, index, ports)...)
+	updates = append(updates, setSequenceRecoveryDirection(root, deviceIp, index, directionOutFacing))
+	updates = append(updates, setSequenceRecoveryReset(root, deviceIp, index, false))
+	updates = append(updates, setSequenceRecoveryAlgorithm(root, deviceIp, index, recovery.Algorithm))
+	updates = append(updates, setSequenceRecoveryHistoryLength(root, deviceIp, index, recovery.HistoryLength))
+	updates = append(updates, setSequenceRecoveryResetTimeout(root, deviceIp, index, recovery.ResetTimeout))
+	updates = append(updates, setSequenceRecoveryTakeNoSequence(root, deviceIp, index, false))
+	updates = append(updates, setSequenceRecoveryIndividualRecovery(root, deviceIp, index, false))
+	updates = append(updates, setSequenceRecoveryLatentErrorDetection(root, deviceIp, index, false))
+	return updates, nil
+}
+
+/*
+Delete an entry of the sequence recovery table, the index is free to use again
+
+key parameters:
+
+	deviceIp, index
+*/
+func DeleteSequenceRecovery(root *st.SchemaTree, deviceIp string, index uint) (deletePath *pb.Path) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	st.RemoveFromParent(entryTree)
+	return pbMethods.GetDelete(deviceIp, entryPb)
+}
+
+/*
+Get both the tree and the pb path to one entry of the sequence recovery table
+*/
+func getSequenceRecoveryPath(root *st.SchemaTree, index uint) (*st.SchemaTree, []*pb.PathElem) {
+	frerTree, frerPb := getFrerPath(root)
+	return path.GetParamKeys(frerTree, frerPb, "", "sequence-recovery", map[string]string{"index": fmt.Sprint(index)})
+}
+
+/*
+Set index, the key of the sequence recovery entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryIndex(root *st.SchemaTree, deviceIp string, index uint, value uint) (update *pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "index")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set the stream handle list of the sequence recovery entry, a leaf-list that is set as one
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryStreams(root *st.SchemaTree, deviceIp string, index uint, values []uint) (updates []*pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	_, pathPb := path.SetParamLeafList(entryTree, entryPb, "stream", getUintStrings(values))
+	return []*pb.Update{pbMethods.GetTypedValUpdate(deviceIp, pathPb, pbMethods.GetPbUintLeafListTypeVal(values))}
+}
+
+/*
+Set the port list of the sequence recovery entry, a leaf-list that is set as one
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryPorts(root *st.SchemaTree, deviceIp string, index uint, values []string) (updates []*pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	_, pathPb := path.SetParamLeafList(entryTree, entryPb, "port", values)
+	return []*pb.Update{pbMethods.GetTypedValUpdate(deviceIp, pathPb, pbMethods.GetPbStringLeafListTypeVal(values))}
+}
+
+/*
+Set direction-out-facing of the sequence recovery entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryDirection(root *st.SchemaTree, deviceIp string, index uint, value bool) (update *pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "direction-out-facing")
+
+	pathTree.SetBool(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(value))
+	return update
+}
+
+/*
+Set reset of the sequence recovery entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryReset(root *st.SchemaTree, deviceIp string, index uint, value bool) (update *pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "reset")
+
+	pathTree.SetBool(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(value))
+	return update
+}
+
+/*
+Set algorithm (vector or match) of the sequence recovery entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryAlgorithm(root *st.SchemaTree, deviceIp string, index uint, value string) (update *pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "algorithm")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set history-length, the number of sequence numbers the vector algorithm remembers of the sequence recovery entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryHistoryLength(root *st.SchemaTree, deviceIp string, index uint, value uint) (update *pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "history-length")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set reset-timeout (ms) of the sequence recovery entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryResetTimeout(root *st.SchemaTree, deviceIp string, index uint, value uint) (update *pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "reset-timeout")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set take-no-sequence of the sequence recovery entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryTakeNoSequence(root *st.SchemaTree, deviceIp string, index uint, value bool) (update *pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "take-no-sequence")
+
+	pathTree.SetBool(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(value))
+	return update
+}
+
+/*
+Set individual-recovery of the sequence recovery entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryIndividualRecovery(root *st.SchemaTree, deviceIp string, index uint, value bool) (update *pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "individual-recovery")
+
+	pathTree.SetBool(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(value))
+	return update
+}
+
+/*
+Set latent-error-detection of the sequence recovery entry
+
+key parameters:
+
+	deviceIp, index
+*/
+func setSequenceRecoveryLatentErrorDetection(root *st.SchemaTree, deviceIp string, index uint, value bool) (update *pb.Update) {
+	entryTree, entryPb := getSequenceRecoveryPath(root, index)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "latent-error-detection")
+
+	pathTree.SetBool(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(value))
+	return update
+}
@@ -0,0 +1,136 @@
+package frer
+
+/*
+Functions to set the stream split function, which copies a stream to several stream handles
+Ref: IEEE 802.1CB-2017 7.7, 10.8
+*/
+
+import (
+	"fmt"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	"tsn-service/pkg/RAE/dataStructures/pbMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Set an entry in the stream split table
+
+key parameters:
+
+	deviceIp, port
+	directionOutFacing: true if the function is on the out-facing side of the bridge port
+
+Parameters to set:
+
+	inputIds: the stream handles that are split
+	outputIds: the stream handles of the copies
+*/
+func SetStreamSplit(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, inputIds []uint, outputIds []uint) (updates []*pb.Update) {
+	updates = append(updates, setStreamSplitPort(root, deviceIp, port, directionOutFacing, port))
+	updates = append(updates, setStreamSplitDirection(root, deviceIp, port, directionOutFacing, directionOutFacing))
+	updates = append(updates, setStreamSplitInputIds(root, deviceIp, port, directionOutFacing, inputIds)...)
+	updates = append(updates, setStreamSplitOutputIds(root, deviceIp, port, directionOutFacing, outputIds)...)
+	return updates
+}
+
+/*
+Remove the stream handles of a stream from the stream split entry it shares with other streams,
+the entry is deleted when no input stream handle is left
+
+key parameters:
+
+	deviceIp, port
+	directionOutFacing: true if the function is on the out-facing side of the bridge port
+
+Parameters to remove:
+
+	inputIds: the stream handles that were split
+	outputIds: the stream handles of the copies
+*/
+func RemoveStreamSplit(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, inputIds []uint, outputIds []uint) (
+	updates []*pb.Update, deletes []*pb.Path) {
+
+	entryTree, entryPb := getStreamSplitPath(root, port, directionOutFacing)
+	inputIds = getRemainingHandles(entryTree, "input-id", inputIds)
+	outputIds = getRemainingHandles(entryTree, "output-id", outputIds)
+	if len(inputIds) == 0 {
+		st.RemoveFromParent(entryTree)
+		return nil, []*pb.Path{pbMethods.GetDelete(deviceIp, entryPb)}
+	}
+
+	inputUpdates, inputDeletes := setRemainingHandles(entryTree, entryPb, deviceIp, "input-id", inputIds)
+	outputUpdates, outputDeletes := setRemainingHandles(entryTree, entryPb, deviceIp, "output-id", outputIds)
+	return append(inputUpdates, outputUpdates...), append(inputDeletes, outputDeletes...)
+}
+
+/*
+Get both the tree and the pb path to one entry of the stream split table
+*/
+func getStreamSplitPath(root *st.SchemaTree, port string, directionOutFacing bool) (*st.SchemaTree, []*pb.PathElem) {
+	frerTree, frerPb := getFrerPath(root)
+	return path.GetParamKeys(frerTree, frerPb, "", "stream-split", map[string]string{"port": port, "direction-out-facing": fmt.Sprint(directionOutFacing)})
+}
+
+/*
+Set port, the first key of the stream split entry
+
+key parameters:
+
+	deviceIp, port, directionOutFacing
+*/
+func setStreamSplitPort(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, value string) (update *pb.Update) {
+	entryTree, entryPb := getStreamSplitPath(root, port, directionOutFacing)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "port")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set direction-out-facing, the second key of the stream split entry
+
+key parameters:
+
+	deviceIp, port, directionOutFacing
+*/
+func setStreamSplitDirection(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, value bool) (update *pb.Update) {
+	entryTree, entryPb := getStreamSplitPath(root, port, directionOutFacing)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "direction-out-facing")
+
+	pathTree.SetBool(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(value))
+	return update
+}
+
+/*
+Add stream handles to the input stream handle list of the stream split entry, a leaf-list that is set as one
+The streams split at the same port share the entry, the handles already in the list stay
+
+key parameters:
+
+	deviceIp, port, directionOutFacing
+*/
+func setStreamSplitInputIds(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, values []uint) (updates []*pb.Update) {
+	entryTree, entryPb := getStreamSplitPath(root, port, directionOutFacing)
+	values = getMergedHandles(entryTree, "input-id", values)
+	_, pathPb := path.SetParamLeafList(entryTree, entryPb, "input-id", getUintStrings(values))
+	return []*pb.Update{pbMethods.GetTypedValUpdate(deviceIp, pathPb, pbMethods.GetPbUintLeafListTypeVal(values))}
+}
+
+/*
+Add stream handles to the output stream handle list of the stream split entry, a leaf-list that is set as one
+The streams split at the same port share the entry, the handles already in the list stay
+
+key parameters:
+
+	deviceIp, port, directionOutFacing
+*/
+func setStreamSplitOutputIds(root *st.SchemaTree, deviceIp string, port string, directionOutFacing bool, values []uint) (updates []*pb.Update) {
+	entryTree, entryPb := getStreamSplitPath(root, port, directionOutFacing)
+	values = getMergedHandles(entryTree, "output-id", values)
+	_, pathPb := path.SetParamLeafList(entryTree, entryPb, "output-id", getUintStrings(values))
+	return []*pb.Update{pbMethods.GetTypedValUpdate(deviceIp, pathPb, pbMethods.GetPbUintLeafListTypeVal(values))}
+}
@@ -0,0 +1,139 @@
+package frer
+
+/*
+Help functions for frer.go
+*/
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	"tsn-service/pkg/RAE/dataStructures/pbMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// The frer container of a device, in the namespace of the ieee802-dot1cb-frer module
+const frerContainer = "ieee802-dot1cb-frer:frer"
+
+/*
+Get both the tree and the pb path to the frer container of the device
+*/
+func getFrerPath(root *st.SchemaTree) (*st.SchemaTree, []*pb.PathElem) {
+	return path.GetParam0Keys(root, nil, frerContainer)
+}
+
+/*
+Get the values of the key of all entries in one of the lists of the frer container
+
+key parameters:
+
+	root: The schema tree of the device
+	list, key: the list and the name of its key
+*/
+func getFrerKeyValues(root *st.SchemaTree, list string, key string) (ids []uint) {
+	frerTree := st.OneLvlDown0Keys(root, frerContainer)
+	for _, id := range st.GetAllKeyValues(frerTree, list, key) {
+		value, err := strconv.ParseUint(id, 10, 32)
+		if err == nil {
+			ids = append(ids, uint(value))
+		}
+	}
+	return ids
+}
+
+// Hands out the lowest identifiers that are not used yet
+type idAllocator map[uint]bool
+
+func newIdAllocator(used []uint) idAllocator {
+	allocator := idAllocator{}
+	for _, id := range used {
+		allocator[id] = true
+	}
+	return allocator
+}
+
+// Get the lowest free identifier and mark it as used, identifiers start at 1
+func (allocator idAllocator) next() uint {
+	id := uint(1)
+	for allocator[id] {
+		id++
+	}
+	allocator[id] = true
+	return id
+}
+
+/*
+Check that the sequence recovery function can be configured with the parameters
+*/
+func validateRecoveryParameters(recovery RecoveryParameters) error {
+	if recovery.Algorithm != VectorAlgorithm && recovery.Algorithm != MatchAlgorithm {
+		return errors.New("Invalid sequence recovery algorithm. Value: " + recovery.Algorithm +
+			". Must be " + VectorAlgorithm + " or " + MatchAlgorithm)
+	}
+	if recovery.Algorithm == VectorAlgorithm && recovery.HistoryLength < 2 {
+		return errors.New("Invalid history length. Value: " + strconv.FormatUint(uint64(recovery.HistoryLength), 10) +
+			". The vector recovery algorithm needs a history length of at least 2")
+	}
+	if recovery.ResetTimeout == 0 {
+		return errors.New("Invalid reset timeout. The reset timeout must be larger than 0")
+	}
+	return nil
+}
+
+// Get the values of a leaf-list of stream handles as the text of the tree
+func getUintStrings(values []uint) (texts []string) {
+	for _, value := range values {
+		texts = append(texts, strconv.FormatUint(uint64(value), 10))
+	}
+	return texts
+}
+
+// Get the stream handles of a leaf-list of an entry
+func getHandles(entryTree *st.SchemaTree, name string) (handles []uint) {
+	for _, text := range st.GetLeafList(entryTree, name) {
+		value, err := strconv.ParseUint(text, 10, 32)
+		if err == nil {
+			handles = append(handles, uint(value))
+		}
+	}
+	return handles
+}
+
+// Get the stream handles of a leaf-list of an entry with the new handles added, the entry can be shared by several streams
+func getMergedHandles(entryTree *st.SchemaTree, name string, values []uint) (handles []uint) {
+	handles = getHandles(entryTree, name)
+	for _, value := range values {
+		if !slices.Contains(handles, value) {
+			handles = append(handles, value)
+		}
+	}
+	return handles
+}
+
+// Get the stream handles of a leaf-list of an entry without the handles of a stream that is removed
+func getRemainingHandles(entryTree *st.SchemaTree, name string, values []uint) (handles []uint) {
+	for _, handle := range getHandles(entryTree, name) {
+		if !slices.Contains(values, handle) {
+			handles = append(handles, handle)
+		}
+	}
+	return handles
+}
+
+/*
+Set a leaf-list of stream handles of a shared entry to the handles that remain after a stream is removed
+The leaf-list is deleted and set again, so the devices do not keep the removed handles when an update adds to a leaf-list
+*/
+func setRemainingHandles(entryTree *st.SchemaTree, entryPb []*pb.PathElem, deviceIp string, name string, values []uint) (
+	updates []*pb.Update, deletes []*pb.Path) {
+
+	_, pathPb := path.SetParamLeafList(entryTree, entryPb, name, getUintStrings(values))
+	deletes = append(deletes, pbMethods.GetDelete(deviceIp, pathPb))
+	if len(values) > 0 {
+		updates = append(updates, pbMethods.GetTypedValUpdate(deviceIp, pathPb, pbMethods.GetPbUintLeafListTypeVal(values)))
+	}
+	return updates, deletes
+}
@@ -0,0 +1,121 @@
+package framepreemption
+
+/*
+Set which priorities are sent as express frames and which can be preempted, and compute the guard band
+that is needed before a window of the time-aware shaper
+
+Ref:
+	IEEE 802.1Q-2018 6.7.2 (frame preemption)
+	IEEE 802.1Q-2018 12.30.1 (frame preemption status table)
+	IEEE 802.3br-2016 99 (MAC merge sublayer)
+	ieee802-dot1q-preemption.yang
+*/
+
+import (
+	"errors"
+	"fmt"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Values of frame-preemption-status
+const (
+	Express     = "express"
+	Preemptable = "preemptable"
+)
+
+// Traffic classes of the schedule that are sent as express frames, all other traffic classes are preemptable
+var ExpressTrafficClasses = map[string]bool{
+	"isochronous":     true,
+	"cyclic-sync":     true,
+	"cyclic-async":    true,
+	"network-control": true,
+}
+
+// Largest frame on the wire (octets), 1522 octets frame with preamble, start frame delimiter and inter frame gap
+const MaxFrameOnWire = 1542
+
+// Largest part of a preemptable frame that can not be preempted (octets), Ref: IEEE 802.3br-2016 99.4.4
+const MaxNonPreemptableFragment = 143
+
+// Octets a frame takes on the wire besides its payload: header, vlan tag and FCS (22), preamble, start frame delimiter and inter frame gap (20)
+const FrameOverhead = 42
+
+/*
+Get the largest frame on the wire (octets) at a port
+
+input:
+
+	mtu: the maximum transmission unit of the port (octets), MaxFrameOnWire is used if it is 0 (not known)
+*/
+func GetMaxFrameOnWire(mtu uint) uint64 {
+	if mtu == 0 {
+		return MaxFrameOnWire
+	}
+	return uint64(mtu) + FrameOverhead
+}
+
+/*
+Get the frame preemption status of a traffic class of the schedule
+*/
+func GetPreemptionStatus(trafficClassName string) string {
+	if ExpressTrafficClasses[trafficClassName] {
+		return Express
+	}
+	return Preemptable
+}
+
+/*
+Get the guard band that is needed before a window so a frame started before it does not run into it
+
+input:
+
+	portSpeed: speed of the port (Mbps)
+	mtu: the maximum transmission unit of the port (octets), 0 if it is not known
+	preemption: true if the frames before the window can be preempted, then only the part that can not be preempted is waited for
+
+output:
+
+	guardBand: ns
+*/
+func GetGuardBand(portSpeed uint, mtu uint, preemption bool) (uint64, error) {
+	if portSpeed == 0 {
+		return 0, errors.New("the port speed must be larger than 0")
+	}
+
+	octets := GetMaxFrameOnWire(mtu)
+	if preemption {
+		octets = min(octets, MaxNonPreemptableFragment)
+	}
+
+	// bits * 1000 / Mbps = ns, rounded up
+	return (octets*8*1000 + uint64(portSpeed) - 1) / uint64(portSpeed), nil
+}
+
+/*
+Set the frame preemption status of every priority at a port
+
+key parameters:
+
+	port, deviceIp
+
+Parameters to set:
+
+	statuses: Express or Preemptable, the index is the priority (0-7)
+*/
+func SetFramePreemptionStatusTable(root *st.SchemaTree, port string, deviceIp string, statuses []string) (updates []*pb.Update, err error) {
+	if len(statuses) != 8 {
+		return nil, errors.New("Must be a frame preemption status for each of the 8 priorities, got " + fmt.Sprint(len(statuses)))
+	}
+
+	for priority, status := range statuses {
+		if status != Express && status != Preemptable {
+			return nil, errors.New("Invalid frame preemption status. Value: " + status + ". Must be " + Express + " or " + Preemptable)
+		}
+		updates = append(updates, setPriority(root, port, deviceIp, uint(priority)))
+		updates = append(updates, setFramePreemptionStatus(root, port, deviceIp, uint(priority), status))
+	}
+
+	return updates, nil
+}
@@ -0,0 +1,63 @@
+package framepreemption
+
+/*
+Functions to set each value in the frame preemption status table
+*/
+
+import (
+	"fmt"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	"tsn-service/pkg/RAE/dataStructures/pbMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Get both the tree and the pb path to the entry of one priority in the frame preemption status table
+
+key parameters:
+
+	port, priority
+*/
+func getFramePreemptionStatusPath(root *st.SchemaTree, port string, priority uint) (*st.SchemaTree, []*pb.PathElem) {
+	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
+	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "frame-preemption-parameters")
+	return path.GetParamKeys(pathLvl1Tree, pathLvl1Pb, "", "frame-preemption-status-table", map[string]string{"priority": fmt.Sprint(priority)})
+}
+
+/*
+	set priority, the key of the entry in the frame preemption status table
+
+key parameters:
+
+	port, deviceIp, priority
+*/
+func setPriority(root *st.SchemaTree, port string, deviceIp string, priority uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFramePreemptionStatusPath(root, port, priority)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "priority")
+
+	pathLvl2Tree.SetUint64(uint64(priority))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(priority))
+	return update
+}
+
+/*
+	set frame-preemption-status of a priority
+
+key parameters:
+
+	port, deviceIp, priority
+
+Parameters to set:
+
+	status: express or preemptable
+*/
+func setFramePreemptionStatus(root *st.SchemaTree, port string, deviceIp string, priority uint, status string) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFramePreemptionStatusPath(root, port, priority)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "frame-preemption-status")
+
+	pathLvl2Tree.SetEnum(status)
+	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbStringTypeVal(status))
+	return update
+}
@@ -12,6 +12,8 @@ input (for all methods):
 */
 
 import (
+	"errors"
+	"fmt"
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	//"git.cs.kau.se/hamzchah/opencnc_kafka-exporter/logger/pkg/logger"
 
@@ -91,15 +93,43 @@ func SetDefaultTrafficClassTable(root *st.SchemaTree, port string, deviceIp stri
 	updates []*pb.Update, err error) {
 
 	var defaultTrafficClasses []int
-	defaultTrafficClasses, err = getDefaultTrafficClasses(nrTrafficClasses)
+	defaultTrafficClasses, err = GetDefaultTrafficClasses(nrTrafficClasses)
 
 	if err != nil {
 		//log.Errorf("Failed getting the number of traffic classes: %v", err)
 		return nil, err
 	}
 
-	for i := 0; i < nrTrafficClasses; i++ {
-		priorityUpdate := setTrafficClassPriorityAtIndex(root, i, defaultTrafficClasses[i], port, deviceIp)
+	return SetTrafficClassTable(root, port, deviceIp, defaultTrafficClasses, nrTrafficClasses)
+}
+
+/*
+Set the traffic class table from a mapping of the priorities to the traffic classes (queues) of the port
+
+Ref: IEEE 802.1Q-2018 8.6.6
+
+key parameters:
+
+	port, deviceIp
+
+parameters to set:
+
+	trafficClasses: the traffic class of each priority, the index is the priority (0-7)
+	nrTrafficClasses: nr of queues at the port
+*/
+func SetTrafficClassTable(root *st.SchemaTree, port string, deviceIp string, trafficClasses []int, nrTrafficClasses int) (
+	updates []*pb.Update, err error) {
+
+	if len(trafficClasses) != 8 {
+		return nil, errors.New("Must be a traffic class for each of the 8 priorities, got " + fmt.Sprint(len(trafficClasses)))
+	}
+
+	for priority, trafficClass := range trafficClasses {
+		if trafficClass < 0 || trafficClass >= nrTrafficClasses {
+			return nil, errors.New("Traffic class (" + fmt.Sprint(trafficClass) + ") of priority " + fmt.Sprint(priority) +
+				" is out of range. Range is [0-" + fmt.Sprint(nrTrafficClasses-1) + "]")
+		}
+		priorityUpdate := setTrafficClassPriorityAtIndex(root, priority, trafficClass, port, deviceIp)
 		updates = append(updates, priorityUpdate)
 	}
 
@@ -107,7 +137,20 @@ func SetDefaultTrafficClassTable(root *st.SchemaTree, port string, deviceIp stri
 }
 
 /*
-Set default priority regeneration table as described in the Q documentation
+Set default priority regeneration table as described in the Q documentation, every received priority is regenerated to itself
+
+Ref: IEEE 802.1Q-2018 6.9.4
+
+key parameters:
+
+	port, deviceIp
+*/
+func SetDefaultPriorityRegenerationTable(root *st.SchemaTree, port string, deviceIp string) (updates []*pb.Update, err error) {
+	return SetPriorityRegenerationTable(root, port, deviceIp, DefaultPriorityRegeneration)
+}
+
+/*
+Set the priority regeneration table from a mapping of the received priorities to the regenerated priorities
 
 Ref: IEEE 802.1Q-2018 6.9.4
 
@@ -117,13 +160,17 @@ key parameters:
 
 parameters to set:
 
-	nrTrafficClasses
+	regeneration: the regenerated priority of each received priority, the index is the received priority (0-7)
 */
-func SetDefaultPriorityRegenerationTable(root *st.SchemaTree, port string, deviceIp string, nrTrafficClasses int) (
+func SetPriorityRegenerationTable(root *st.SchemaTree, port string, deviceIp string, regeneration []int) (
 	updates []*pb.Update, err error) {
 
-	for i := 0; i < nrTrafficClasses; i++ {
-		priorityUpdate := setTrafficClassPriorityAtIndex(root, i, i, port, deviceIp)
+	if err = validatePriorities(regeneration); err != nil {
+		return nil, err
+	}
+
+	for i, priority := range regeneration {
+		priorityUpdate := setPriorityRegenerationAtIndex(root, i, priority, port, deviceIp)
 		updates = append(updates, priorityUpdate)
 	}
 
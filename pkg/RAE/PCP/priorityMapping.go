@@ -0,0 +1,192 @@
+package pcp
+
+/*
+Set a priority mapping supplied by the operator instead of the defaults of 802.1Q, for one port or for all ports of a bridge
+	* PCP selection (which row of the encoding and decoding tables is used)
+	* traffic class table
+	* priority regeneration table
+*/
+
+import (
+	"errors"
+	"fmt"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/topology"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Every received priority is regenerated to itself, Ref: IEEE 802.1Q-2018 table 6-5
+var DefaultPriorityRegeneration = []int{0, 1, 2, 3, 4, 5, 6, 7}
+
+// The priority mapping of a port
+type PriorityMapping struct {
+	PcpSelection   string // one of PCPTYPES
+	TrafficClasses []int  // the traffic class of each priority, the index is the priority, nil for the default table
+	Regeneration   []int  // the regenerated priority of each received priority, nil for DefaultPriorityRegeneration
+}
+
+/*
+Check that a priority mapping can be used at a port
+The traffic classes must exist at the port, and the priorities that can be received must be regenerated to priorities the
+PCP selection can encode without changing them, otherwise the next bridge decodes another priority (e.g. 5 is decoded as 4 with 7P1D).
+
+input:
+
+	mapping: the priority mapping
+	nrTrafficClasses: nr of queues at the port
+*/
+func ValidatePriorityMapping(mapping PriorityMapping, nrTrafficClasses int) error {
+	trafficClasses, regeneration, err := getMappingTables(mapping, nrTrafficClasses)
+	if err != nil {
+		return err
+	}
+
+	if len(trafficClasses) != 8 {
+		return errors.New("Must be a traffic class for each of the 8 priorities, got " + fmt.Sprint(len(trafficClasses)))
+	}
+	for priority, trafficClass := range trafficClasses {
+		if trafficClass < 0 || trafficClass >= nrTrafficClasses {
+			return errors.New("Traffic class (" + fmt.Sprint(trafficClass) + ") of priority " + fmt.Sprint(priority) +
+				" is out of range. Range is [0-" + fmt.Sprint(nrTrafficClasses-1) + "]")
+		}
+	}
+	if err = validatePriorities(regeneration); err != nil {
+		return err
+	}
+
+	// The priorities a bridge can receive with the PCP selection
+	decoded := map[int]bool{}
+	for pcp := 0; pcp <= 7; pcp++ {
+		priority, _, err := getPriorityAndDropEligibleValue(mapping.PcpSelection, pcp)
+		if err != nil {
+			return err
+		}
+		decoded[priority] = true
+	}
+	for received, priority := range regeneration {
+		if decoded[received] && !decoded[priority] {
+			return errors.New("Priority " + fmt.Sprint(received) + " is regenerated to " + fmt.Sprint(priority) +
+				", which " + mapping.PcpSelection + " can not carry to the next bridge")
+		}
+	}
+
+	return nil
+}
+
+/*
+Set the PCP selection, traffic class table and priority regeneration table of a port
+
+key parameters:
+
+	port, deviceIp
+
+parameters to set:
+
+	mapping: the priority mapping
+	nrTrafficClasses: nr of queues at the port
+*/
+func SetPriorityMapping(root *st.SchemaTree, port string, deviceIp string, mapping PriorityMapping, nrTrafficClasses int) (
+	updates []*pb.Update, err error) {
+
+	if err = ValidatePriorityMapping(mapping, nrTrafficClasses); err != nil {
+		return nil, fmt.Errorf("invalid priority mapping for port %s: %w", port, err)
+	}
+	trafficClasses, regeneration, err := getMappingTables(mapping, nrTrafficClasses)
+	if err != nil {
+		return nil, err
+	}
+
+	updates = append(updates, setPcpSelection(root, port, deviceIp, mapping.PcpSelection))
+
+	trafficClassUpdates, err := SetTrafficClassTable(root, port, deviceIp, trafficClasses, nrTrafficClasses)
+	if err != nil {
+		return nil, err
+	}
+	updates = append(updates, trafficClassUpdates...)
+
+	regenerationUpdates, err := SetPriorityRegenerationTable(root, port, deviceIp, regeneration)
+	if err != nil {
+		return nil, err
+	}
+	updates = append(updates, regenerationUpdates...)
+
+	return updates, nil
+}
+
+/*
+Set the priority mapping of every port of a bridge
+All ports are validated before any update is made, so a mapping that does not fit one port changes nothing
+
+input:
+
+	root: the configuration of the device
+	node: the bridge in the topology, the number of queues of each port is used
+	mapping: the priority mapping of the ports
+	portMappings: the priority mapping of the ports that do not use mapping, by port name
+*/
+func SetBridgePriorityMapping(root *st.SchemaTree, node *topology.Node, mapping PriorityMapping,
+	portMappings map[string]PriorityMapping) (updates []*pb.Update, err error) {
+
+	deviceIp := node.GetManagementInfo().GetIpAddress()
+	if deviceIp == "" {
+		return nil, errors.New("node " + node.Name + " has no management ip address")
+	}
+
+	for name := range portMappings {
+		if getPort(node, name) == nil {
+			return nil, errors.New("port " + name + " is not a port of " + node.Name)
+		}
+	}
+
+	for _, port := range node.Ports {
+		portMapping, ok := portMappings[port.Name]
+		if !ok {
+			portMapping = mapping
+		}
+		if err = ValidatePriorityMapping(portMapping, int(port.NumberOfQueues)); err != nil {
+			return nil, fmt.Errorf("invalid priority mapping for port %s of %s: %w", port.Name, node.Name, err)
+		}
+	}
+
+	for _, port := range node.Ports {
+		portMapping, ok := portMappings[port.Name]
+		if !ok {
+			portMapping = mapping
+		}
+		portUpdates, err := SetPriorityMapping(root, port.Name, deviceIp, portMapping, int(port.NumberOfQueues))
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, portUpdates...)
+	}
+
+	return updates, nil
+}
+
+// Get the traffic class and priority regeneration tables of a mapping, with the defaults for the tables that are not given
+func getMappingTables(mapping PriorityMapping, nrTrafficClasses int) (trafficClasses []int, regeneration []int, err error) {
+	trafficClasses = mapping.TrafficClasses
+	if trafficClasses == nil {
+		trafficClasses, err = GetDefaultTrafficClasses(nrTrafficClasses)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	regeneration = mapping.Regeneration
+	if regeneration == nil {
+		regeneration = DefaultPriorityRegeneration
+	}
+
+	return trafficClasses, regeneration, nil
+}
+
+func getPort(node *topology.Node, name string) *topology.Port {
+	for _, port := range node.Ports {
+		if port.Name == name {
+			return port
+		}
+	}
+	return nil
+}
@@ -58,7 +58,7 @@ output:
 
 	prio: priority mapping list after nr of traffic classes
 */
-func getDefaultTrafficClasses(nrTrafficClasses int) (prio []int, err error) {
+func GetDefaultTrafficClasses(nrTrafficClasses int) (prio []int, err error) {
 	switch nrTrafficClasses {
 	case 1:
 		prio = []int{0, 0, 0, 0, 0, 0, 0, 0}
@@ -216,13 +216,13 @@ func setPcpEncodingTableValue(root *st.SchemaTree, port string, deviceIp string,
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 
 	pathEncodingTree, pathEncodingPb :=
-		path.GetParam1Key(bridgePathTree, bridgePathPb, "", "pcp-encoding-table", "pcp", pcpType)
+		path.GetParamKeys(bridgePathTree, bridgePathPb, "", "pcp-encoding-table", map[string]string{"pcp": pcpType})
 
 	pathPrioMapTree, pathPrioMapPb :=
-		path.GetParam2Keys(pathEncodingTree, pathEncodingPb, "", "priority-map", "priority", fmt.Sprint(prio), "dei", fmt.Sprint(dei))
+		path.GetParamKeys(pathEncodingTree, pathEncodingPb, "", "priority-map", map[string]string{"priority": fmt.Sprint(prio), "dei": fmt.Sprint(dei)})
 
 	pathTree, pathPb := path.GetParam0Keys(pathPrioMapTree, pathPrioMapPb, "priority-code-point")
-	pathTree.Value = fmt.Sprint(pcp)
+	pathTree.SetInt64(int64(pcp))
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(uint(pcp)))
 	return update
 }
@@ -247,17 +247,17 @@ func setPcpDecodingTableValue(root *st.SchemaTree, port string, deviceIp string,
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 
 	pathDecodingTree, pathDecodingPb :=
-		path.GetParam1Key(bridgePathTree, bridgePathPb, "", "pcp-decoding-table", "pcp", pcpType)
+		path.GetParamKeys(bridgePathTree, bridgePathPb, "", "pcp-decoding-table", map[string]string{"pcp": pcpType})
 
 	pathPrioMapTree, pathPrioMapPb :=
-		path.GetParam1Key(pathDecodingTree, pathDecodingPb, "", "priority-map", "priority-code-point", fmt.Sprint(pcp))
+		path.GetParamKeys(pathDecodingTree, pathDecodingPb, "", "priority-map", map[string]string{"priority-code-point": fmt.Sprint(pcp)})
 
 	pathPrioTree, pathPrioPb := path.GetParam0Keys(pathPrioMapTree, pathPrioMapPb, "priority")
 	PcpDecodingTablePriorityUpdate := pbMethods.GetUpdate(deviceIp, pathPrioPb, pbMethods.GetPbUintTypeVal(uint(priority)))
-	pathPrioTree.Value = fmt.Sprint(priority)
+	pathPrioTree.SetInt64(int64(priority))
 
 	pathDeiTree, pathDeiPb := path.GetParam0Keys(pathPrioMapTree, pathPrioMapPb, "dei")
-	pathDeiTree.Value = fmt.Sprint(dei)
+	pathDeiTree.SetBool(dei)
 	PcpDecodingTableDropEligibleUpdate := pbMethods.GetUpdate(deviceIp, pathDeiPb, pbMethods.GetPbBoolTypeVal(dei))
 
 	updates = []*pb.Update{PcpDecodingTablePriorityUpdate, PcpDecodingTableDropEligibleUpdate}
@@ -282,7 +282,7 @@ func setTrafficClassPriorityAtIndex(root *st.SchemaTree, priorityIndex int, prio
 	pathTrafficClassTree, pathTrafficClassPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "traffic-class")
 	pathTree, pathPb := path.GetParam0Keys(pathTrafficClassTree, pathTrafficClassPb, "priority"+fmt.Sprint(priorityIndex))
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(uint(priority)))
-	pathTree.Value = fmt.Sprint(priority)
+	pathTree.SetInt64(int64(priority))
 	return update
 }
 
@@ -303,6 +303,38 @@ func setPriorityRegenerationAtIndex(root *st.SchemaTree, priorityIndex int, prio
 	pathPrioRegTree, pathPrioRegPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "priority-regeneration")
 	pathTree, pathPb := path.GetParam0Keys(pathPrioRegTree, pathPrioRegPb, "priority"+fmt.Sprint(priorityIndex))
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(uint(priority)))
-	pathTree.Value = fmt.Sprint(priority)
+	pathTree.SetInt64(int64(priority))
 	return update
 }
+
+/*
+	Build update for change the PCP selection of a port
+
+key parameters:
+
+	port, deviceIp
+
+parameters to set:
+
+	pcpType: PCP type, aka "8P0D", "7P1D", "6P2D", "5P3D"
+*/
+func setPcpSelection(root *st.SchemaTree, port string, deviceIp string, pcpType string) (update *pb.Update) {
+	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
+	pathTree, pathPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "pcp-selection")
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(pcpType))
+	pathTree.SetEnum(pcpType)
+	return update
+}
+
+// Check that every value of a priority table is a priority (0-7), for each of the 8 priorities
+func validatePriorities(priorities []int) error {
+	if len(priorities) != 8 {
+		return errors.New("Must be a value for each of the 8 priorities, got " + fmt.Sprint(len(priorities)))
+	}
+	for i, priority := range priorities {
+		if priority < 0 || priority > 7 {
+			return errors.New("Priority (" + fmt.Sprint(priority) + ") at index " + fmt.Sprint(i) + " is out of range. Range is [0-7]")
+		}
+	}
+	return nil
+}
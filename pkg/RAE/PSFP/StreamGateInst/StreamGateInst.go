@@ -29,6 +29,7 @@ key parameter:
 
 	port: port the table is at
 	deviceIp: device the port is on
+	gateId: identifier of the stream gate instance
 
 parameters to set:
 
@@ -74,47 +75,114 @@ parameters to set:
 	drx
 		default false
 */
-func setStreamGateInstanceTable(root *st.SchemaTree, port string, deviceIp string,
+func SetStreamGateInstanceTable(root *st.SchemaTree, port string, deviceIp string, gateId uint,
 	gateEnabled bool, adminGateState uint, ctrlListLen uint,
 	opNameList []string, sgsGateState []uint, sgsTimeInt []uint, ipvCtrlList []uint, timeIntervalCtrlList []uint,
 	cycleTimeNumerator uint, cycleTimeDenominator uint, cycleTimeExtension uint,
 	baseTimeSec int, baseTimeFrac int, configChanged bool, adminIpv uint, operIpv uint, drxEnabled bool, drx bool) (
 	updates []*pb.Update, err error) {
 
-	updates = append(updates, SetGateParaTblGateEnabled(root, port, deviceIp, gateEnabled))
-	updates = append(updates, SetGateParaTblAdminGateStates(root, port, deviceIp, adminGateState))
-	updates = append(updates, SetGateParaTblCtrlListLen(root, port, deviceIp, ctrlListLen))
+	updates = append(updates, setStreamGateInstanceId(root, port, deviceIp, gateId))
+	updates = append(updates, SetGateParaTblGateEnabled(root, port, deviceIp, gateId, gateEnabled))
+	updates = append(updates, SetGateParaTblAdminGateStates(root, port, deviceIp, gateId, adminGateState))
+	updates = append(updates, SetGateParaTblCtrlListLen(root, port, deviceIp, gateId, ctrlListLen))
 
 	// Not a 100 that the path for this is correct
 	if int(ctrlListLen) < len(opNameList) {
-		err = errors.New("the control list lenght are less than the number of control list, which it should not be")
-	} else {
-		// set control lists
-		for i := 0; i < len(opNameList); i++ {
-			updates = append(updates, SetGateParaTblCtrlListOperName(root, port, deviceIp, i, opNameList[i]))
-			updates = append(updates, SetGateParaTblCtrlListSgsGateState(root, port, deviceIp, i, sgsGateState[i]))
-			updates = append(updates, SetGateParaTblCtrlListSgsTimeInterval(root, port, deviceIp, i, sgsTimeInt[i]))
-		}
+		return nil, errors.New("the control list lenght are less than the number of control list, which it should not be")
+	}
 
+	// set control lists
+	for i := 0; i < len(opNameList); i++ {
+		updates = append(updates, SetGateParaTblCtrlListOperName(root, port, deviceIp, gateId, i, opNameList[i]))
+		updates = append(updates, SetGateParaTblCtrlListSgsGateState(root, port, deviceIp, gateId, i, sgsGateState[i]))
+		updates = append(updates, SetGateParaTblCtrlListSgsTimeInterval(root, port, deviceIp, gateId, i, sgsTimeInt[i]))
+		if i < len(ipvCtrlList) {
+			updates = append(updates, SetGateParaTblCtrlListSgsIpv(root, port, deviceIp, gateId, i, ipvCtrlList[i]))
+		}
 	}
 
 	// psfp admin cycle time
-	updates = append(updates, SetGateParaTblCycleTimeNum(root, port, deviceIp, cycleTimeNumerator))
-	updates = append(updates, SetGateParaTblCycleTimeDen(root, port, deviceIp, cycleTimeDenominator))
+	updates = append(updates, SetGateParaTblCycleTimeNum(root, port, deviceIp, gateId, cycleTimeNumerator))
+	updates = append(updates, SetGateParaTblCycleTimeDen(root, port, deviceIp, gateId, cycleTimeDenominator))
 
-	updates = append(updates, SetGateParaTblCycleTimeExt(root, port, deviceIp, cycleTimeExtension))
+	updates = append(updates, SetGateParaTblCycleTimeExt(root, port, deviceIp, gateId, cycleTimeExtension))
 
 	// base time, defined in the yang file?
-	updates = append(updates, SetGateParaTblBaseTimeSec(root, port, deviceIp, baseTimeSec))
-	updates = append(updates, SetGateParaTblBaseTimeSecFrac(root, port, deviceIp, baseTimeFrac))
+	updates = append(updates, SetGateParaTblBaseTimeSec(root, port, deviceIp, gateId, baseTimeSec))
+	updates = append(updates, SetGateParaTblBaseTimeSecFrac(root, port, deviceIp, gateId, baseTimeFrac))
 
-	updates = append(updates, SetGateParaTblConfigChange(root, port, deviceIp, configChanged))
+	updates = append(updates, SetGateParaTblConfigChange(root, port, deviceIp, gateId, configChanged))
 
 	// Not in the yang file know
-	updates = append(updates, setStreamGateInstanceTableAdminIpv(root, port, deviceIp, adminIpv))
-	updates = append(updates, setStreamGateInstanceTableOperIpv(root, port, deviceIp, operIpv))
-	updates = append(updates, setStreamGateInstanceTableDrxEnable(root, port, deviceIp, drxEnabled))
-	updates = append(updates, setStreamGateInstanceTableDrx(root, port, deviceIp, drx))
+	updates = append(updates, setStreamGateInstanceTableAdminIpv(root, port, deviceIp, gateId, adminIpv))
+	updates = append(updates, setStreamGateInstanceTableOperIpv(root, port, deviceIp, gateId, operIpv))
+	updates = append(updates, setStreamGateInstanceTableDrxEnable(root, port, deviceIp, gateId, drxEnabled))
+	updates = append(updates, setStreamGateInstanceTableDrx(root, port, deviceIp, gateId, drx))
 
 	return updates, nil
 }
+
+/*
+Default stream gate instance table, a gate that is always open and has no control list
+
+key parameter:
+
+	port, deviceIp, gateId
+
+parameters to set:
+
+	cycleTimeNumerator/cycleTimeDenominator: the cycle time of the gate in seconds
+*/
+func SetDefaultStreamGateInstanceTable(root *st.SchemaTree, port string, deviceIp string, gateId uint,
+	cycleTimeNumerator uint, cycleTimeDenominator uint) ([]*pb.Update, error) {
+
+	var gateEnabled bool = true
+	var adminGateState uint = 255
+	var ctrlListLen uint = 0
+	var cycleTimeExtension uint = 0
+	var configChanged bool = true
+	var drxEnabled bool = false
+
+	return SetStreamGateInstanceTable(root, port, deviceIp, gateId,
+		gateEnabled, adminGateState, ctrlListLen,
+		nil, nil, nil, nil, nil,
+		cycleTimeNumerator, cycleTimeDenominator, cycleTimeExtension,
+		0, 0, configChanged, 0, 0, drxEnabled, false)
+}
+
+/*
+Stream gate instance table for Cyclic Queuing and Forwarding (CQF), Ref: IEEE 802.1Qch-2017 Annex T
+The gate is always open, and every cycle the frames are put in the other of the two queues,
+so the frames received in one cycle are sent in the next
+
+key parameter:
+
+	port, deviceIp, gateId
+
+parameters to set:
+
+	cycleTime: the CQF cycle time in ns, the gate cycle is two CQF cycles
+	queues: the internal priority values of the two queues that alternate
+*/
+func SetCqfStreamGateInstanceTable(root *st.SchemaTree, port string, deviceIp string, gateId uint,
+	cycleTime uint, queues [2]uint) ([]*pb.Update, error) {
+
+	var gateEnabled bool = true
+	var adminGateState uint = 1
+	var ctrlListLen uint = 2
+	var cycleTimeExtension uint = 0
+	var configChanged bool = true
+	var drxEnabled bool = false
+
+	opNameList := []string{"set-gate-and-ipv", "set-gate-and-ipv"}
+	sgsGateState := []uint{1, 1}
+	sgsTimeInt := []uint{cycleTime, cycleTime}
+	ipvCtrlList := []uint{queues[0], queues[1]}
+
+	return SetStreamGateInstanceTable(root, port, deviceIp, gateId,
+		gateEnabled, adminGateState, ctrlListLen,
+		opNameList, sgsGateState, sgsTimeInt, ipvCtrlList, sgsTimeInt,
+		2*cycleTime, 1000000000, cycleTimeExtension,
+		0, 0, configChanged, 0, 0, drxEnabled, false)
+}
@@ -6,6 +6,7 @@ Functions to set each value in the stream gate instance table
 
 import (
 	"fmt"
+	"strconv"
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	rae "tsn-service/pkg/RAE/dataStructures/composit"
 	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
@@ -13,6 +14,68 @@ import (
 	pb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
+/*
+Get the identifiers of all stream gate instances already configured on any port of the device
+
+key parameters:
+
+	root: The schema tree of the device
+*/
+func GetStreamGateInstanceIds(root *st.SchemaTree) (ids []uint) {
+	for _, bridgePort := range st.LvlsDownToBridgePorts(root) {
+		for _, id := range st.GetAllKeyValues(bridgePort, "stream-gate-instance-table", "stream-gate-instance-id") {
+			value, err := strconv.ParseUint(id, 10, 32)
+			if err == nil {
+				ids = append(ids, uint(value))
+			}
+		}
+	}
+	return ids
+}
+
+/*
+Delete one entry of the stream gate instance table, its identifier is free to use again
+
+key parameters:
+
+	port, deviceIp, gateId
+*/
+func DeleteStreamGateInstance(root *st.SchemaTree, port string, deviceIp string, gateId uint) (deletePath *pb.Path) {
+	pathTree, pathPb := getStreamGateInstancePath(root, port, gateId)
+	st.RemoveFromParent(pathTree)
+	return pbMethods.GetDelete(deviceIp, pathPb)
+}
+
+/*
+Get both the tree and the pb path to one entry of the stream gate instance table
+
+key parameters:
+
+	port, gateId
+*/
+func getStreamGateInstancePath(root *st.SchemaTree, port string, gateId uint) (*st.SchemaTree, []*pb.PathElem) {
+	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
+	return rae.GetParamKeys(bridgePathTree, bridgePathPb, "", "stream-gate-instance-table", map[string]string{"stream-gate-instance-id": fmt.Sprint(gateId)})
+}
+
+/*
+Set the stream-gate-instance-id of the entry in the stream gate instance table
+
+key parameters:
+
+	port: the port that the table are at
+	deviceIp: the device the port is on
+	gateId: the stream gate instance
+*/
+func setStreamGateInstanceId(root *st.SchemaTree, port string, deviceIp string, gateId uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
+	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "stream-gate-instance-id")
+
+	pathLvl2Tree.SetUint64(uint64(gateId))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(gateId))
+	return update
+}
+
 /*
 	Set the gate-enabled value in the gate parameter table
 
@@ -20,17 +83,17 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	gateEnabled
 */
-func SetGateParaTblGateEnabled(root *st.SchemaTree, port string, deviceIp string, gateEnabled bool) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func SetGateParaTblGateEnabled(root *st.SchemaTree, port string, deviceIp string, gateId uint, gateEnabled bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "gate-enabled")
 
-	pathLvl2Tree.Value = fmt.Sprint(gateEnabled)
+	pathLvl2Tree.SetBool(gateEnabled)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(gateEnabled))
 	return update
 }
@@ -42,17 +105,17 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	adminGateState
 */
-func SetGateParaTblAdminGateStates(root *st.SchemaTree, port string, deviceIp string, adminGateState uint) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func SetGateParaTblAdminGateStates(root *st.SchemaTree, port string, deviceIp string, gateId uint, adminGateState uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "admin-gate-states")
 
-	pathLvl2Tree.Value = fmt.Sprint(adminGateState)
+	pathLvl2Tree.SetUint64(uint64(adminGateState))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(adminGateState))
 	return update
 }
@@ -64,17 +127,17 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	ctrlListLen
 */
-func SetGateParaTblCtrlListLen(root *st.SchemaTree, port string, deviceIp string, ctrlListLen uint) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func SetGateParaTblCtrlListLen(root *st.SchemaTree, port string, deviceIp string, gateId uint, ctrlListLen uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "admin-control-list-length")
 
-	pathLvl2Tree.Value = fmt.Sprint(ctrlListLen)
+	pathLvl2Tree.SetUint64(uint64(ctrlListLen))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(ctrlListLen))
 	return update
 }
@@ -86,18 +149,18 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	numerator
 */
-func SetGateParaTblCycleTimeNum(root *st.SchemaTree, port string, deviceIp string, numerator uint) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func SetGateParaTblCycleTimeNum(root *st.SchemaTree, port string, deviceIp string, gateId uint, numerator uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "admin-cycle-time")
 	pathLvl3Tree, pathLvl3Pb := rae.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "psfp-admin-cycle-time-numerator")
 
-	pathLvl3Tree.Value = fmt.Sprint(numerator)
+	pathLvl3Tree.SetUint64(uint64(numerator))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl3Pb, pbMethods.GetPbUintTypeVal(numerator))
 	return update
 }
@@ -109,18 +172,18 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	denominator
 */
-func SetGateParaTblCycleTimeDen(root *st.SchemaTree, port string, deviceIp string, denominator uint) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func SetGateParaTblCycleTimeDen(root *st.SchemaTree, port string, deviceIp string, gateId uint, denominator uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "admin-cycle-time")
 	pathLvl3Tree, pathLvl3Pb := rae.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "psfp-admin-cycle-time-denominator")
 
-	pathLvl3Tree.Value = fmt.Sprint(denominator)
+	pathLvl3Tree.SetUint64(uint64(denominator))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl3Pb, pbMethods.GetPbUintTypeVal(denominator))
 	return update
 }
@@ -132,17 +195,17 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	extension
 */
-func SetGateParaTblCycleTimeExt(root *st.SchemaTree, port string, deviceIp string, extension uint) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func SetGateParaTblCycleTimeExt(root *st.SchemaTree, port string, deviceIp string, gateId uint, extension uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "admin-cycle-time-extension")
 
-	pathLvl2Tree.Value = fmt.Sprint(extension)
+	pathLvl2Tree.SetUint64(uint64(extension))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(extension))
 	return update
 }
@@ -157,19 +220,19 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 	index: Which index in the control list
 
 Parameters to set:
 
 	opername
 */
-func SetGateParaTblCtrlListOperName(root *st.SchemaTree, port string, deviceIp string, index int, opername string) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
-	pathLvl2Tree, pathLvl2Pb := rae.GetParam1Key(pathLvl1Tree, pathLvl1Pb, "", "admin-control-list", "index", string(index))
+func SetGateParaTblCtrlListOperName(root *st.SchemaTree, port string, deviceIp string, gateId uint, index int, opername string) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
+	pathLvl2Tree, pathLvl2Pb := rae.GetParamKeys(pathLvl1Tree, pathLvl1Pb, "", "admin-control-list", map[string]string{"index": fmt.Sprint(index)})
 	pathLvl3Tree, pathLvl3Pb := rae.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "operation-name")
 
-	pathLvl3Tree.Value = opername
+	pathLvl3Tree.SetString(opername)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl3Pb, pbMethods.GetPbStringTypeVal(opername))
 	return update
 }
@@ -180,20 +243,20 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 	index: Which index in the control list
 
 Parameters to set:
 
 	sgsParams
 */
-func SetGateParaTblCtrlListSgsGateState(root *st.SchemaTree, port string, deviceIp string, index int, sgsParams uint) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
-	pathLvl2Tree, pathLvl2Pb := rae.GetParam1Key(pathLvl1Tree, pathLvl1Pb, "", "admin-control-list", "index", string(index))
+func SetGateParaTblCtrlListSgsGateState(root *st.SchemaTree, port string, deviceIp string, gateId uint, index int, sgsParams uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
+	pathLvl2Tree, pathLvl2Pb := rae.GetParamKeys(pathLvl1Tree, pathLvl1Pb, "", "admin-control-list", map[string]string{"index": fmt.Sprint(index)})
 	pathLvl3Tree, pathLvl3Pb := rae.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "sgs-params")
 	pathLvl4Tree, pathLvl4Pb := rae.GetParam0Keys(pathLvl3Tree, pathLvl3Pb, "gate-states-value")
 
-	pathLvl4Tree.Value = fmt.Sprint(sgsParams)
+	pathLvl4Tree.SetUint64(uint64(sgsParams))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl4Pb, pbMethods.GetPbUintTypeVal(sgsParams))
 	return update
 }
@@ -204,24 +267,48 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 	index: Which index in the control list
 
 Parameters to set:
 
 	timeInter
 */
-func SetGateParaTblCtrlListSgsTimeInterval(root *st.SchemaTree, port string, deviceIp string, index int, timeInter uint) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
-	pathLvl2Tree, pathLvl2Pb := rae.GetParam1Key(pathLvl1Tree, pathLvl1Pb, "", "admin-control-list", "index", string(index))
+func SetGateParaTblCtrlListSgsTimeInterval(root *st.SchemaTree, port string, deviceIp string, gateId uint, index int, timeInter uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
+	pathLvl2Tree, pathLvl2Pb := rae.GetParamKeys(pathLvl1Tree, pathLvl1Pb, "", "admin-control-list", map[string]string{"index": fmt.Sprint(index)})
 	pathLvl3Tree, pathLvl3Pb := rae.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "sgs-params")
 	pathLvl4Tree, pathLvl4Pb := rae.GetParam0Keys(pathLvl3Tree, pathLvl3Pb, "time-interval-value")
 
-	pathLvl4Tree.Value = fmt.Sprint(timeInter)
+	pathLvl4Tree.SetUint64(uint64(timeInter))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl4Pb, pbMethods.GetPbUintTypeVal(timeInter))
 	return update
 }
 
+/*
+Set the internal priority value (IPV) for the setGatesStates, the queue the frames that pass the gate are put in
+key parameters:
+
+	port: the port that the table are at
+	deviceIp: the device the port is on
+	gateId: the stream gate instance
+	index: Which index in the control list
+
+Parameters to set:
+
+	ipv
+*/
+func SetGateParaTblCtrlListSgsIpv(root *st.SchemaTree, port string, deviceIp string, gateId uint, index int, ipv uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
+	pathLvl2Tree, pathLvl2Pb := rae.GetParamKeys(pathLvl1Tree, pathLvl1Pb, "", "admin-control-list", map[string]string{"index": fmt.Sprint(index)})
+	pathLvl3Tree, pathLvl3Pb := rae.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "sgs-params")
+	pathLvl4Tree, pathLvl4Pb := rae.GetParam0Keys(pathLvl3Tree, pathLvl3Pb, "ipv")
+
+	pathLvl4Tree.SetUint64(uint64(ipv))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl4Pb, pbMethods.GetPbUintTypeVal(ipv))
+	return update
+}
+
 /* ---------------------------- <Base times values> ---------------------------------------- */
 
 /*
@@ -230,18 +317,19 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	baseTimeSec
 */
-func SetGateParaTblBaseTimeSec(root *st.SchemaTree, port string, deviceIp string, baseTimeSec int) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func SetGateParaTblBaseTimeSec(root *st.SchemaTree, port string, deviceIp string, gateId uint, baseTimeSec int) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "admin-base-time")
+	pathLvl3Tree, pathLvl3Pb := rae.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "seconds")
 
-	pathLvl2Tree.Value = fmt.Sprint(baseTimeSec)
-	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbIntTypeVal(baseTimeSec))
+	pathLvl3Tree.SetInt64(int64(baseTimeSec))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl3Pb, pbMethods.GetPbIntTypeVal(baseTimeSec))
 	return update
 }
 
@@ -251,18 +339,19 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	baseTimeFrac
 */
-func SetGateParaTblBaseTimeSecFrac(root *st.SchemaTree, port string, deviceIp string, baseTimeFrac int) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func SetGateParaTblBaseTimeSecFrac(root *st.SchemaTree, port string, deviceIp string, gateId uint, baseTimeFrac int) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "admin-base-time")
+	pathLvl3Tree, pathLvl3Pb := rae.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "fractional-seconds")
 
-	pathLvl2Tree.Value = fmt.Sprint(baseTimeFrac)
-	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbIntTypeVal(baseTimeFrac))
+	pathLvl3Tree.SetInt64(int64(baseTimeFrac))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl3Pb, pbMethods.GetPbIntTypeVal(baseTimeFrac))
 	return update
 }
 
@@ -274,17 +363,17 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	configChanged
 */
-func SetGateParaTblConfigChange(root *st.SchemaTree, port string, deviceIp string, configChanged bool) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func SetGateParaTblConfigChange(root *st.SchemaTree, port string, deviceIp string, gateId uint, configChanged bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "config-change")
 
-	pathLvl2Tree.Value = fmt.Sprint(configChanged)
+	pathLvl2Tree.SetBool(configChanged)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(configChanged))
 	return update
 }
@@ -295,17 +384,17 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	adminIpv
 */
-func setStreamGateInstanceTableAdminIpv(root *st.SchemaTree, port string, deviceIp string, adminIpv uint) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func setStreamGateInstanceTableAdminIpv(root *st.SchemaTree, port string, deviceIp string, gateId uint, adminIpv uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "admin-ipv")
 
-	pathLvl2Tree.Value = fmt.Sprint(adminIpv)
+	pathLvl2Tree.SetUint64(uint64(adminIpv))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(adminIpv))
 	return update
 }
@@ -317,17 +406,17 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	operIpv
 */
-func setStreamGateInstanceTableOperIpv(root *st.SchemaTree, port string, deviceIp string, operIpv uint) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func setStreamGateInstanceTableOperIpv(root *st.SchemaTree, port string, deviceIp string, gateId uint, operIpv uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "oper-ipv")
 
-	pathLvl2Tree.Value = fmt.Sprint(operIpv)
+	pathLvl2Tree.SetUint64(uint64(operIpv))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(operIpv))
 	return update
 }
@@ -338,17 +427,17 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	drxEnabled
 */
-func setStreamGateInstanceTableDrxEnable(root *st.SchemaTree, port string, deviceIp string, drxEnabled bool) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func setStreamGateInstanceTableDrxEnable(root *st.SchemaTree, port string, deviceIp string, gateId uint, drxEnabled bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "drx-enabled")
 
-	pathLvl2Tree.Value = fmt.Sprint(drxEnabled)
+	pathLvl2Tree.SetBool(drxEnabled)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(drxEnabled))
 	return update
 }
@@ -359,17 +448,17 @@ key parameters:
 
 	port: the port that the table are at
 	deviceIp: the device the port is on
+	gateId: the stream gate instance
 
 Parameters to set:
 
 	drx
 */
-func setStreamGateInstanceTableDrx(root *st.SchemaTree, port string, deviceIp string, drx bool) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := rae.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "gate-parameters")
+func setStreamGateInstanceTableDrx(root *st.SchemaTree, port string, deviceIp string, gateId uint, drx bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamGateInstancePath(root, port, gateId)
 	pathLvl2Tree, pathLvl2Pb := rae.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "drx")
 
-	pathLvl2Tree.Value = fmt.Sprint(drx)
+	pathLvl2Tree.SetBool(drx)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(drx))
 	return update
 }
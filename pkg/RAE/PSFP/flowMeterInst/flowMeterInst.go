@@ -35,20 +35,27 @@ Parameters to set:
 	dropOnYellow: (true or false), defalut false
 	markAllFrameRedEnabled: (true or false), defalut false
 	markAllFrameRed: (true or false), defalut false
+
+The rates and burst sizes are checked, a bucket that is in use must be able to pass frames
 */
-func setFlowMeterInstanceTable(root *st.SchemaTree, port string, deviceIp string,
+func SetFlowMeterInstanceTable(root *st.SchemaTree, port string, deviceIp string,
 	flowId uint, cir uint, cbs uint, eir uint, ebs uint, cf bool, cm bool, dropOnYellow bool,
 	markAllFrameRedEnabled bool, markAllFrameRed bool) (updates []*pb.Update, err error) {
 
+	if err = validateParameters(Parameters{Cir: cir, Cbs: cbs, Eir: eir, Ebs: ebs}); err != nil {
+		return nil, err
+	}
+
 	updates = append(updates, setFlowMeterInstanceTableID(root, port, deviceIp, flowId))
-	updates = append(updates, setCommittedInformationRate(root, port, deviceIp, cir))
-	updates = append(updates, setCommittedBurstnRate(root, port, deviceIp, cbs))
-	updates = append(updates, setExcessInformationRate(root, port, deviceIp, eir))
-	updates = append(updates, setCouplingFlag(root, port, deviceIp, cf))
-	updates = append(updates, setColorMode(root, port, deviceIp, cm))
-	updates = append(updates, setDropOnYellow(root, port, deviceIp, dropOnYellow))
-	updates = append(updates, setRedEnabled(root, port, deviceIp, markAllFrameRedEnabled))
-	updates = append(updates, setRed(root, port, deviceIp, markAllFrameRed))
+	updates = append(updates, setCommittedInformationRate(root, port, deviceIp, flowId, cir))
+	updates = append(updates, setCommittedBurstSize(root, port, deviceIp, flowId, cbs))
+	updates = append(updates, setExcessInformationRate(root, port, deviceIp, flowId, eir))
+	updates = append(updates, setExcessBurstSize(root, port, deviceIp, flowId, ebs))
+	updates = append(updates, setCouplingFlag(root, port, deviceIp, flowId, cf))
+	updates = append(updates, setColorMode(root, port, deviceIp, flowId, cm))
+	updates = append(updates, setDropOnYellow(root, port, deviceIp, flowId, dropOnYellow))
+	updates = append(updates, setRedEnabled(root, port, deviceIp, flowId, markAllFrameRedEnabled))
+	updates = append(updates, setRed(root, port, deviceIp, flowId, markAllFrameRed))
 
 	return updates, nil
 }
@@ -56,7 +63,7 @@ func setFlowMeterInstanceTable(root *st.SchemaTree, port string, deviceIp string
 /*
 Default flow metering instance table
 */
-func setDefaultFlowMeterInstanceTable(root *st.SchemaTree, port string, deviceIp string,
+func SetDefaultFlowMeterInstanceTable(root *st.SchemaTree, port string, deviceIp string,
 	flowId uint, cir uint, cbs uint, eir uint, ebs uint) (updates []*pb.Update, err error) {
 
 	var cf bool = false
@@ -65,7 +72,7 @@ func setDefaultFlowMeterInstanceTable(root *st.SchemaTree, port string, deviceIp
 	var markAllFrameRedEnabled bool = false
 	var markAllFrameRed bool = false
 
-	updates, err = setFlowMeterInstanceTable(root, port, deviceIp,
+	updates, err = SetFlowMeterInstanceTable(root, port, deviceIp,
 		flowId, cir, cbs, eir, ebs, cf, cm, dropOnYellow,
 		markAllFrameRedEnabled, markAllFrameRed)
 
@@ -0,0 +1,154 @@
+package flowmeterinst
+
+/*
+Derive the flow meter parameters from the traffic specification of the talker
+
+Ref:
+	IEEE 802.1Q-2018 8.6.5.1.3 (flow meters)
+	IEEE 802.1Qcc-2018 46.2.3.5 (TrafficSpecification)
+	https://wiki.mef.net/display/CESG/Bandwidth+Profile
+*/
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/configuration"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// How much more than the talker has announced the flow meter lets through
+type Tolerance struct {
+	RatePercent   uint // added to the committed rate and burst size, in percent of the announced traffic
+	ExcessPercent uint // excess rate and burst size, in percent of the committed values (0 disables the excess bucket)
+}
+
+// Tolerance used when nothing else is configured
+var DefaultTolerance = Tolerance{RatePercent: 10, ExcessPercent: 0}
+
+// The largest tolerance, a flow meter that lets through more than twice the announced traffic no longer protects the other streams
+const MaxTolerancePercent = 100
+
+// The four bandwidth profile parameters of a flow meter
+type Parameters struct {
+	Cir uint // Committed Information Rate (bits/sec)
+	Cbs uint // Committed Burst Size (octets)
+	Eir uint // Excess Information Rate (bits/sec)
+	Ebs uint // Excess Burst Size (octets)
+}
+
+/*
+Compute the flow meter parameters from the traffic specification
+
+The talker sends at most MaxFramesPerInterval frames of MaxFrameSize octets every Interval
+(Numerator/Denominator seconds), which is the committed burst, and the committed rate is that burst per interval.
+Both are increased by the tolerance, and the excess values are a share of the committed ones.
+
+input:
+
+	trafficSpec: the traffic specification of the talker
+	tolerance: how much the flow meter allows above what the talker has announced, at most MaxTolerancePercent
+*/
+func GetParameters(trafficSpec *configuration.TrafficSpecification, tolerance Tolerance) (params Parameters, err error) {
+	if trafficSpec == nil {
+		return params, errors.New("no traffic specification to derive the flow meter from")
+	}
+	if err = validateTolerance(tolerance); err != nil {
+		return params, err
+	}
+
+	interval := trafficSpec.Interval
+	if interval == nil || interval.Numerator == 0 || interval.Denominator == 0 {
+		return params, errors.New("the traffic specification must have an interval larger than 0")
+	}
+	if trafficSpec.MaxFrameSize == 0 || trafficSpec.MaxFramesPerInterval == 0 {
+		return params, errors.New("the traffic specification must have a max frame size and max frames per interval larger than 0")
+	}
+
+	maxFrameSize := uint(trafficSpec.MaxFrameSize)
+	burst := uint(trafficSpec.MaxFramesPerInterval) * maxFrameSize
+
+	// The bits per interval times the intervals per second, a very short interval can take the rate beyond 64 bits
+	// (with room for the excess rate, which is a percentage of it)
+	hi, bitsPerSecond := bits.Mul64(uint64(addPercent(burst*8, tolerance.RatePercent)), uint64(interval.Denominator))
+	if hi != 0 || bitsPerSecond > uint64(^uint(0))/100 {
+		return params, errors.New("Invalid traffic specification. Value: " + fmt.Sprint(burst) + " octets every " +
+			fmt.Sprint(interval.Numerator) + "/" + fmt.Sprint(interval.Denominator) + " s. The rate is too large for a flow meter")
+	}
+
+	params.Cbs = addPercent(burst, tolerance.RatePercent)
+	params.Cir = ceilDiv(uint(bitsPerSecond), uint(interval.Numerator))
+	params.Eir = ceilDiv(params.Cir*tolerance.ExcessPercent, 100)
+	if params.Eir > 0 {
+		params.Ebs = max(ceilDiv(params.Cbs*tolerance.ExcessPercent, 100), maxFrameSize)
+	}
+	return params, nil
+}
+
+/*
+Set the flow meter instance for a stream, with the parameters derived from the traffic specification of the talker
+
+key parameters:
+
+	port, deviceIp, flowId
+
+Parameters to set:
+
+	trafficSpec: the traffic specification of the talker
+	tolerance: how much the flow meter allows above what the talker has announced
+*/
+func SetStreamFlowMeterInstanceTable(root *st.SchemaTree, port string, deviceIp string, flowId uint,
+	trafficSpec *configuration.TrafficSpecification, tolerance Tolerance) ([]*pb.Update, error) {
+
+	params, err := GetParameters(trafficSpec, tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	return SetDefaultFlowMeterInstanceTable(root, port, deviceIp, flowId, params.Cir, params.Cbs, params.Eir, params.Ebs)
+}
+
+/*
+Check the tolerance an operator can configure, larger values let a talker send far more than it has announced
+*/
+func validateTolerance(tolerance Tolerance) error {
+	if tolerance.RatePercent > MaxTolerancePercent {
+		return errors.New("Invalid rate tolerance. Value: " + fmt.Sprint(tolerance.RatePercent) +
+			" %. The rate tolerance must be at most " + fmt.Sprint(MaxTolerancePercent) + " %")
+	}
+	if tolerance.ExcessPercent > MaxTolerancePercent {
+		return errors.New("Invalid excess tolerance. Value: " + fmt.Sprint(tolerance.ExcessPercent) +
+			" %. The excess tolerance must be at most " + fmt.Sprint(MaxTolerancePercent) + " %")
+	}
+	return nil
+}
+
+/*
+Check the parameters a flow meter is set with, a bucket that is in use must be able to pass frames
+The parameters can be given directly (SetDefaultFlowMeterInstanceTable), not only from GetParameters
+*/
+func validateParameters(params Parameters) error {
+	if params.Cir == 0 && params.Eir == 0 {
+		return errors.New("Invalid flow meter. The committed and excess information rate are both 0, every frame would be dropped")
+	}
+	if params.Cir > 0 && params.Cbs == 0 {
+		return errors.New("Invalid committed burst size. Value: 0. The committed burst size must be larger than 0 when the committed information rate is " +
+			fmt.Sprint(params.Cir) + " bits/sec")
+	}
+	if params.Eir > 0 && params.Ebs == 0 {
+		return errors.New("Invalid excess burst size. Value: 0. The excess burst size must be larger than 0 when the excess information rate is " +
+			fmt.Sprint(params.Eir) + " bits/sec")
+	}
+	return nil
+}
+
+// value increased by percent, rounded up
+func addPercent(value uint, percent uint) uint {
+	return ceilDiv(value*(100+percent), 100)
+}
+
+func ceilDiv(numerator uint, denominator uint) uint {
+	return (numerator + denominator - 1) / denominator
+}
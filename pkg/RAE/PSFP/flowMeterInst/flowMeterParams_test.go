@@ -0,0 +1,127 @@
+package flowmeterinst
+
+import (
+	"testing"
+
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/configuration"
+)
+
+// Two frames of 1500 octets every interval of numerator/denominator seconds
+func getTestTrafficSpec(numerator uint32, denominator uint32) *configuration.TrafficSpecification {
+	return &configuration.TrafficSpecification{
+		Interval:             &configuration.Interval{Numerator: numerator, Denominator: denominator},
+		MaxFramesPerInterval: 2,
+		MaxFrameSize:         1500,
+	}
+}
+
+func TestGetParameters(t *testing.T) {
+	tests := []struct {
+		name        string
+		trafficSpec *configuration.TrafficSpecification
+		tolerance   Tolerance
+		want        Parameters
+	}{
+		{
+			// 3000 octets every ms: 24 Mbit/s, 10 % more
+			name:        "default tolerance",
+			trafficSpec: getTestTrafficSpec(1, 1000),
+			tolerance:   DefaultTolerance,
+			want:        Parameters{Cir: 26400000, Cbs: 3300},
+		},
+		{
+			name:        "no tolerance",
+			trafficSpec: getTestTrafficSpec(1, 1000),
+			tolerance:   Tolerance{},
+			want:        Parameters{Cir: 24000000, Cbs: 3000},
+		},
+		{
+			// the excess burst is half the committed burst, 1500 octets, one frame
+			name:        "excess bucket",
+			trafficSpec: getTestTrafficSpec(1, 1000),
+			tolerance:   Tolerance{ExcessPercent: 50},
+			want:        Parameters{Cir: 24000000, Cbs: 3000, Eir: 12000000, Ebs: 1500},
+		},
+		{
+			// 10 % of the committed burst is 330 octets, less than a frame
+			name:        "excess burst of at least one frame",
+			trafficSpec: getTestTrafficSpec(1, 1000),
+			tolerance:   Tolerance{RatePercent: 10, ExcessPercent: 10},
+			want:        Parameters{Cir: 26400000, Cbs: 3300, Eir: 2640000, Ebs: 1500},
+		},
+		{
+			// 26400 bits every 7 s is 3771.4 bits/s, rounded up
+			name:        "rate rounded up",
+			trafficSpec: getTestTrafficSpec(7, 1),
+			tolerance:   DefaultTolerance,
+			want:        Parameters{Cir: 3772, Cbs: 3300},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetParameters(tt.trafficSpec, tt.tolerance)
+			if err != nil {
+				t.Fatalf("GetParameters() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetParameters() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetParametersErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		trafficSpec *configuration.TrafficSpecification
+		tolerance   Tolerance
+	}{
+		{name: "no traffic specification", trafficSpec: nil, tolerance: DefaultTolerance},
+		{name: "interval of 0", trafficSpec: getTestTrafficSpec(0, 1000), tolerance: DefaultTolerance},
+		{name: "no frames", trafficSpec: &configuration.TrafficSpecification{
+			Interval: &configuration.Interval{Numerator: 1, Denominator: 1000}, MaxFrameSize: 1500}, tolerance: DefaultTolerance},
+		{name: "rate tolerance too large", trafficSpec: getTestTrafficSpec(1, 1000), tolerance: Tolerance{RatePercent: 150}},
+		{name: "excess tolerance too large", trafficSpec: getTestTrafficSpec(1, 1000), tolerance: Tolerance{ExcessPercent: 101}},
+		{name: "rate beyond 64 bits", trafficSpec: &configuration.TrafficSpecification{
+			Interval:             &configuration.Interval{Numerator: 1, Denominator: 4000000000},
+			MaxFramesPerInterval: 65535, MaxFrameSize: 65535}, tolerance: DefaultTolerance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if params, err := GetParameters(tt.trafficSpec, tt.tolerance); err == nil {
+				t.Errorf("GetParameters() = %+v, want an error", params)
+			}
+		})
+	}
+}
+
+// The parameters given directly to the flow meter are checked as well
+func TestSetDefaultFlowMeterInstanceTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  Parameters
+		wantErr bool
+	}{
+		{name: "committed bucket", params: Parameters{Cir: 24000000, Cbs: 3000}},
+		{name: "only an excess bucket", params: Parameters{Eir: 1000000, Ebs: 1500}},
+		{name: "no rate", params: Parameters{Cbs: 3000, Ebs: 1500}, wantErr: true},
+		{name: "no committed burst", params: Parameters{Cir: 24000000}, wantErr: true},
+		{name: "no excess burst", params: Parameters{Cir: 24000000, Cbs: 3000, Eir: 1000000}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := &st.SchemaTree{Name: "data", Kind: st.KindContainer}
+			updates, err := SetDefaultFlowMeterInstanceTable(root, "sw0p1", "10.0.0.1", 1, tt.params.Cir, tt.params.Cbs, tt.params.Eir, tt.params.Ebs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetDefaultFlowMeterInstanceTable(%+v) error = %v, want error %v", tt.params, err, tt.wantErr)
+			}
+			if err != nil && len(updates) > 0 {
+				t.Errorf("SetDefaultFlowMeterInstanceTable(%+v) gave %d updates with the error, want none", tt.params, len(updates))
+			}
+		})
+	}
+}
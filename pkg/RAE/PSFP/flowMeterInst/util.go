@@ -6,6 +6,7 @@ Set configuration for the flow meter instance table
 
 import (
 	"fmt"
+	"strconv"
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	path "tsn-service/pkg/RAE/dataStructures/composit"
 	"tsn-service/pkg/RAE/dataStructures/pbMethods"
@@ -13,6 +14,50 @@ import (
 	pb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
+/*
+Get the identifiers of all flow meter instances already configured on any port of the device
+
+key parameters:
+
+	root: The schema tree of the device
+*/
+func GetFlowMeterInstanceIds(root *st.SchemaTree) (ids []uint) {
+	for _, bridgePort := range st.LvlsDownToBridgePorts(root) {
+		for _, id := range st.GetAllKeyValues(bridgePort, "flow-meter-instance-table", "flow-meter-instance-id") {
+			value, err := strconv.ParseUint(id, 10, 32)
+			if err == nil {
+				ids = append(ids, uint(value))
+			}
+		}
+	}
+	return ids
+}
+
+/*
+Delete one entry of the flow meter instance table, its identifier is free to use again
+
+key parameters:
+
+	port, deviceIp, flowId
+*/
+func DeleteFlowMeterInstance(root *st.SchemaTree, port string, deviceIp string, flowId uint) (deletePath *pb.Path) {
+	pathTree, pathPb := getFlowMeterInstancePath(root, port, flowId)
+	st.RemoveFromParent(pathTree)
+	return pbMethods.GetDelete(deviceIp, pathPb)
+}
+
+/*
+Get both the tree and the pb path to one entry of the flow meter instance table
+
+key parameters:
+
+	port, flowId
+*/
+func getFlowMeterInstancePath(root *st.SchemaTree, port string, flowId uint) (*st.SchemaTree, []*pb.PathElem) {
+	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
+	return path.GetParamKeys(bridgePathTree, bridgePathPb, "", "flow-meter-instance-table", map[string]string{"flow-meter-instance-id": fmt.Sprint(flowId)})
+}
+
 /*
 set identifier for the flow meter instance table
 
@@ -25,11 +70,10 @@ Parameters to set:
 	flowId
 */
 func setFlowMeterInstanceTableID(root *st.SchemaTree, port string, deviceIp string, flowId uint) (update *pb.Update) {
-	bridgePathTree, bridgebridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgebridgePathPb, "flow-meter-instance-table")
-	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "flow-meter-instance-identifier")
+	pathLvl1Tree, pathLvl1Pb := getFlowMeterInstancePath(root, port, flowId)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "flow-meter-instance-id")
 
-	pathLvl2Tree.Value = fmt.Sprint(flowId)
+	pathLvl2Tree.SetUint64(uint64(flowId))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(flowId))
 	return update
 }
@@ -39,40 +83,38 @@ set Committed Information Rate (CIR) (bits/sec).
 CIR provided performance by the SP(Service Provider), exact, or might be set a little higher
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, flowId
 
 Parameters to set:
 
 	cir
 */
-func setCommittedInformationRate(root *st.SchemaTree, port string, deviceIp string, cir uint) (update *pb.Update) {
-	bridgePathTree, bridgebridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgebridgePathPb, "flow-meter-instance-table")
+func setCommittedInformationRate(root *st.SchemaTree, port string, deviceIp string, flowId uint, cir uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFlowMeterInstancePath(root, port, flowId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "committed-information-rate")
 
-	pathLvl2Tree.Value = fmt.Sprint(cir)
+	pathLvl2Tree.SetUint64(uint64(cir))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(cir))
 	return update
 }
 
 /*
-set Committed burst Rate (CBS) (octets).
+set Committed Burst Size (CBS) (octets).
 CBS must be at least as large as the MFS (Maximum Frame Size)
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, flowId
 
 Parameters to set:
 
 	cbs
 */
-func setCommittedBurstnRate(root *st.SchemaTree, port string, deviceIp string, cbs uint) (update *pb.Update) {
-	bridgePathTree, bridgebridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgebridgePathPb, "flow-meter-instance-table")
-	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "committed-burst-rate")
+func setCommittedBurstSize(root *st.SchemaTree, port string, deviceIp string, flowId uint, cbs uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFlowMeterInstancePath(root, port, flowId)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "committed-burst-size")
 
-	pathLvl2Tree.Value = fmt.Sprint(cbs)
+	pathLvl2Tree.SetUint64(uint64(cbs))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(cbs))
 	return update
 }
@@ -84,39 +126,58 @@ for which traffic can probably pass through the CEN (Carrier Ethernet Network) a
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, flowId
 
 Parameters to set:
 
 	eir
 */
-func setExcessInformationRate(root *st.SchemaTree, port string, deviceIp string, eir uint) (update *pb.Update) {
-	bridgePathTree, bridgebridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgebridgePathPb, "flow-meter-instance-table")
+func setExcessInformationRate(root *st.SchemaTree, port string, deviceIp string, flowId uint, eir uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFlowMeterInstancePath(root, port, flowId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "excess-information-rate")
 
-	pathLvl2Tree.Value = fmt.Sprint(eir)
+	pathLvl2Tree.SetUint64(uint64(eir))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(eir))
 	return update
 }
 
+/*
+set Excess Burst Size (EBS) (octets).
+EBS must be at least as large as the MFS (Maximum Frame Size) when EIR is larger than 0
+
+key parameters:
+
+	port, deviceIp, flowId
+
+Parameters to set:
+
+	ebs
+*/
+func setExcessBurstSize(root *st.SchemaTree, port string, deviceIp string, flowId uint, ebs uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFlowMeterInstancePath(root, port, flowId)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "excess-burst-size")
+
+	pathLvl2Tree.SetUint64(uint64(ebs))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(ebs))
+	return update
+}
+
 /*
 set Coupling flag (CF) (true or false)
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, flowId
 
 Parameters to set:
 
 	cf
 */
-func setCouplingFlag(root *st.SchemaTree, port string, deviceIp string, cf bool) (update *pb.Update) {
-	bridgePathTree, bridgebridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgebridgePathPb, "flow-meter-instance-table")
+func setCouplingFlag(root *st.SchemaTree, port string, deviceIp string, flowId uint, cf bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFlowMeterInstancePath(root, port, flowId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "coupling-flag")
 
-	pathLvl2Tree.Value = fmt.Sprint(cf)
+	pathLvl2Tree.SetBool(cf)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(cf))
 	return update
 }
@@ -126,18 +187,17 @@ set color mode (CM) (true or false)
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, flowId
 
 Parameters to set:
 
 	cm
 */
-func setColorMode(root *st.SchemaTree, port string, deviceIp string, cm bool) (update *pb.Update) {
-	bridgePathTree, bridgebridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgebridgePathPb, "flow-meter-instance-table")
+func setColorMode(root *st.SchemaTree, port string, deviceIp string, flowId uint, cm bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFlowMeterInstancePath(root, port, flowId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "color-mode")
 
-	pathLvl2Tree.Value = fmt.Sprint(cm)
+	pathLvl2Tree.SetBool(cm)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(cm))
 	return update
 }
@@ -147,18 +207,17 @@ set setDropOnYellow (true or false)
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, flowId
 
 Parameters to set:
 
 	dropOnYellow
 */
-func setDropOnYellow(root *st.SchemaTree, port string, deviceIp string, dropOnYellow bool) (update *pb.Update) {
-	bridgePathTree, bridgebridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgebridgePathPb, "flow-meter-instance-table")
+func setDropOnYellow(root *st.SchemaTree, port string, deviceIp string, flowId uint, dropOnYellow bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFlowMeterInstancePath(root, port, flowId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "drop-on-yellow")
 
-	pathLvl2Tree.Value = fmt.Sprint(dropOnYellow)
+	pathLvl2Tree.SetBool(dropOnYellow)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(dropOnYellow))
 	return update
 }
@@ -169,17 +228,16 @@ Tells if it matters if variable setRed matters
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, flowId
 
 Parameters to set:
 
 	redEnabled
 */
-func setRedEnabled(root *st.SchemaTree, port string, deviceIp string, redEnabled bool) (update *pb.Update) {
-	bridgePathTree, bridgebridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgebridgePathPb, "flow-meter-instance-table")
+func setRedEnabled(root *st.SchemaTree, port string, deviceIp string, flowId uint, redEnabled bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFlowMeterInstancePath(root, port, flowId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "mark-all-frame-red-enabled")
-	pathLvl2Tree.Value = fmt.Sprint(redEnabled)
+	pathLvl2Tree.SetBool(redEnabled)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(redEnabled))
 	return update
 }
@@ -190,18 +248,17 @@ changed to true the bridge, default should be false
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, flowId
 
 Parameters to set:
 
 	red
 */
-func setRed(root *st.SchemaTree, port string, deviceIp string, red bool) (update *pb.Update) {
-	bridgePathTree, bridgebridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgebridgePathPb, "flow-meter-instance-table")
+func setRed(root *st.SchemaTree, port string, deviceIp string, flowId uint, red bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getFlowMeterInstancePath(root, port, flowId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "mark-all-frame-red")
 
-	pathLvl2Tree.Value = fmt.Sprint(red)
+	pathLvl2Tree.SetBool(red)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(red))
 	return update
 }
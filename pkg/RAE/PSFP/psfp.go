@@ -0,0 +1,201 @@
+package psfp
+
+/*
+Compose the Per-Stream Filtering and Policing (PSFP) configuration of a stream.
+For every bridge the stream passes, one entry is set in each of the four PSFP tables at the port where
+the stream is received, and the entries refer to each other:
+
+	stream identification (stream handle) <- stream filter instance -> stream gate instance
+	                                                                 -> flow meter instance
+
+Ref:
+	IEEE 802.1Q-2018 8.6.5
+	IEEE 802.1Q-2018 12.31
+	IEEE 802.1CB-2017 6
+*/
+
+import (
+	"errors"
+	"fmt"
+	pe "tsn-service/pkg/PE"
+	streamgateinst "tsn-service/pkg/RAE/PSFP/StreamGateInst"
+	flowmeterinst "tsn-service/pkg/RAE/PSFP/flowMeterInst"
+	streamfilterinst "tsn-service/pkg/RAE/PSFP/streamFilterInst"
+	"tsn-service/pkg/RAE/PSFP/streamIdTable"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/configuration"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// The PSFP entries that are set for a stream at one port
+type StreamPSFP struct {
+	DeviceIp     string
+	Port         string
+	StreamHandle uint
+	FilterId     uint
+	GateId       uint
+	FlowMeterId  uint
+}
+
+/*
+Build the PSFP configuration for a stream request at every ingress port of its path.
+The identifiers are allocated so they do not collide with entries that are already in the configuration of the devices.
+
+input:
+
+	req: the stream request, the talker decides how the stream is identified and policed
+	path: the bridges the stream passes
+	roots: the configuration of each device on the path, by device ip
+	tolerance: how much the flow meters allow above the traffic specification of the talker
+
+output:
+
+	entries: the identifiers allocated at each port
+	updates: the updates for all devices on the path
+*/
+func ComposeStreamPSFP(req *configuration.Request, path pe.Path, roots map[string]*st.SchemaTree, tolerance flowmeterinst.Tolerance) (
+	entries []StreamPSFP, updates []*pb.Update, err error) {
+
+	return composeStreamPSFP(req, path, roots, tolerance, setDefaultGate)
+}
+
+/*
+Build the PSFP configuration for a stream that is forwarded with Cyclic Queuing and Forwarding (CQF).
+The same as ComposeStreamPSFP, except that the stream gates alternate the queue the frames are put in every cycle.
+
+input:
+
+	req, path, roots, tolerance: as for ComposeStreamPSFP
+	cycleTime: the CQF cycle time in ns
+	queues: the two queues that alternate
+*/
+func ComposeCqfStreamPSFP(req *configuration.Request, path pe.Path, roots map[string]*st.SchemaTree, tolerance flowmeterinst.Tolerance,
+	cycleTime uint, queues [2]uint) (entries []StreamPSFP, updates []*pb.Update, err error) {
+
+	return composeStreamPSFP(req, path, roots, tolerance, getCqfGateSetter(cycleTime, queues))
+}
+
+/*
+Release the PSFP configuration of a stream that is withdrawn.
+The entries of all four tables are removed at every port, so their identifiers can be allocated to other streams.
+
+input:
+
+	entries: the identifiers allocated by ComposeStreamPSFP or ComposeCqfStreamPSFP
+	roots: the configuration of each device on the path, by device ip
+
+output:
+
+	deletes: the paths to delete on all devices on the path
+*/
+func ReleaseStreamPSFP(entries []StreamPSFP, roots map[string]*st.SchemaTree) (deletes []*pb.Path, err error) {
+	for _, entry := range entries {
+		root, ok := roots[entry.DeviceIp]
+		if !ok {
+			return nil, errors.New("no configuration for device " + entry.DeviceIp)
+		}
+
+		deletes = append(deletes,
+			streamIdTable.DeleteStreamHandle(root, entry.Port, entry.DeviceIp, fmt.Sprint(entry.StreamHandle)),
+			streamfilterinst.DeleteStreamFilterInstance(root, entry.Port, entry.DeviceIp, entry.FilterId),
+			streamgateinst.DeleteStreamGateInstance(root, entry.Port, entry.DeviceIp, entry.GateId),
+			flowmeterinst.DeleteFlowMeterInstance(root, entry.Port, entry.DeviceIp, entry.FlowMeterId))
+	}
+	return deletes, nil
+}
+
+func composeStreamPSFP(req *configuration.Request, path pe.Path, roots map[string]*st.SchemaTree, tolerance flowmeterinst.Tolerance,
+	setGate gateSetter) (entries []StreamPSFP, updates []*pb.Update, err error) {
+
+	talker := req.GetTalker()
+	if talker == nil || talker.TrafficSpecification == nil {
+		return nil, nil, errors.New("the request has no talker with a traffic specification")
+	}
+
+	vlanTag, err := GetStreamVlanTag(talker)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Fail before allocating anything if the flow meters can not be derived from the traffic specification
+	if _, err = flowmeterinst.GetParameters(talker.TrafficSpecification, tolerance); err != nil {
+		return nil, nil, err
+	}
+
+	allocators := map[string]*deviceAllocator{}
+	for _, hop := range path {
+		root, ok := roots[hop.DeviceIp]
+		if !ok {
+			return nil, nil, errors.New("no configuration for device " + hop.DeviceIp + " (" + hop.Node + ")")
+		}
+
+		allocator, ok := allocators[hop.DeviceIp]
+		if !ok {
+			allocator = newDeviceAllocator(root)
+			allocators[hop.DeviceIp] = allocator
+		}
+
+		entry := StreamPSFP{
+			DeviceIp:     hop.DeviceIp,
+			Port:         hop.IngressPort,
+			StreamHandle: allocator.streamHandles.next(),
+			FilterId:     allocator.filterIds.next(),
+			GateId:       allocator.gateIds.next(),
+			FlowMeterId:  allocator.flowMeterIds.next(),
+		}
+
+		portUpdates, err := setStreamPSFP(root, entry, talker, vlanTag, tolerance, setGate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed setting PSFP at %s port %s: %w", hop.Node, hop.IngressPort, err)
+		}
+
+		entries = append(entries, entry)
+		updates = append(updates, portUpdates...)
+	}
+
+	return entries, updates, nil
+}
+
+/*
+Set the entries of all four PSFP tables for a stream at one port
+*/
+func setStreamPSFP(root *st.SchemaTree, entry StreamPSFP, talker *configuration.TalkerGroup,
+	vlanTag *configuration.IeeeVlanTag, tolerance flowmeterinst.Tolerance, setGate gateSetter) (updates []*pb.Update, err error) {
+
+	trafficSpec := talker.TrafficSpecification
+
+	streamHandle, err := streamIdTable.NewStreamHandles(fmt.Sprint(entry.StreamHandle), []string{entry.Port},
+		talker.DataFrameSpecification)
+	if err != nil {
+		return nil, err
+	}
+	streamIdUpdates, err := streamIdTable.SetStreamHandle(root, streamHandle, entry.Port, entry.DeviceIp)
+	if err != nil {
+		return nil, err
+	}
+	updates = append(updates, streamIdUpdates...)
+
+	filterUpdates, err := streamfilterinst.SetDefaultStreamFilterInstanceTable(root, entry.Port, entry.DeviceIp, entry.FilterId,
+		int(entry.StreamHandle), int(vlanTag.PriorityCodePoint), int(entry.GateId),
+		[]uint{uint(trafficSpec.MaxFrameSize)}, []uint{entry.FlowMeterId})
+	if err != nil {
+		return nil, err
+	}
+	updates = append(updates, filterUpdates...)
+
+	gateUpdates, err := setGate(root, entry, trafficSpec)
+	if err != nil {
+		return nil, err
+	}
+	updates = append(updates, gateUpdates...)
+
+	flowMeterUpdates, err := flowmeterinst.SetStreamFlowMeterInstanceTable(root, entry.Port, entry.DeviceIp, entry.FlowMeterId,
+		trafficSpec, tolerance)
+	if err != nil {
+		return nil, err
+	}
+	updates = append(updates, flowMeterUpdates...)
+
+	return updates, nil
+}
@@ -1,4 +1,4 @@
-package streamFilterInst
+package streamfilterinst
 
 /*
 Functions to set configuration for stream filter instance table
@@ -18,37 +18,37 @@ import (
 key parameters:
 
 	port, deviceIp
+	filterId: identifier of the stream filter instance
 	streamId: which stream the table is for
 	gateTableId: which stream-gate-instance-table that correlate to this table
 
 Parameters to set:
 
+	prio: priority the filter applies to
 	maxSduSize:
 	flowId:
 	streamBlockedDueToOversizeFrame: Default false
 	streamBlockedDueToOversizeFrameEnabled: default false
 */
-func StreamFilterInstanceTable(root *st.SchemaTree, port string, deviceIp string,
-	streamId int, gateTableId int, maxSduSize []uint, flowId []uint,
+func SetStreamFilterInstanceTable(root *st.SchemaTree, port string, deviceIp string, filterId uint,
+	streamId int, prio int, gateTableId int, maxSduSize []uint, flowId []uint,
 	streamBlockedDueToOversizeFrame bool, streamBlockedDueToOversizeFrameEnabled bool) (updates []*pb.Update, err error) {
 
-	err = nil
-
-	updates = append(updates, setStreamHandleSpecification(root, port, deviceIp, streamId))
-	updates = append(updates, setPrioritySpecification(root, port, deviceIp, streamId)) //TODO: the value should not be streamID
-	updates = append(updates, setStreamGateInstanceId(root, port, deviceIp, gateTableId))
+	updates = append(updates, setStreamFilterInstanceId(root, port, deviceIp, filterId))
+	updates = append(updates, setStreamHandleSpecification(root, port, deviceIp, filterId, streamId))
+	updates = append(updates, setPrioritySpecification(root, port, deviceIp, filterId, prio))
+	updates = append(updates, setStreamGateInstanceId(root, port, deviceIp, filterId, gateTableId))
 
 	// Set filter-specification-table
-	// TODO: check if logical structure
-	filterSpecUpdate, err := setFilterSpecTables(root, port, deviceIp, maxSduSize, flowId)
+	filterSpecUpdate, err := setFilterSpecTables(root, port, deviceIp, filterId, maxSduSize, flowId)
 	if err != nil {
 		//log.Errorf("Failed setting filter specification table for stream filter instance table: %v", err)
-	} else {
-		updates = append(updates, filterSpecUpdate...)
+		return nil, err
 	}
+	updates = append(updates, filterSpecUpdate...)
 
-	updates = append(updates, setStreamBlockedDueToOversizeFrameEnabled(root, port, deviceIp, streamBlockedDueToOversizeFrame))
-	updates = append(updates, setStreamBlockedDueToOversizeFrame(root, port, deviceIp, streamBlockedDueToOversizeFrame))
+	updates = append(updates, setStreamBlockedDueToOversizeFrameEnabled(root, port, deviceIp, filterId, streamBlockedDueToOversizeFrameEnabled))
+	updates = append(updates, setStreamBlockedDueToOversizeFrame(root, port, deviceIp, filterId, streamBlockedDueToOversizeFrame))
 
 	return updates, nil
 }
@@ -56,13 +56,13 @@ func StreamFilterInstanceTable(root *st.SchemaTree, port string, deviceIp string
 /*
 Default stream filter instance table
 */
-func setDefaultStreamFilterInstanceTable(root st.SchemaTree, port string, deviceIp string,
-	streamId int, gateTableId int, maxSduSize []uint, flowId []uint) ([]*pb.Update, error) {
+func SetDefaultStreamFilterInstanceTable(root *st.SchemaTree, port string, deviceIp string, filterId uint,
+	streamId int, prio int, gateTableId int, maxSduSize []uint, flowId []uint) ([]*pb.Update, error) {
 
 	var streamBlockedDueToOversizeFrame bool = false
 	var streamBlockedDueToOversizeFrameEnabled bool = false
 
-	return setStreamFilterInstanceTable(root, port, deviceIp,
-		streamId, gateTableId, maxSduSize, flowId,
+	return SetStreamFilterInstanceTable(root, port, deviceIp, filterId,
+		streamId, prio, gateTableId, maxSduSize, flowId,
 		streamBlockedDueToOversizeFrame, streamBlockedDueToOversizeFrameEnabled)
 }
@@ -7,6 +7,7 @@ Functions to set each value in the stream filter instance table
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	path "tsn-service/pkg/RAE/dataStructures/composit"
 	"tsn-service/pkg/RAE/dataStructures/pbMethods"
@@ -14,23 +15,82 @@ import (
 	pb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
+/*
+Get the identifiers of all stream filter instances already configured on any port of the device
+
+key parameters:
+
+	root: The schema tree of the device
+*/
+func GetStreamFilterInstanceIds(root *st.SchemaTree) (ids []uint) {
+	for _, bridgePort := range st.LvlsDownToBridgePorts(root) {
+		for _, id := range st.GetAllKeyValues(bridgePort, "stream-filter-instance-table", "stream-filter-instance-id") {
+			value, err := strconv.ParseUint(id, 10, 32)
+			if err == nil {
+				ids = append(ids, uint(value))
+			}
+		}
+	}
+	return ids
+}
+
+/*
+Delete one entry of the stream filter instance table, its identifier is free to use again
+
+key parameters:
+
+	port, deviceIp, filterId
+*/
+func DeleteStreamFilterInstance(root *st.SchemaTree, port string, deviceIp string, filterId uint) (deletePath *pb.Path) {
+	pathTree, pathPb := getStreamFilterInstancePath(root, port, filterId)
+	st.RemoveFromParent(pathTree)
+	return pbMethods.GetDelete(deviceIp, pathPb)
+}
+
+/*
+Get both the tree and the pb path to one entry of the stream filter instance table
+
+key parameters:
+
+	port, filterId
+*/
+func getStreamFilterInstancePath(root *st.SchemaTree, port string, filterId uint) (*st.SchemaTree, []*pb.PathElem) {
+	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
+	return path.GetParamKeys(bridgePathTree, bridgePathPb, "", "stream-filter-instance-table", map[string]string{"stream-filter-instance-id": fmt.Sprint(filterId)})
+}
+
+/*
+	set stream-filter-instance-id of the entry in the stream filter instance table
+
+key parameters:
+
+	port, deviceIp, filterId
+*/
+func setStreamFilterInstanceId(root *st.SchemaTree, port string, deviceIp string, filterId uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamFilterInstancePath(root, port, filterId)
+	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "stream-filter-instance-id")
+
+	pathLvl2Tree.SetUint64(uint64(filterId))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(filterId))
+	return update
+}
+
 /*
 	set stream-handle-specification in the frame instance table at the desired port
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, filterId
 
 Parameters to set:
 
 	streamId: id for the stream that the port is for
 */
-func setStreamHandleSpecification(root *st.SchemaTree, port string, deviceIp string, streamId int) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-filter-instance-table")
+func setStreamHandleSpecification(root *st.SchemaTree, port string, deviceIp string, filterId uint, streamId int) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamFilterInstancePath(root, port, filterId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "stream-handle-specification")
 
-	pathLvl2Tree.Value = fmt.Sprint(streamId)
+	pathLvl2Tree.SetInt64(int64(streamId))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbIntTypeVal(streamId))
 	return update
 }
@@ -40,18 +100,17 @@ func setStreamHandleSpecification(root *st.SchemaTree, port string, deviceIp str
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, filterId
 
 Parameters to set:
 
 	prio
 */
-func setPrioritySpecification(root *st.SchemaTree, port string, deviceIp string, prio int) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-filter-instance-table")
+func setPrioritySpecification(root *st.SchemaTree, port string, deviceIp string, filterId uint, prio int) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamFilterInstancePath(root, port, filterId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "priority-specification")
 
-	pathLvl2Tree.Value = fmt.Sprint(prio)
+	pathLvl2Tree.SetInt64(int64(prio))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbIntTypeVal(prio))
 	return update
 }
@@ -61,18 +120,17 @@ func setPrioritySpecification(root *st.SchemaTree, port string, deviceIp string,
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, filterId
 
 Parameters to set:
 
 	gateID: the stream gate instance table that it is connected to
 */
-func setStreamGateInstanceId(root *st.SchemaTree, port string, deviceIp string, gateID int) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-filter-instance-table")
+func setStreamGateInstanceId(root *st.SchemaTree, port string, deviceIp string, filterId uint, gateID int) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamFilterInstancePath(root, port, filterId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "stream-gate-instance-id")
 
-	pathLvl2Tree.Value = fmt.Sprint(gateID)
+	pathLvl2Tree.SetInt64(int64(gateID))
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbIntTypeVal(gateID))
 	return update
 }
@@ -82,14 +140,14 @@ func setStreamGateInstanceId(root *st.SchemaTree, port string, deviceIp string,
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, filterId
 	flowId: for which flow meter instance table the max sdu size is for
 
 Parameters to set:
 
 	maxSduSize: max sdu size for an flow meter instance table
 */
-func setFilterSpecTables(root *st.SchemaTree, port string, deviceIp string, maxSduSize []uint, flowId []uint) ([]*pb.Update, error) {
+func setFilterSpecTables(root *st.SchemaTree, port string, deviceIp string, filterId uint, maxSduSize []uint, flowId []uint) ([]*pb.Update, error) {
 	var finalUpdates []*pb.Update
 
 	if len(maxSduSize) != len(flowId) {
@@ -97,7 +155,7 @@ func setFilterSpecTables(root *st.SchemaTree, port string, deviceIp string, maxS
 	}
 
 	for i := 0; i < len(maxSduSize); i++ {
-		finalUpdates = append(finalUpdates, setFilterSpecTable(root, port, deviceIp, maxSduSize[i], flowId[i]))
+		finalUpdates = append(finalUpdates, setFilterSpecTable(root, port, deviceIp, filterId, maxSduSize[i], flowId[i]))
 	}
 	return finalUpdates, nil
 }
@@ -107,20 +165,20 @@ func setFilterSpecTables(root *st.SchemaTree, port string, deviceIp string, maxS
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, filterId
 	flowId: for which flow meter instance table the max sdu size is for
 
 Parameters to set:
 
 	maxSduSize: max sdu size for an flow meter instance table
 */
-func setFilterSpecTable(root *st.SchemaTree, port string, deviceIp string, maxSduSize uint, flowId uint) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-filter-instance-table")
-	pathLvl2Tree, pathLvl2Pb := path.GetParam1Key(pathLvl1Tree, pathLvl1Pb, "", "filter-specification-table", "flow-meter-instence-identifier", fmt.Sprint(flowId)) // Unsure if "filter-specification-table" and "filter-specification-entry" or just "filter-specification-table" is used
-	pathLvl2Tree.Value = fmt.Sprint(maxSduSize)
+func setFilterSpecTable(root *st.SchemaTree, port string, deviceIp string, filterId uint, maxSduSize uint, flowId uint) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamFilterInstancePath(root, port, filterId)
+	pathLvl2Tree, pathLvl2Pb := path.GetParamKeys(pathLvl1Tree, pathLvl1Pb, "", "filter-specification-table", map[string]string{"flow-meter-instance-id": fmt.Sprint(flowId)})
+	pathLvl3Tree, pathLvl3Pb := path.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "maximum-sdu-size")
+	pathLvl3Tree.SetUint64(uint64(maxSduSize))
 
-	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbUintTypeVal(maxSduSize))
+	update = pbMethods.GetUpdate(deviceIp, pathLvl3Pb, pbMethods.GetPbUintTypeVal(maxSduSize))
 	return update
 }
 
@@ -129,18 +187,17 @@ func setFilterSpecTable(root *st.SchemaTree, port string, deviceIp string, maxSd
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, filterId
 
 Parameters to set:
 
 	blockedEnabled: the boolean to be set
 */
-func setStreamBlockedDueToOversizeFrameEnabled(root *st.SchemaTree, port string, deviceIp string, blockedEnabled bool) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-filter-instance-table")
+func setStreamBlockedDueToOversizeFrameEnabled(root *st.SchemaTree, port string, deviceIp string, filterId uint, blockedEnabled bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamFilterInstancePath(root, port, filterId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "stream-blocked-due-to-oversize-frame-enabled")
 
-	pathLvl2Tree.Value = fmt.Sprint(blockedEnabled)
+	pathLvl2Tree.SetBool(blockedEnabled)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(blockedEnabled))
 	return update
 }
@@ -150,18 +207,17 @@ func setStreamBlockedDueToOversizeFrameEnabled(root *st.SchemaTree, port string,
 
 key parameters:
 
-	port, deviceIp
+	port, deviceIp, filterId
 
 Parameters to set:
 
 	blocked: the value it should be set to
 */
-func setStreamBlockedDueToOversizeFrame(root *st.SchemaTree, port string, deviceIp string, blocked bool) (update *pb.Update) {
-	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
-	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-filter-instance-table")
+func setStreamBlockedDueToOversizeFrame(root *st.SchemaTree, port string, deviceIp string, filterId uint, blocked bool) (update *pb.Update) {
+	pathLvl1Tree, pathLvl1Pb := getStreamFilterInstancePath(root, port, filterId)
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "stream-blocked-due-to-oversize-frame")
 
-	pathLvl2Tree.Value = fmt.Sprint(blocked)
+	pathLvl2Tree.SetBool(blocked)
 	update = pbMethods.GetUpdate(deviceIp, pathLvl2Pb, pbMethods.GetPbBoolTypeVal(blocked))
 	return update
 }
@@ -5,7 +5,6 @@ Functions to set the configuration for the {in/out}Facing{In/Out}putPort list in
 */
 
 import (
-	"fmt"
 	"tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	"tsn-service/pkg/RAE/dataStructures/composit"
 
@@ -87,11 +86,12 @@ func setOutFacingOutPortListIndex(root *SchemaTreeMethods.SchemaTree,
 }
 
 /*
-Set whole {in/out}Facing{In/Out}putPort list, one value at a time
+Set whole {in/out}Facing{In/Out}putPort list, a leaf-list that is set as one
 
 key parameters:
 
 	port, deviceIp, currStreamHandle
+	whichIoIo: which combination of in- and output should be used
 
 parameters to set:
 
@@ -101,37 +101,10 @@ parameters to set:
 func setIoFacingIoPortList(root *SchemaTreeMethods.SchemaTree,
 	currStreamHandle string, whichIoIo string, IOFacingIOPortList []string, port string, deviceIP string) (updates []*pb.Update) {
 
-	for i := 0; i < len(IOFacingIOPortList); i++ {
-		updates = append(updates, setIoFacingIoPortListIndex(
-			root, currStreamHandle, i, whichIoIo, IOFacingIOPortList, port, deviceIP))
-	}
-	return updates
-}
-
-/*
-Set {in/out}Facing{In/Out}putPort value at specific index
-
-key parameters:
-
-	port, deviceIp, currStreamHandle
-	index: index that the FacingPort is for
-	whichIoIo: which combination of in- and output should be used
-
-parameters to set:
-
-	IOFacingIOPortList: the value to be set
-	root: The schema tree of the config that should be updated
-*/
-func setIoFacingIoPortListIndex(root *SchemaTreeMethods.SchemaTree, streamHandle string,
-	index int, whichIoIo string, IOFacingIOPortList []string, port string, deviceIp string) (update *pb.Update) {
-
-	// interfaces -> interface -> bridgePort -> stream-identification -> stream-handle -> IoFacingIoPor
+	// interfaces -> interface -> bridgePort -> stream-identification -> stream-handle -> IoFacingIoPort
 	bridgePathTree, bridgePathPt := composit.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := composit.GetParam0Keys(bridgePathTree, bridgePathPt, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := composit.GetParam1Key(
-		pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
-	pathTree, pathPb := composit.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, whichIoIo+fmt.Sprint(index))
-	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(IOFacingIOPortList[index]))
-	pathTree.Value = IOFacingIOPortList[index]
-	return update
+	pathStreamHandleTree, pathStreamHandlePb := composit.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": currStreamHandle})
+	_, pathPb := composit.SetParamLeafList(pathStreamHandleTree, pathStreamHandlePb, whichIoIo, IOFacingIOPortList)
+	return []*pb.Update{pbMethods.GetTypedValUpdate(deviceIP, pathPb, pbMethods.GetPbStringLeafListTypeVal(IOFacingIOPortList))}
 }
@@ -1,7 +1,6 @@
 package streamIdTable
 
 import (
-	"fmt"
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	path "tsn-service/pkg/RAE/dataStructures/composit"
 	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
@@ -14,23 +13,26 @@ import (
 /* ------------------------------------------------------------------------------------------------------------- */
 
 /*
-Set maxCurrentStreams at the stream identification table
+Set active-destination-mac-identification-entry at the stream identification table
+Input:
+
+	activeId: the upper and lower values to set
 */
-// func setActiveDestMacId(port string, deviceIp string, streamHandle string, activeId activeDestMacIdEntry) (updates []*pb.Update) {
-// 	updates = append(updates, setActiveDestMacIdUpperDestMac(port, deviceIp, streamHandle, activeId.upperDestMac))
-// 	updates = append(updates, setActiveDestMacIdUpperTagged(port, deviceIp, streamHandle, activeId.upperTagged))
-// 	updates = append(updates, setActiveDestMacIdUpperVlan(port, deviceIp, streamHandle, activeId.upperVlan))
-// 	updates = append(updates, setActiveDestMacIdUpperPrio(port, deviceIp, streamHandle, activeId.upperPriority))
-// 	updates = append(updates, setActiveDestMacIdLowerDestMac(port, deviceIp, streamHandle, activeId.lowerDestMac))
-// 	updates = append(updates, setActiveDestMacIdLowerTagged(port, deviceIp, streamHandle, activeId.lowerTagged))
-// 	updates = append(updates, setActiveDestMacIdLowerVlan(port, deviceIp, streamHandle, activeId.lowerVlan))
-// 	return updates
-// }
+func setActiveDestMacId(root *st.SchemaTree, port string, deviceIp string, streamHandle string, activeId ActiveDestMacIdEntry) (updates []*pb.Update) {
+	updates = append(updates, updateActiveDestMacIdUpperDestMac(root, port, deviceIp, streamHandle, activeId.upperDestMac))
+	updates = append(updates, updateActiveDestMacIdUpperTagged(root, port, deviceIp, streamHandle, activeId.upperTagged))
+	updates = append(updates, updateActiveDestMacIdUpperVlan(root, port, deviceIp, streamHandle, activeId.upperVlan))
+	updates = append(updates, updateActiveDestMacIdUpperPrio(root, port, deviceIp, streamHandle, activeId.upperPriority))
+	updates = append(updates, updateActiveDestMacIdLowerDestMac(root, port, deviceIp, streamHandle, activeId.lowerDestMac))
+	updates = append(updates, updateActiveDestMacIdLowerTagged(root, port, deviceIp, streamHandle, activeId.lowerTagged))
+	updates = append(updates, updateActiveDestMacIdLowerVlan(root, port, deviceIp, streamHandle, activeId.lowerVlan))
+	return updates
+}
 
 func setActiveDestMacIdUpperDestMac(port string, deviceIp string, streamHandle string, destMac string) (update *pb.Update) {
 	bridgePath := pbMethods.GetPath2bridge(port)
 	pathStreamId := pbMethods.GetPath1lvlDown0Keys(bridgePath, "stream-identification")
-	pathStreamHandle := pbMethods.GetPath1lvlDown1Key(pathStreamId, "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandle := pbMethods.GetPath1lvlDownKeys(pathStreamId, "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamId := pbMethods.GetPath1lvlDown0Keys(pathStreamHandle, "active-destination-mac-identification-entry")
 	path := pbMethods.GetPath1lvlDown0Keys(pathNullStreamId, "upper-destination-mac")
 
@@ -41,11 +43,11 @@ func setActiveDestMacIdUpperDestMac(port string, deviceIp string, streamHandle s
 func updateActiveDestMacIdUpperDestMac(root *st.SchemaTree, port string, deviceIp string, streamHandle string, destMac string) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "active-destination-mac-identification-entry")
 	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "upper-destination-mac")
 
-	pathTree.Value = destMac
+	pathTree.SetString(destMac)
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(destMac))
 	return update
 }
@@ -53,7 +55,7 @@ func updateActiveDestMacIdUpperDestMac(root *st.SchemaTree, port string, deviceI
 func setActiveDestMacIdUpperTagged(port string, deviceIp string, streamHandle string, Tagg string) (update *pb.Update) {
 	bridgePath := pbMethods.GetPath2bridge(port)
 	pathStreamId := pbMethods.GetPath1lvlDown0Keys(bridgePath, "stream-identification")
-	pathStreamHandle := pbMethods.GetPath1lvlDown1Key(pathStreamId, "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandle := pbMethods.GetPath1lvlDownKeys(pathStreamId, "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamId := pbMethods.GetPath1lvlDown0Keys(pathStreamHandle, "active-destination-mac-identification-entry")
 	path := pbMethods.GetPath1lvlDown0Keys(pathNullStreamId, "upper-tagged")
 
@@ -64,11 +66,11 @@ func setActiveDestMacIdUpperTagged(port string, deviceIp string, streamHandle st
 func updateActiveDestMacIdUpperTagged(root *st.SchemaTree, port string, deviceIp string, streamHandle string, Tagg string) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "active-destination-mac-identification-entry")
 	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "upper-tagged")
 
-	pathTree.Value = Tagg
+	pathTree.SetEnum(Tagg)
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(Tagg))
 	return update
 }
@@ -76,7 +78,7 @@ func updateActiveDestMacIdUpperTagged(root *st.SchemaTree, port string, deviceIp
 func setActiveDestMacIdUpperVlan(port string, deviceIp string, streamHandle string, Vlan uint) (update *pb.Update) {
 	bridgePath := pbMethods.GetPath2bridge(port)
 	pathStreamId := pbMethods.GetPath1lvlDown0Keys(bridgePath, "stream-identification")
-	pathStreamHandle := pbMethods.GetPath1lvlDown1Key(pathStreamId, "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandle := pbMethods.GetPath1lvlDownKeys(pathStreamId, "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamId := pbMethods.GetPath1lvlDown0Keys(pathStreamHandle, "active-destination-mac-identification-entry")
 	path := pbMethods.GetPath1lvlDown0Keys(pathNullStreamId, "upper-vlan")
 
@@ -87,11 +89,11 @@ func setActiveDestMacIdUpperVlan(port string, deviceIp string, streamHandle stri
 func updateActiveDestMacIdUpperVlan(root *st.SchemaTree, port string, deviceIp string, streamHandle string, Vlan uint) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "active-destination-mac-identification-entry")
 	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "upper-vlan")
 
-	pathTree.Value = fmt.Sprint(Vlan)
+	pathTree.SetUint64(uint64(Vlan))
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(Vlan))
 	return update
 }
@@ -99,7 +101,7 @@ func updateActiveDestMacIdUpperVlan(root *st.SchemaTree, port string, deviceIp s
 func setActiveDestMacIdUpperPrio(port string, deviceIp string, streamHandle string, prio uint) (update *pb.Update) {
 	bridgePath := pbMethods.GetPath2bridge(port)
 	pathStreamId := pbMethods.GetPath1lvlDown0Keys(bridgePath, "stream-identification")
-	pathStreamHandle := pbMethods.GetPath1lvlDown1Key(pathStreamId, "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandle := pbMethods.GetPath1lvlDownKeys(pathStreamId, "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamId := pbMethods.GetPath1lvlDown0Keys(pathStreamHandle, "active-destination-mac-identification-entry")
 	path := pbMethods.GetPath1lvlDown0Keys(pathNullStreamId, "upper-priority")
 
@@ -110,11 +112,11 @@ func setActiveDestMacIdUpperPrio(port string, deviceIp string, streamHandle stri
 func updateActiveDestMacIdUpperPrio(root *st.SchemaTree, port string, deviceIp string, streamHandle string, prio uint) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "active-destination-mac-identification-entry")
 	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "upper-priority")
 
-	pathTree.Value = fmt.Sprint(prio)
+	pathTree.SetUint64(uint64(prio))
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(prio))
 	return update
 }
@@ -122,9 +124,9 @@ func updateActiveDestMacIdUpperPrio(root *st.SchemaTree, port string, deviceIp s
 func setActiveDestMacIdLowerDestMac(port string, deviceIp string, streamHandle string, destMac string) (update *pb.Update) {
 	bridgePath := pbMethods.GetPath2bridge(port)
 	pathStreamId := pbMethods.GetPath1lvlDown0Keys(bridgePath, "stream-identification")
-	pathStreamHandle := pbMethods.GetPath1lvlDown1Key(pathStreamId, "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandle := pbMethods.GetPath1lvlDownKeys(pathStreamId, "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamId := pbMethods.GetPath1lvlDown0Keys(pathStreamHandle, "active-destination-mac-identification-entry")
-	path := pbMethods.GetPath1lvlDown0Keys(pathNullStreamId, "lower-destionation-mac")
+	path := pbMethods.GetPath1lvlDown0Keys(pathNullStreamId, "lower-destination-mac")
 
 	update = pbMethods.GetUpdate(deviceIp, path, pbMethods.GetPbStringTypeVal(destMac))
 	return update
@@ -133,11 +135,11 @@ func setActiveDestMacIdLowerDestMac(port string, deviceIp string, streamHandle s
 func updateActiveDestMacIdLowerDestMac(root *st.SchemaTree, port string, deviceIp string, streamHandle string, destMac string) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "active-destination-mac-identification-entry")
-	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "lower-destionation-mac")
+	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "lower-destination-mac")
 
-	pathTree.Value = destMac
+	pathTree.SetString(destMac)
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(destMac))
 	return update
 }
@@ -145,7 +147,7 @@ func updateActiveDestMacIdLowerDestMac(root *st.SchemaTree, port string, deviceI
 func setActiveDestMacIdLowerTagged(port string, deviceIp string, streamHandle string, Tagg string) (update *pb.Update) {
 	bridgePath := pbMethods.GetPath2bridge(port)
 	pathStreamId := pbMethods.GetPath1lvlDown0Keys(bridgePath, "stream-identification")
-	pathStreamHandle := pbMethods.GetPath1lvlDown1Key(pathStreamId, "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandle := pbMethods.GetPath1lvlDownKeys(pathStreamId, "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamId := pbMethods.GetPath1lvlDown0Keys(pathStreamHandle, "active-destination-mac-identification-entry")
 	path := pbMethods.GetPath1lvlDown0Keys(pathNullStreamId, "lower-tagged")
 
@@ -156,11 +158,11 @@ func setActiveDestMacIdLowerTagged(port string, deviceIp string, streamHandle st
 func updateActiveDestMacIdLowerTagged(root *st.SchemaTree, port string, deviceIp string, streamHandle string, Tagg string) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "active-destination-mac-identification-entry")
 	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "lower-tagged")
 
-	pathTree.Value = Tagg
+	pathTree.SetEnum(Tagg)
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(Tagg))
 	return update
 }
@@ -168,7 +170,7 @@ func updateActiveDestMacIdLowerTagged(root *st.SchemaTree, port string, deviceIp
 func setActiveDestMacIdLowerVlan(port string, deviceIp string, streamHandle string, Vlan uint) (update *pb.Update) {
 	bridgePath := pbMethods.GetPath2bridge(port)
 	pathStreamId := pbMethods.GetPath1lvlDown0Keys(bridgePath, "stream-identification")
-	pathStreamHandle := pbMethods.GetPath1lvlDown1Key(pathStreamId, "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandle := pbMethods.GetPath1lvlDownKeys(pathStreamId, "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamId := pbMethods.GetPath1lvlDown0Keys(pathStreamHandle, "active-destination-mac-identification-entry")
 	path := pbMethods.GetPath1lvlDown0Keys(pathNullStreamId, "lower-vlan")
 
@@ -179,11 +181,11 @@ func setActiveDestMacIdLowerVlan(port string, deviceIp string, streamHandle stri
 func updateActiveDestMacIdLowerVlan(root *st.SchemaTree, port string, deviceIp string, streamHandle string, Vlan uint) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "active-destination-mac-identification-entry")
 	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "lower-vlan")
 
-	pathTree.Value = fmt.Sprint(Vlan)
+	pathTree.SetUint64(uint64(Vlan))
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(Vlan))
 	return update
 }
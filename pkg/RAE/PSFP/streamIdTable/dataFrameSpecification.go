@@ -0,0 +1,94 @@
+package streamIdTable
+
+/*
+Pick the stream identification function from the data frame specification of the talker
+Ref:
+	IEEE 802.1Qcc-2018 46.2.3.4.1 (DataFrameSpecification)
+	IEEE 802.1CB-2017 6.4-6.7
+	IEEE 802.1CBdb-2021 6.8
+*/
+
+import (
+	"errors"
+	"tsn-service/pkg/structures/configuration"
+)
+
+// Mask where every bit of a mac address is compared
+const fullMacMask = "ff-ff-ff-ff-ff-ff"
+
+/*
+Build the stream handle entry with the most specific identification function the data frame specification allows:
+  - ipv4 or ipv6 tuple: IP stream identification
+  - both source and destination mac: mask-and-match on both addresses, so streams from one talker to different listeners are told apart
+  - only source mac: source mac stream identification
+  - only destination mac: null stream identification
+
+The vlan tag is matched if there is one, otherwise frames are identified regardless of their tag.
+
+input:
+
+	streamHandle: the stream handle the identified frames get
+	outFacingInputPortList: ports where the frames are identified when they are received
+	frameSpecs: the data frame specification of the talker
+*/
+func NewStreamHandles(streamHandle string, outFacingInputPortList []string,
+	frameSpecs []*configuration.DataFrameSpecification) (StreamHandles, error) {
+
+	var macAddr *configuration.IeeeMacAddress
+	var vlanTag *configuration.IeeeVlanTag
+	var ipv4Tup *configuration.Ipv4Tuple
+	var ipv6Tup *configuration.Ipv6Tuple
+	for _, frameSpec := range frameSpecs {
+		if frameSpec.MacAddr != nil {
+			macAddr = frameSpec.MacAddr
+		}
+		if frameSpec.VlanTag != nil {
+			vlanTag = frameSpec.VlanTag
+		}
+		if frameSpec.Ipv4Tup != nil {
+			ipv4Tup = frameSpec.Ipv4Tup
+		}
+		if frameSpec.Ipv6Tup != nil {
+			ipv6Tup = frameSpec.Ipv6Tup
+		}
+	}
+
+	// tagged is "all" when the talker sends no vlan tag, then the vlan is not used
+	tagged, vlan, priority := "all", uint(0), uint(0)
+	if vlanTag != nil {
+		tagged, vlan, priority = "tagged", uint(vlanTag.VlanId), uint(vlanTag.PriorityCodePoint)
+	}
+
+	var destMac, sourceMac string
+	if macAddr != nil {
+		destMac, sourceMac = macAddr.DestinationMac, macAddr.SourceMac
+	}
+
+	switch {
+	case ipv4Tup != nil:
+		return NewIpStreamHandles(streamHandle, outFacingInputPortList, destMac, tagged, vlan,
+			ipv4Tup.SrcIpAddr, ipv4Tup.DestIpAddr, uint(ipv4Tup.Dscp), uint(ipv4Tup.Protocol),
+			uint(ipv4Tup.SrcPort), uint(ipv4Tup.DestPort)), nil
+
+	case ipv6Tup != nil:
+		return NewIpStreamHandles(streamHandle, outFacingInputPortList, destMac, tagged, vlan,
+			ipv6Tup.SrcIpAddr, ipv6Tup.DestIpAddr, uint(ipv6Tup.Dscp), uint(ipv6Tup.Protocol),
+			uint(ipv6Tup.SrcPort), uint(ipv6Tup.DestPort)), nil
+
+	case destMac != "" && sourceMac != "":
+		var vlanMask, priorityMask uint
+		if vlanTag != nil {
+			vlanMask, priorityMask = 0xfff, 0x7
+		}
+		return NewMaskAndMatchStreamHandles(streamHandle, outFacingInputPortList,
+			destMac, fullMacMask, sourceMac, fullMacMask, vlan, vlanMask, priority, priorityMask), nil
+
+	case sourceMac != "":
+		return NewSourceMacStreamHandles(streamHandle, outFacingInputPortList, sourceMac, tagged, vlan), nil
+
+	case destMac != "":
+		return NewNullStreamHandles(streamHandle, outFacingInputPortList, destMac, tagged, vlan), nil
+	}
+
+	return StreamHandles{}, errors.New("the data frame specification has neither mac addresses nor an ip tuple to identify the stream by")
+}
@@ -0,0 +1,187 @@
+package streamIdTable
+
+/*
+Set the configuration entries in the IP stream identification table
+Ref: IEEE 802.1CB-2017 6.7, 9.1.4
+*/
+
+import (
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Set ip-stream-identification-entry at the stream identification table
+Input:
+
+	ipStream: all the values of the entry
+*/
+func setIpStreamId(root *st.SchemaTree, port string, deviceIp string, streamHandle string, ipStream IpStreamIdEntry) (updates []*pb.Update) {
+	updates = append(updates, setIpStreamIdDestMac(root, port, deviceIp, streamHandle, ipStream.destMac))
+	updates = append(updates, setIpStreamIdTagged(root, port, deviceIp, streamHandle, ipStream.tagged))
+	updates = append(updates, setIpStreamIdVlan(root, port, deviceIp, streamHandle, ipStream.vlan))
+	updates = append(updates, setIpStreamIdIpSource(root, port, deviceIp, streamHandle, ipStream.ipSource))
+	updates = append(updates, setIpStreamIdIpDestination(root, port, deviceIp, streamHandle, ipStream.ipDestination))
+	updates = append(updates, setIpStreamIdDscp(root, port, deviceIp, streamHandle, ipStream.dscp))
+	updates = append(updates, setIpStreamIdNextProtocol(root, port, deviceIp, streamHandle, ipStream.nextProtocol))
+	updates = append(updates, setIpStreamIdSourcePort(root, port, deviceIp, streamHandle, ipStream.sourcePort))
+	updates = append(updates, setIpStreamIdDestinationPort(root, port, deviceIp, streamHandle, ipStream.destinationPort))
+	return updates
+}
+
+/*
+Get both the tree and the pb path to the ip-stream-identification-entry of a stream handle
+*/
+func getIpStreamIdPath(root *st.SchemaTree, port string, streamHandle string) (*st.SchemaTree, []*pb.PathElem) {
+	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
+	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
+	return path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "ip-stream-identification-entry")
+}
+
+/*
+Set destination mac address in ip-stream-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setIpStreamIdDestMac(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value string) (update *pb.Update) {
+	entryTree, entryPb := getIpStreamIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "destination-mac")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set tagged in ip-stream-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setIpStreamIdTagged(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value string) (update *pb.Update) {
+	entryTree, entryPb := getIpStreamIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "tagged")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set vlan in ip-stream-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setIpStreamIdVlan(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value uint) (update *pb.Update) {
+	entryTree, entryPb := getIpStreamIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "vlan")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set source ip address in ip-stream-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setIpStreamIdIpSource(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value string) (update *pb.Update) {
+	entryTree, entryPb := getIpStreamIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "ip-source")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set destination ip address in ip-stream-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setIpStreamIdIpDestination(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value string) (update *pb.Update) {
+	entryTree, entryPb := getIpStreamIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "ip-destination")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set differentiated services codepoint in ip-stream-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setIpStreamIdDscp(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value uint) (update *pb.Update) {
+	entryTree, entryPb := getIpStreamIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "dscp")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set next protocol (e.g. 6 for TCP, 17 for UDP) in ip-stream-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setIpStreamIdNextProtocol(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value uint) (update *pb.Update) {
+	entryTree, entryPb := getIpStreamIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "next-protocol")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set source port in ip-stream-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setIpStreamIdSourcePort(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value uint) (update *pb.Update) {
+	entryTree, entryPb := getIpStreamIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "source-port")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set destination port in ip-stream-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setIpStreamIdDestinationPort(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value uint) (update *pb.Update) {
+	entryTree, entryPb := getIpStreamIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "destination-port")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
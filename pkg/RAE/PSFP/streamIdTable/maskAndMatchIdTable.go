@@ -0,0 +1,170 @@
+package streamIdTable
+
+/*
+Set the configuration entries in the mask-and-match stream identification table
+Ref: IEEE 802.1CBdb-2021 6.8
+*/
+
+import (
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Set mask-and-match-identification-entry at the stream identification table
+Input:
+
+	maskAndMatch: all the values of the entry
+*/
+func setMaskAndMatchId(root *st.SchemaTree, port string, deviceIp string, streamHandle string, maskAndMatch MaskAndMatchIdEntry) (updates []*pb.Update) {
+	updates = append(updates, setMaskAndMatchIdDestMac(root, port, deviceIp, streamHandle, maskAndMatch.destMac))
+	updates = append(updates, setMaskAndMatchIdDestMacMask(root, port, deviceIp, streamHandle, maskAndMatch.destMacMask))
+	updates = append(updates, setMaskAndMatchIdSourceMac(root, port, deviceIp, streamHandle, maskAndMatch.sourceMac))
+	updates = append(updates, setMaskAndMatchIdSourceMacMask(root, port, deviceIp, streamHandle, maskAndMatch.sourceMacMask))
+	updates = append(updates, setMaskAndMatchIdVlan(root, port, deviceIp, streamHandle, maskAndMatch.vlan))
+	updates = append(updates, setMaskAndMatchIdVlanMask(root, port, deviceIp, streamHandle, maskAndMatch.vlanMask))
+	updates = append(updates, setMaskAndMatchIdPriority(root, port, deviceIp, streamHandle, maskAndMatch.priority))
+	updates = append(updates, setMaskAndMatchIdPriorityMask(root, port, deviceIp, streamHandle, maskAndMatch.priorityMask))
+	return updates
+}
+
+/*
+Get both the tree and the pb path to the mask-and-match-identification-entry of a stream handle
+*/
+func getMaskAndMatchIdPath(root *st.SchemaTree, port string, streamHandle string) (*st.SchemaTree, []*pb.PathElem) {
+	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
+	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
+	return path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "mask-and-match-identification-entry")
+}
+
+/*
+Set destination mac address in mask-and-match-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setMaskAndMatchIdDestMac(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value string) (update *pb.Update) {
+	entryTree, entryPb := getMaskAndMatchIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "destination-mac")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set destination mac mask in mask-and-match-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setMaskAndMatchIdDestMacMask(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value string) (update *pb.Update) {
+	entryTree, entryPb := getMaskAndMatchIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "destination-mac-mask")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set source mac address in mask-and-match-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setMaskAndMatchIdSourceMac(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value string) (update *pb.Update) {
+	entryTree, entryPb := getMaskAndMatchIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "source-mac")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set source mac mask in mask-and-match-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setMaskAndMatchIdSourceMacMask(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value string) (update *pb.Update) {
+	entryTree, entryPb := getMaskAndMatchIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "source-mac-mask")
+
+	pathTree.SetString(value)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(value))
+	return update
+}
+
+/*
+Set vlan in mask-and-match-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setMaskAndMatchIdVlan(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value uint) (update *pb.Update) {
+	entryTree, entryPb := getMaskAndMatchIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "vlan")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set vlan mask in mask-and-match-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setMaskAndMatchIdVlanMask(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value uint) (update *pb.Update) {
+	entryTree, entryPb := getMaskAndMatchIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "vlan-mask")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set priority in mask-and-match-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setMaskAndMatchIdPriority(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value uint) (update *pb.Update) {
+	entryTree, entryPb := getMaskAndMatchIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "priority")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
+
+/*
+Set priority mask in mask-and-match-identification-entry
+
+key parameter:
+
+	port, deviceIp, streamHandle
+*/
+func setMaskAndMatchIdPriorityMask(root *st.SchemaTree, port string, deviceIp string, streamHandle string, value uint) (update *pb.Update) {
+	entryTree, entryPb := getMaskAndMatchIdPath(root, port, streamHandle)
+	pathTree, pathPb := path.GetParam0Keys(entryTree, entryPb, "priority-mask")
+
+	pathTree.SetUint64(uint64(value))
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(value))
+	return update
+}
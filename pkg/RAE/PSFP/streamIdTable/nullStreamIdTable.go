@@ -5,7 +5,6 @@ Set the configuration entries in teh null stream identification table
 */
 
 import (
-	"fmt"
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	path "tsn-service/pkg/RAE/dataStructures/composit"
 	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
@@ -45,7 +44,7 @@ Set destination mac address in Null-stream-identification-table
 func setNullStreamIdDestMac(port string, deviceIp string, streamHandle string, destMac string) (update *pb.Update) {
 	bridgePath := pbMethods.GetPath2bridge(port)
 	pathStreamId := pbMethods.GetPath1lvlDown0Keys(bridgePath, "stream-identification")
-	pathStreamHandle := pbMethods.GetPath1lvlDown1Key(pathStreamId, "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandle := pbMethods.GetPath1lvlDownKeys(pathStreamId, "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamId := pbMethods.GetPath1lvlDown0Keys(pathStreamHandle, "null-stream-identification-entry")
 	path := pbMethods.GetPath1lvlDown0Keys(pathNullStreamId, "destination-mac")
 
@@ -56,10 +55,10 @@ func setNullStreamIdDestMac(port string, deviceIp string, streamHandle string, d
 func updateNullStreamIdDestMac(root *st.SchemaTree, port string, deviceIp string, streamHandle string, destMac string) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "null-stream-identification-entry")
 	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "destination-mac")
-	pathTree.Value = destMac
+	pathTree.SetString(destMac)
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(destMac))
 	return update
 }
@@ -70,10 +69,10 @@ Set tagged in Null-stream-identification-table
 func updateNullStreamIdTagged(root *st.SchemaTree, port string, deviceIp string, streamHandle string, tagged string) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "null-stream-identification-entry")
 	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "tagged")
-	pathTree.Value = tagged
+	pathTree.SetEnum(tagged)
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(tagged))
 	return update
 }
@@ -84,7 +83,7 @@ Set vlan in Null-stream-identification-table
 func setNullStreamIdVlan(port string, deviceIp string, streamHandle string, vlan uint) (update *pb.Update) {
 	bridgePath := pbMethods.GetPath2bridge(port)
 	pathStreamId := pbMethods.GetPath1lvlDown0Keys(bridgePath, "stream-identification")
-	pathStreamHandle := pbMethods.GetPath1lvlDown1Key(pathStreamId, "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandle := pbMethods.GetPath1lvlDownKeys(pathStreamId, "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamId := pbMethods.GetPath1lvlDown0Keys(pathStreamHandle, "null-stream-identification-entry")
 	path := pbMethods.GetPath1lvlDown0Keys(pathNullStreamId, "vlan")
 
@@ -95,11 +94,11 @@ func setNullStreamIdVlan(port string, deviceIp string, streamHandle string, vlan
 func updateNullStreamIdVlan(root *st.SchemaTree, port string, deviceIp string, streamHandle string, vlan uint) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "null-stream-identification-entry")
 	pathTree, pathPb := path.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "vlan")
 
-	pathTree.Value = fmt.Sprint(vlan)
+	pathTree.SetUint64(uint64(vlan))
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(vlan))
 	return update
 }
@@ -1,7 +1,6 @@
 package streamIdTable
 
 import (
-	"fmt"
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	rae "tsn-service/pkg/RAE/dataStructures/composit"
 	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
@@ -39,13 +38,13 @@ func setSourceMacIdSourceMac(root *st.SchemaTree, port string, deviceIp string,
 	pathStreamIdTree, pathStreamIdPb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
 
 	pathStreamHandleTree, pathStreamHandlePb :=
-		rae.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+		rae.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb :=
 		rae.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "source-mac-identification-entry")
 
 	pathTree, pathPb := rae.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "source-mac")
 
-	pathTree.Value = srcMac
+	pathTree.SetString(srcMac)
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(srcMac))
 	return update
 }
@@ -66,13 +65,13 @@ func setSourceMacIdTagged(root *st.SchemaTree, port string, deviceIp string, str
 	pathStreamIdTree, pathStreamIdPb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
 
 	pathStreamHandleTree, pathStreamHandlePb :=
-		rae.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+		rae.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb :=
 		rae.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "source-mac-identification-entry")
 
 	pathTree, pathPb := rae.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "tagged")
 
-	pathTree.Value = tagged
+	pathTree.SetEnum(tagged)
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(tagged))
 	return update
 }
@@ -93,13 +92,13 @@ func setSourceMacIdVlan(root *st.SchemaTree, port string, deviceIp string, strea
 	pathStreamIdTree, pathStreamIdPb := rae.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
 
 	pathStreamHandleTree, pathStreamHandlePb :=
-		rae.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+		rae.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathNullStreamIdTree, pathNullStreamIdPb :=
 		rae.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "source-mac-identification-entry")
 
 	pathTree, pathPb := rae.GetParam0Keys(pathNullStreamIdTree, pathNullStreamIdPb, "vlan")
 
-	pathTree.Value = fmt.Sprint(vlan)
+	pathTree.SetUint64(uint64(vlan))
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(vlan))
 	return update
 }
@@ -12,7 +12,12 @@ Ref:
 */
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
 
 	pb "github.com/openconfig/gnmi/proto/gnmi"
 	// logger "git.cs.kau.se/hamzchah/opencnc_kafka-exporter/logger/pkg/logger"
@@ -33,44 +38,105 @@ parameters to set:
 */
 func UpdatePSFPStreamIdTable(maxCurrentStreams uint, streamHandleValuesList []StreamHandles, port string, deviceIp string) error {
 
-	/*
-		var log = logger.GetLogger()
-		// TODO: get configuration from k/v-store
+	// TODO: get configuration from k/v-store
 
+	// updates, err := SetStreamIdTable(&root, maxCurrentStreams, streamHandleValuesList, port, deviceIp)
 
-		var updates []*pb.Update
+	//TODO: update config service
 
-		// Set configuration for Max Concurrent Stream
-		updates = append(updates, setMaxConcurrentStream(&root, maxCurrentStreams, port, deviceIp))
+	// TODO Update k/v-store
+	return nil
+}
 
-		// set each streamHandlesTable
-		for i := 0; i < len(streamHandleValuesList); i++ {
-			// Set stream handles entries
-			streamHandleUpdate, err := updatePSFPStreamHandleTable(&root, maxCurrentStreams, streamHandleValuesList[i], port, deviceIp)
-			if err != nil {
-				log.Errorf("Failed setting a stream handles table for stream identification table: %v", err)
-			} else {
-				updates = append(updates, streamHandleUpdate...)
-			}
+/*
+Set the configuration of the stream identification table
+
+key parameters:
+  - port; port where the table is at
+  - deviceIp; IP address for the device which port one look at
+
+parameters to set:
+  - maxCurrentStreams; he maximum number of concurrent stream identification functions supported by the application
+  - streamHandleValuesList; the stream handle entries to set
+*/
+func SetStreamIdTable(root *st.SchemaTree, maxCurrentStreams uint, streamHandleValuesList []StreamHandles,
+	port string, deviceIp string) (updates []*pb.Update, err error) {
+
+	// Set configuration for Max Concurrent Stream
+	updates = append(updates, setMaxConcurrentStream(root, maxCurrentStreams, port, deviceIp))
+
+	// set each streamHandlesTable
+	for i := 0; i < len(streamHandleValuesList); i++ {
+		streamHandleUpdate, err := SetStreamHandle(root, streamHandleValuesList[i], port, deviceIp)
+		if err != nil {
+			//log.Errorf("Failed setting a stream handles table for stream identification table: %v", err)
+			return nil, err
 		}
+		updates = append(updates, streamHandleUpdate...)
+	}
 
-		//TODO: update config service
+	return updates, nil
+}
 
+/*
+Set one entry in the stream identification table, without changing max concurrent streams
 
-	*/
+key parameters:
+  - port, deviceIp
 
-	// TODO Update k/v-store
-	return nil
+parameters to set:
+  - streamHandleValues; the stream handle entry to set
+*/
+func SetStreamHandle(root *st.SchemaTree, streamHandleValues StreamHandles, port string, deviceIp string) ([]*pb.Update, error) {
+	return updatePSFPStreamHandleTable(root, streamHandleValues, port, deviceIp)
+}
+
+/*
+Get all stream handles already in use on any port of the device
+
+key parameters:
+
+	root: The schema tree of the device
+*/
+func GetStreamHandles(root *st.SchemaTree) (handles []uint) {
+	for _, bridgePort := range st.LvlsDownToBridgePorts(root) {
+		streamId := st.OneLvlDown0Keys(bridgePort, "stream-identification")
+		for _, handle := range st.GetAllKeyValues(streamId, "stream-handles", "stream-handle") {
+			value, err := strconv.ParseUint(handle, 10, 32)
+			if err == nil {
+				handles = append(handles, uint(value))
+			}
+		}
+	}
+	return handles
+}
+
+/*
+Delete the entry of a stream handle in the stream identification table, the stream handle is free to use again
+
+key parameters:
+
+	port, deviceIp, streamHandle
+*/
+func DeleteStreamHandle(root *st.SchemaTree, port string, deviceIp string, streamHandle string) (deletePath *pb.Path) {
+	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
+	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
+	pathTree, pathPb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
+	st.RemoveFromParent(pathTree)
+	return pbMethods.GetDelete(deviceIp, pathPb)
 }
 
 /*
 Set the configuration for the PSFP stream handle table
 */
-func updatePSFPStreamHandleTable(root *st.SchemaTree, maxCurrentStreams uint,
+func updatePSFPStreamHandleTable(root *st.SchemaTree,
 	streamHandleValues StreamHandles, port string, deviceIp string) (updates []*pb.Update, err error) {
 
 	/* ---------------------- Configure each value ---------------------- */
 
+	// set the key of the entry
+	updates = append(updates, setStreamHandleKey(root, port, deviceIp, streamHandleValues.streamHandle))
+
 	// set {in/out}Facing{In/Out}putPort lists
 	updates = append(updates, setInFacingInPortListIndex(
 		root, streamHandleValues.streamHandle, streamHandleValues.inFacingInputPortList, port, deviceIp)...)
@@ -87,14 +153,21 @@ func updatePSFPStreamHandleTable(root *st.SchemaTree, maxCurrentStreams uint,
 	// set tsn-stream-identification-type
 	updates = append(updates, setTsnStreamIdType(root, port, deviceIp, streamHandleValues.streamHandle, streamHandleValues.tsnStreamIdType))
 
-	// Set null-stream-identification-entry
-	// updates = append(updates, setNullStreamId(root, port, deviceIp, streamHandleValues.streamHandle, streamHandleValues.nullStreamId)...)
-
-	// set source-mac-identification-entry
-	updates = append(updates, setSourceMacId(root, port, deviceIp, streamHandleValues.streamHandle, streamHandleValues.sourceMacId)...)
-
-	// set active-destination-mac-identification-entry
-	// updates = append(updates, setActiveDestMacId(root, port, deviceIp, streamHandleValues.streamHandle, streamHandleValues.activeDestMacId)...)
+	// set the parameters of the identification function that is used
+	switch streamHandleValues.tsnStreamIdType {
+	case NullStreamIdType:
+		updates = append(updates, UpdateNullStreamId(root, port, deviceIp, streamHandleValues.streamHandle, streamHandleValues.nullStreamId)...)
+	case SourceMacStreamIdType:
+		updates = append(updates, setSourceMacId(root, port, deviceIp, streamHandleValues.streamHandle, streamHandleValues.sourceMacId)...)
+	case ActiveDestMacStreamIdType:
+		updates = append(updates, setActiveDestMacId(root, port, deviceIp, streamHandleValues.streamHandle, streamHandleValues.activeDestMacId)...)
+	case IpStreamIdType:
+		updates = append(updates, setIpStreamId(root, port, deviceIp, streamHandleValues.streamHandle, streamHandleValues.ipStreamId)...)
+	case MaskAndMatchStreamIdType:
+		updates = append(updates, setMaskAndMatchId(root, port, deviceIp, streamHandleValues.streamHandle, streamHandleValues.maskAndMatchId)...)
+	default:
+		return nil, errors.New("Invalid tsn-stream-identification-type. Value: " + fmt.Sprint(streamHandleValues.tsnStreamIdType))
+	}
 
 	return updates, nil
 }
@@ -9,7 +9,6 @@ import (
 
 	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
 
-	"fmt"
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	path "tsn-service/pkg/RAE/dataStructures/composit"
 
@@ -33,7 +32,7 @@ func setMaxConcurrentStream(root *st.SchemaTree, maxCurrentStreams uint, port st
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathTree, pathPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(maxCurrentStreams))
-	pathTree.Value = fmt.Sprint(maxCurrentStreams)
+	pathTree.SetUint64(uint64(maxCurrentStreams))
 	return update
 }
 
@@ -50,9 +49,25 @@ parameters to set:
 func setTsnStreamIdType(root *st.SchemaTree, port string, deviceIp string, streamHandle string, tsnStreamIdType int) (update *pb.Update) {
 	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
 	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
-	pathStreamHandleTree, pathStreamHandlePb := path.GetParam1Key(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", "stream-handle", streamHandle)
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
 	pathTree, pathPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "tsn-stream-identication-type")
-	pathTree.Value = fmt.Sprint(tsnStreamIdType)
+	pathTree.SetInt64(int64(tsnStreamIdType))
 	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbIntTypeVal(tsnStreamIdType))
 	return update
 }
+
+/*
+Set stream-handle, the key of the entry in the stream identification table
+key parameters:
+
+	port, deviceIp, streamHandle
+*/
+func setStreamHandleKey(root *st.SchemaTree, port string, deviceIp string, streamHandle string) (update *pb.Update) {
+	bridgePathTree, bridgePathPb := path.GetPath2Bridge(root, port)
+	pathStreamIdTree, pathStreamIdPb := path.GetParam0Keys(bridgePathTree, bridgePathPb, "stream-identification")
+	pathStreamHandleTree, pathStreamHandlePb := path.GetParamKeys(pathStreamIdTree, pathStreamIdPb, "", "stream-handles", map[string]string{"stream-handle": streamHandle})
+	pathTree, pathPb := path.GetParam0Keys(pathStreamHandleTree, pathStreamHandlePb, "stream-handle")
+	pathTree.SetString(streamHandle)
+	update = pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(streamHandle))
+	return update
+}
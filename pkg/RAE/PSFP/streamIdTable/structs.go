@@ -3,6 +3,19 @@ package streamIdTable
 /*
 Data structures to set the steam identification table
 */
+
+/*
+Values of tsn-stream-identification-type
+Ref: IEEE 802.1CB-2017 table 9-1, IEEE 802.1CBdb-2021 table 9-1
+*/
+const (
+	NullStreamIdType          = 1
+	SourceMacStreamIdType     = 2
+	ActiveDestMacStreamIdType = 3
+	IpStreamIdType            = 4
+	MaskAndMatchStreamIdType  = 5
+)
+
 type StreamHandles struct {
 	streamHandle            string
 	inFacingOutputPortList  []string
@@ -13,6 +26,8 @@ type StreamHandles struct {
 	nullStreamId            NullStreamIdEntry
 	sourceMacId             SourceMacIdEntry
 	activeDestMacId         ActiveDestMacIdEntry
+	ipStreamId              IpStreamIdEntry
+	maskAndMatchId          MaskAndMatchIdEntry
 }
 
 /*
@@ -42,3 +57,177 @@ type ActiveDestMacIdEntry struct {
 	lowerTagged   string
 	lowerVlan     uint
 }
+
+/*
+IP stream identification, Ref: IEEE 802.1CB-2017 6.7
+destMac, tagged and vlan as for null stream identification,
+the ip addresses are either IPv4 or IPv6 and the ports are only used if nextProtocol is UDP, TCP or SCTP
+*/
+type IpStreamIdEntry struct {
+	destMac         string
+	tagged          string
+	vlan            uint
+	ipSource        string
+	ipDestination   string
+	dscp            uint
+	nextProtocol    uint
+	sourcePort      uint
+	destinationPort uint
+}
+
+/*
+Mask-and-match stream identification, Ref: IEEE 802.1CBdb-2021 6.8
+A frame is identified if all its fields are equal to the values where the mask is set
+*/
+type MaskAndMatchIdEntry struct {
+	destMac       string
+	destMacMask   string
+	sourceMac     string
+	sourceMacMask string
+	vlan          uint
+	vlanMask      uint
+	priority      uint
+	priorityMask  uint
+}
+
+/*
+Stream handle entry that identifies the stream by its source mac address and vlan
+
+input:
+
+	streamHandle: the stream handle the identified frames get
+	outFacingInputPortList: ports where the frames are identified when they are received
+	sourceMac, tagged, vlan: the values to match
+*/
+func NewSourceMacStreamHandles(streamHandle string, outFacingInputPortList []string,
+	sourceMac string, tagged string, vlan uint) StreamHandles {
+
+	return StreamHandles{
+		streamHandle:           streamHandle,
+		outFacingInputPortList: outFacingInputPortList,
+		tsnStreamIdType:        SourceMacStreamIdType,
+		sourceMacId: SourceMacIdEntry{
+			sourceMac: sourceMac,
+			tagged:    tagged,
+			vlan:      vlan,
+		},
+	}
+}
+
+/*
+Stream handle entry that identifies the stream by its destination mac address and vlan only
+
+input:
+
+	streamHandle: the stream handle the identified frames get
+	outFacingInputPortList: ports where the frames are identified when they are received
+	destMac, tagged, vlan: the values to match
+*/
+func NewNullStreamHandles(streamHandle string, outFacingInputPortList []string,
+	destMac string, tagged string, vlan uint) StreamHandles {
+
+	return StreamHandles{
+		streamHandle:           streamHandle,
+		outFacingInputPortList: outFacingInputPortList,
+		tsnStreamIdType:        NullStreamIdType,
+		nullStreamId: NullStreamIdEntry{
+			destMac: destMac,
+			tagged:  tagged,
+			vlan:    vlan,
+		},
+	}
+}
+
+/*
+Stream handle entry that identifies the stream by the destination mac address and vlan at the upper layer,
+and translates them to the values the listener expects at the lower layer
+
+input:
+
+	streamHandle: the stream handle the identified frames get
+	outFacingInputPortList: ports where the frames are identified when they are received
+	upperDestMac, upperTagged, upperVlan, upperPriority: the values to match
+	lowerDestMac, lowerTagged, lowerVlan: the values of the frames at the listener
+*/
+func NewActiveDestMacStreamHandles(streamHandle string, outFacingInputPortList []string,
+	upperDestMac string, upperTagged string, upperVlan uint, upperPriority uint,
+	lowerDestMac string, lowerTagged string, lowerVlan uint) StreamHandles {
+
+	return StreamHandles{
+		streamHandle:           streamHandle,
+		outFacingInputPortList: outFacingInputPortList,
+		tsnStreamIdType:        ActiveDestMacStreamIdType,
+		activeDestMacId: ActiveDestMacIdEntry{
+			upperDestMac:  upperDestMac,
+			upperTagged:   upperTagged,
+			upperVlan:     upperVlan,
+			upperPriority: upperPriority,
+			lowerDestMac:  lowerDestMac,
+			lowerTagged:   lowerTagged,
+			lowerVlan:     lowerVlan,
+		},
+	}
+}
+
+/*
+Stream handle entry that identifies the stream by its ip header, and the destination mac address and vlan
+
+input:
+
+	streamHandle: the stream handle the identified frames get
+	outFacingInputPortList: ports where the frames are identified when they are received
+	destMac, tagged, vlan: the values to match in the ethernet header
+	ipSource, ipDestination, dscp, nextProtocol, sourcePort, destinationPort: the values to match in the ip header
+*/
+func NewIpStreamHandles(streamHandle string, outFacingInputPortList []string,
+	destMac string, tagged string, vlan uint,
+	ipSource string, ipDestination string, dscp uint, nextProtocol uint, sourcePort uint, destinationPort uint) StreamHandles {
+
+	return StreamHandles{
+		streamHandle:           streamHandle,
+		outFacingInputPortList: outFacingInputPortList,
+		tsnStreamIdType:        IpStreamIdType,
+		ipStreamId: IpStreamIdEntry{
+			destMac:         destMac,
+			tagged:          tagged,
+			vlan:            vlan,
+			ipSource:        ipSource,
+			ipDestination:   ipDestination,
+			dscp:            dscp,
+			nextProtocol:    nextProtocol,
+			sourcePort:      sourcePort,
+			destinationPort: destinationPort,
+		},
+	}
+}
+
+/*
+Stream handle entry that identifies the stream by masked fields of the frame
+
+input:
+
+	streamHandle: the stream handle the identified frames get
+	outFacingInputPortList: ports where the frames are identified when they are received
+	destMac, sourceMac, vlan, priority: the values to match
+	destMacMask, sourceMacMask, vlanMask, priorityMask: which bits of the values that are compared
+*/
+func NewMaskAndMatchStreamHandles(streamHandle string, outFacingInputPortList []string,
+	destMac string, destMacMask string, sourceMac string, sourceMacMask string,
+	vlan uint, vlanMask uint, priority uint, priorityMask uint) StreamHandles {
+
+	return StreamHandles{
+		streamHandle:           streamHandle,
+		outFacingInputPortList: outFacingInputPortList,
+		tsnStreamIdType:        MaskAndMatchStreamIdType,
+		maskAndMatchId: MaskAndMatchIdEntry{
+			destMac:       destMac,
+			destMacMask:   destMacMask,
+			sourceMac:     sourceMac,
+			sourceMacMask: sourceMacMask,
+			vlan:          vlan,
+			vlanMask:      vlanMask,
+			priority:      priority,
+			priorityMask:  priorityMask,
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package psfp
+
+/*
+Help functions for psfp.go
+*/
+
+import (
+	"errors"
+	streamgateinst "tsn-service/pkg/RAE/PSFP/StreamGateInst"
+	flowmeterinst "tsn-service/pkg/RAE/PSFP/flowMeterInst"
+	streamfilterinst "tsn-service/pkg/RAE/PSFP/streamFilterInst"
+	"tsn-service/pkg/RAE/PSFP/streamIdTable"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/configuration"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Hands out the lowest identifiers that are not used yet
+type idAllocator struct {
+	used map[uint]bool
+	last uint
+}
+
+// The allocators for each PSFP table of one device
+type deviceAllocator struct {
+	streamHandles idAllocator
+	filterIds     idAllocator
+	gateIds       idAllocator
+	flowMeterIds  idAllocator
+}
+
+func newIdAllocator(used []uint) idAllocator {
+	allocator := idAllocator{used: map[uint]bool{}}
+	for _, id := range used {
+		allocator.used[id] = true
+	}
+	return allocator
+}
+
+// Get the next free identifier and mark it as used, identifiers start at 1
+func (allocator *idAllocator) next() uint {
+	id := allocator.last + 1
+	for allocator.used[id] {
+		id++
+	}
+	allocator.used[id] = true
+	allocator.last = id
+	return id
+}
+
+// Collect the identifiers that are already used in the configuration of the device
+func newDeviceAllocator(root *st.SchemaTree) *deviceAllocator {
+	return &deviceAllocator{
+		streamHandles: newIdAllocator(streamIdTable.GetStreamHandles(root)),
+		filterIds:     newIdAllocator(streamfilterinst.GetStreamFilterInstanceIds(root)),
+		gateIds:       newIdAllocator(streamgateinst.GetStreamGateInstanceIds(root)),
+		flowMeterIds:  newIdAllocator(flowmeterinst.GetFlowMeterInstanceIds(root)),
+	}
+}
+
+/*
+Get the vlan tag the talker sends the stream with, the priority code point is the priority the stream filter applies to
+input:
+
+	talker: the talker of the stream request
+*/
+func GetStreamVlanTag(talker *configuration.TalkerGroup) (vlanTag *configuration.IeeeVlanTag, err error) {
+	for _, frameSpec := range talker.DataFrameSpecification {
+		if frameSpec.VlanTag != nil {
+			vlanTag = frameSpec.VlanTag
+		}
+	}
+
+	if vlanTag == nil {
+		return nil, errors.New("the data frame specification of the talker must have a vlan tag")
+	}
+	return vlanTag, nil
+}
+
+// Sets the stream gate instance of a stream at one port
+type gateSetter func(root *st.SchemaTree, entry StreamPSFP, trafficSpec *configuration.TrafficSpecification) ([]*pb.Update, error)
+
+// A gate that is always open, with the interval of the stream as cycle time
+func setDefaultGate(root *st.SchemaTree, entry StreamPSFP, trafficSpec *configuration.TrafficSpecification) ([]*pb.Update, error) {
+	return streamgateinst.SetDefaultStreamGateInstanceTable(root, entry.Port, entry.DeviceIp, entry.GateId,
+		uint(trafficSpec.Interval.Numerator), uint(trafficSpec.Interval.Denominator))
+}
+
+// A gate that alternates between the two CQF queues every cycle
+func getCqfGateSetter(cycleTime uint, queues [2]uint) gateSetter {
+	return func(root *st.SchemaTree, entry StreamPSFP, trafficSpec *configuration.TrafficSpecification) ([]*pb.Update, error) {
+		return streamgateinst.SetCqfStreamGateInstanceTable(root, entry.Port, entry.DeviceIp, entry.GateId, cycleTime, queues)
+	}
+}
@@ -1,7 +1,6 @@
 package streamreservation
 
 import (
-	"fmt"
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	path "tsn-service/pkg/RAE/dataStructures/composit"
 	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
@@ -51,7 +50,7 @@ func setExternalControlValue(root *st.SchemaTree, mrpExternalControl bool, devic
 	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(root, nil, "ieee8021TsnRemoteMgmtMsrpMrpExternalControlTable")
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "ieee8021TsnRemoteMgmtMsrpMrpExternalControlEntry")
 	pathTree, pathPb := path.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "ieee8021TsnRemoteMgmtMsrpMrpExternalControl")
-	pathTree.Value = fmt.Sprint(mrpExternalControl)
+	pathTree.SetBool(mrpExternalControl)
 	update := pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbBoolTypeVal(mrpExternalControl))
 
 	return pathTree, update
@@ -77,7 +76,7 @@ func setAdminRequestListValue(root *st.SchemaTree, adminRequestList string, devi
 	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(root, nil, "ieee8021TsnRemoteMgmtMsrpMrpExternalControlTable")
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "ieee8021TsnRemoteMgmtMsrpMrpExternalControlEntry")
 	pathTree, pathPb := path.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "ieee8021TsnRemoteMgmtMrpAdminRequestList")
-	pathTree.Value = adminRequestList
+	pathTree.SetString(adminRequestList)
 	update := pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbStringTypeVal(adminRequestList))
 
 	return pathTree, update
@@ -93,7 +92,7 @@ func setAdminRequestListLengthValue(root *st.SchemaTree, adminRequestListLength
 	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(root, nil, "ieee8021TsnRemoteMgmtMsrpMrpExternalControlTable")
 	pathLvl2Tree, pathLvl2Pb := path.GetParam0Keys(pathLvl1Tree, pathLvl1Pb, "ieee8021TsnRemoteMgmtMsrpMrpExternalControlEntry")
 	pathTree, pathPb := path.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "ieee8021TsnRemoteMgmtMrpAdminRequestListLength")
-	pathTree.Value = fmt.Sprint(adminRequestListLength)
+	pathTree.SetUint64(uint64(adminRequestListLength))
 	update := pbMethods.GetUpdate(deviceIp, pathPb, pbMethods.GetPbUintTypeVal(uint(adminRequestListLength)))
 
 	return pathTree, update
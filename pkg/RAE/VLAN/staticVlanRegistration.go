@@ -45,6 +45,33 @@ func SetStaticVlanRegistrationEntry(root *st.SchemaTree, vlanTransmitted string,
 	return []*st.SchemaTree{vlanTransmittedUpdateTree, adminCtrlPathUpdateTree}, []*pb.Update{vlanTransmittedUpdatePb, adminCtrlPathUpdatePb}, nil
 }
 
+// Remove a port from the VLAN, by deleting its Static VLAN Registration Entry
+//
+//	Input values (keys):
+//		port: the port on the bridge
+//		componentName: name of the component in the bridge
+//		bridgeName: name of the bridge
+//		databaseId: the ID of the Filtering Database for the Static VLAN Registration Entry
+//		vids: the VLAN Identifiers for which the Filtering Database apply
+//
+//	Input values (other)
+//		deviceIp: IP-addredss to the switch where the update is made
+//		root: a reference to the root of the SchemaTree where the entry is removed
+func DeleteStaticVlanRegistrationEntry(root *st.SchemaTree, vids string, databaseId uint32, componentName string, bridgeName string, port string, deviceIp string) *pb.Path {
+
+	treeElemLvl1, pbElemLvl1 := path.GetParam0Keys(root, nil, "ieee802-dot1q-bridge:ieee802-dot1q-bridge")
+	treeElemLvl2, pbElemLvl2 := path.GetParamKeys(treeElemLvl1, pbElemLvl1, "bridges", "bridge", map[string]string{"name": bridgeName})
+	treeElemLvl3, pbElemLvl3 := path.GetParamKeys(treeElemLvl2, pbElemLvl2, "", "component", map[string]string{"name": componentName})
+
+	treeElemLvl4, pbElemLvl4 := path.GetParam0Keys(treeElemLvl3, pbElemLvl3, "filtering-database")
+	treeElemLvl5, pbElemLvl5 := path.GetParamKeys(treeElemLvl4, pbElemLvl4, "", "vlan-registration-entry", map[string]string{"database-id": fmt.Sprint(databaseId), "vids": vids})
+
+	treePortMap, pbPortMap := path.GetParamKeys(treeElemLvl5, pbElemLvl5, "", "port-map", map[string]string{"port-ref": port})
+	st.RemoveFromParent(treePortMap)
+
+	return pbMethods.GetDelete(deviceIp, pbPortMap)
+}
+
 /* --------------------------------------------------------------------------- */
 /* ----------------------- Check if the value is valid ----------------------- */
 /* --------------------------------------------------------------------------- */
@@ -75,18 +102,18 @@ func invalidRegistrarAdminContolValue(registrarAdminContol string) error {
 // Unsure about the datatypes of some of the fields (vids)
 func setStaticVlanRegVlanTransmitted(root *st.SchemaTree, vlanTransmitted string, vids string, databaseId uint32, componentName string, bridgeName string, port string, deviceIp string) (*st.SchemaTree, *pb.Update) {
 
-	treeElemLvl1, pbElemLvl1 := path.GetParamNamespace(root, nil, "ieee802-dot1q-bridge", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
-	treeElemLvl2, pbElemLvl2 := path.GetParam1Key(treeElemLvl1, pbElemLvl1, "bridges", "bridge", "name", bridgeName)
-	treeElemLvl3, pbElemLvl3 := path.GetParam1Key(treeElemLvl2, pbElemLvl2, "", "component", "name", componentName)
+	treeElemLvl1, pbElemLvl1 := path.GetParam0Keys(root, nil, "ieee802-dot1q-bridge:ieee802-dot1q-bridge")
+	treeElemLvl2, pbElemLvl2 := path.GetParamKeys(treeElemLvl1, pbElemLvl1, "bridges", "bridge", map[string]string{"name": bridgeName})
+	treeElemLvl3, pbElemLvl3 := path.GetParamKeys(treeElemLvl2, pbElemLvl2, "", "component", map[string]string{"name": componentName})
 
 	treeElemLvl4, pbElemLvl4 := path.GetParam0Keys(treeElemLvl3, pbElemLvl3, "filtering-database")
-	treeElemLvl5, pbElemLvl5 := path.GetParam2Keys(treeElemLvl4, pbElemLvl4, "", "vlan-registration-entry", "database-id", fmt.Sprint(databaseId), "vids", vids) //TODO: Error in the "vids" variable, i think this should be a list
+	treeElemLvl5, pbElemLvl5 := path.GetParamKeys(treeElemLvl4, pbElemLvl4, "", "vlan-registration-entry", map[string]string{"database-id": fmt.Sprint(databaseId), "vids": vids}) //TODO: Error in the "vids" variable, i think this should be a list
 
-	treeElemLvl6, pbElemLvl6 := path.GetParam1Key(treeElemLvl5, pbElemLvl5, "", "port-map", "port-ref", port)
+	treeElemLvl6, pbElemLvl6 := path.GetParamKeys(treeElemLvl5, pbElemLvl5, "", "port-map", map[string]string{"port-ref": port})
 	treeElemLvl7, pbElemLvl7 := path.GetParam0Keys(treeElemLvl6, pbElemLvl6, "static-vlan-registration-entries")
 
 	treeVlanTransmitPath, pbVlanTransmitPath := path.GetParam0Keys(treeElemLvl7, pbElemLvl7, "vlan-transmitted")
-	treeVlanTransmitPath.Value = vlanTransmitted
+	treeVlanTransmitPath.SetEnum(vlanTransmitted)
 	vlanTransmittedUpdate := pbMethods.GetUpdate(deviceIp, pbVlanTransmitPath, pbMethods.GetPbStringTypeVal(vlanTransmitted))
 
 	return treeVlanTransmitPath, vlanTransmittedUpdate
@@ -98,18 +125,18 @@ func setStaticVlanRegVlanTransmitted(root *st.SchemaTree, vlanTransmitted string
 // Unsure about the datatypes of some of the fields (vids)
 func setStaticVlanRegVlanRegAdminCtrlPath(root *st.SchemaTree, registrarAdminContol string, vids string, database_id uint32, componentName string, bridgeName string, port string, deviceIp string) (*st.SchemaTree, *pb.Update) {
 
-	treeElemLvl1, pbElemLvl1 := path.GetParamNamespace(root, nil, "ieee802-dot1q-bridge", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
-	treeElemLvl2, pbElemLvl2 := path.GetParam1Key(treeElemLvl1, pbElemLvl1, "bridges", "bridge", "name", bridgeName)
-	treeElemLvl3, pbElemLvl3 := path.GetParam1Key(treeElemLvl2, pbElemLvl2, "", "component", "name", componentName)
+	treeElemLvl1, pbElemLvl1 := path.GetParam0Keys(root, nil, "ieee802-dot1q-bridge:ieee802-dot1q-bridge")
+	treeElemLvl2, pbElemLvl2 := path.GetParamKeys(treeElemLvl1, pbElemLvl1, "bridges", "bridge", map[string]string{"name": bridgeName})
+	treeElemLvl3, pbElemLvl3 := path.GetParamKeys(treeElemLvl2, pbElemLvl2, "", "component", map[string]string{"name": componentName})
 
 	treeElemLvl4, pbElemLvl4 := path.GetParam0Keys(treeElemLvl3, pbElemLvl3, "filtering-database")
-	treeElemLvl5, pbElemLvl5 := path.GetParam2Keys(treeElemLvl4, pbElemLvl4, "", "vlan-registration-entry", "database-id", fmt.Sprint(database_id), "vids", vids) //TODO: Error in the "vids" variable, i think this should be a list
+	treeElemLvl5, pbElemLvl5 := path.GetParamKeys(treeElemLvl4, pbElemLvl4, "", "vlan-registration-entry", map[string]string{"database-id": fmt.Sprint(database_id), "vids": vids}) //TODO: Error in the "vids" variable, i think this should be a list
 
-	treeElemLvl6, pbElemLvl6 := path.GetParam1Key(treeElemLvl5, pbElemLvl5, "", "port-map", "port-ref", port)
+	treeElemLvl6, pbElemLvl6 := path.GetParamKeys(treeElemLvl5, pbElemLvl5, "", "port-map", map[string]string{"port-ref": port})
 	treeElemLvl7, pbElemLvl7 := path.GetParam0Keys(treeElemLvl6, pbElemLvl6, "static-vlan-registration-entries")
 
 	treeRegAdminCtrlPath, pbRegAdminCtrlPath := path.GetParam0Keys(treeElemLvl7, pbElemLvl7, "registrar-admin-control")
-	treeRegAdminCtrlPath.Value = registrarAdminContol
+	treeRegAdminCtrlPath.SetEnum(registrarAdminContol)
 	registrarAdminControlUpdate := pbMethods.GetUpdate(deviceIp, pbRegAdminCtrlPath, pbMethods.GetPbStringTypeVal(registrarAdminContol))
 
 	return treeRegAdminCtrlPath, registrarAdminControlUpdate
@@ -90,7 +90,7 @@ func validFrameType(acceptableFrame string) error {
 func setPvidUpdate(root *st.SchemaTree, pvid uint32, port string, deviceIp string) (*st.SchemaTree, *pb.Update) {
 	treeLvl1, pbLvl1 := path.GetPath2Bridge(root, fmt.Sprint(port))
 	treeLvl2, pbLvl2 := path.GetParam0Keys(treeLvl1, pbLvl1, "pvid")
-	treeLvl2.Value = fmt.Sprint(pvid)
+	treeLvl2.SetUint64(uint64(pvid))
 	update := pbMethods.GetUpdate(deviceIp, pbLvl2, pbMethods.GetPbUintTypeVal(uint(pvid)))
 	return treeLvl2, update
 }
@@ -101,7 +101,7 @@ func setPvidUpdate(root *st.SchemaTree, pvid uint32, port string, deviceIp strin
 func setAcceptableFrameTypes(root *st.SchemaTree, acceptableFrame string, port string, deviceIp string) (*st.SchemaTree, *pb.Update) {
 	treeLvl1, pbLvl1 := path.GetPath2Bridge(root, fmt.Sprint(port))
 	treeLvl2, pbLvl2 := path.GetParam0Keys(treeLvl1, pbLvl1, "acceptable-frame")
-	treeLvl2.Value = acceptableFrame
+	treeLvl2.SetEnum(acceptableFrame)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl2, pbMethods.GetPbStringTypeVal(acceptableFrame))
 	return treeLvl2, update
 }
@@ -112,7 +112,7 @@ func setAcceptableFrameTypes(root *st.SchemaTree, acceptableFrame string, port s
 func setEnableIngressFiltering(root *st.SchemaTree, enableIngressFiltering bool, port string, deviceIp string) (*st.SchemaTree, *pb.Update) {
 	treeLvl1, pbLvl1 := path.GetPath2Bridge(root, fmt.Sprint(port))
 	treeLvl2, pbLvl2 := path.GetParam0Keys(treeLvl1, pbLvl1, "enable-ingress-filtering")
-	treeLvl2.Value = fmt.Sprint(enableIngressFiltering)
+	treeLvl2.SetBool(enableIngressFiltering)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl2, pbMethods.GetPbBoolTypeVal(enableIngressFiltering))
 	return treeLvl2, update
 }
@@ -123,7 +123,7 @@ func setEnableIngressFiltering(root *st.SchemaTree, enableIngressFiltering bool,
 func setEnableRestrictedVlanRegistration(root *st.SchemaTree, enableRestrictedVlanRegistration bool, port string, deviceIp string) (*st.SchemaTree, *pb.Update) {
 	treeLvl1, pbLvl1 := path.GetPath2Bridge(root, fmt.Sprint(port))
 	treeLvl2, pbLvl2 := path.GetParam0Keys(treeLvl1, pbLvl1, "enable-restricted-vlan-registration")
-	treeLvl2.Value = fmt.Sprint(enableRestrictedVlanRegistration)
+	treeLvl2.SetBool(enableRestrictedVlanRegistration)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl2, pbMethods.GetPbBoolTypeVal(enableRestrictedVlanRegistration))
 	return treeLvl2, update
 }
@@ -54,14 +54,31 @@ func inValidVlanName(vlanName string) error {
 // Value to set: vlanName (max 32 characters)
 // Key values: vid, bridgeName, deviceIp
 func setVlanConfigurationUpdate(root *st.SchemaTree, vlanName string, vid uint32, componentName string, bridgeName string, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee802-dot1q-bridge", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
-	treeLvl2, pbLvl2 := path.GetParam1Key(treeLvl1, pbLvl1, "bridges", "bridge", "name", bridgeName)
-	treeLvl3, pbLvl3 := path.GetParam1Key(treeLvl2, pbLvl2, "", "component", "name", componentName)
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee802-dot1q-bridge:ieee802-dot1q-bridge")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "bridges", "bridge", map[string]string{"name": bridgeName})
+	treeLvl3, pbLvl3 := path.GetParamKeys(treeLvl2, pbLvl2, "", "component", map[string]string{"name": componentName})
 	treeLvl4, pbLvl4 := path.GetParam0Keys(treeLvl3, pbLvl3, "bridge-vlan")
-	treeLvl5, pbLvl5 := path.GetParam1Key(treeLvl4, pbLvl4, "", "vlan", "vid", fmt.Sprint(vid))
+	treeLvl5, pbLvl5 := path.GetParamKeys(treeLvl4, pbLvl4, "", "vlan", map[string]string{"vid": fmt.Sprint(vid)})
 	treePath, pbPath := path.GetParam0Keys(treeLvl5, pbLvl5, "name")
-	treePath.Value = vlanName
+	treePath.SetString(vlanName)
 	update := pbMethods.GetUpdate(deviceIp, pbPath, pbMethods.GetPbStringTypeVal(vlanName))
 
 	return treePath, update
 }
+
+// Get the name of the bridge of a device and of its first component, where the VLANs of the device are configured
+//
+//	Input values (other)
+//		root: a reference to the root of the SchemaTree of the device
+func GetBridgeComponent(root *st.SchemaTree) (bridgeName string, componentName string, err error) {
+	bridges := st.OneLvlDown0Keys(st.OneLvlDown0Keys(root, "ieee802-dot1q-bridge:ieee802-dot1q-bridge"), "bridges")
+	bridge := st.OneLvlDown0Keys(bridges, "bridge")
+	component := st.OneLvlDown0Keys(bridge, "component")
+
+	bridgeName = st.OneLvlDown0Keys(bridge, "name").Value
+	componentName = st.OneLvlDown0Keys(component, "name").Value
+	if bridgeName == "" || componentName == "" {
+		return "", "", errors.New("the configuration of the device has no bridge with a component")
+	}
+	return bridgeName, componentName, nil
+}
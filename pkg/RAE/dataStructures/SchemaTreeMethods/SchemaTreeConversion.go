@@ -0,0 +1,101 @@
+package SchemaTreeMethods
+
+/*
+Convert schema trees to and from the adapterResponse the adapter stores the configuration of a device as
+Every element is a "start" entry, with the value of a leaf, followed by the entries of its children and an "end" entry
+
+The start entry has the kind of the element, so empty containers, leaves with an empty value (or of the type empty),
+list entries with their key leaves and the values of a leaf-list are told apart. An element without a kind (from an adapter that does not send it)
+is a leaf if it has no children (see IsLeaf)
+*/
+
+import (
+	adapterResp "tsn-service/pkg/structures/adapterResponse"
+)
+
+// Convert a schema tree to an adapterResponse, the element itself is the first entry
+func ToAdapterResponse(tree *SchemaTree) *adapterResp.AdapterResponse {
+	adapterResponse := &adapterResp.AdapterResponse{}
+	appendEntries(tree, adapterResponse)
+	return adapterResponse
+}
+
+// Append the entries of an element and everything below it
+func appendEntries(tree *SchemaTree, adapterResponse *adapterResp.AdapterResponse) {
+	start := &adapterResp.SchemaEntry{
+		Name:          tree.Name,
+		Tag:           "start",
+		Namespace:     tree.Namespace,
+		Value:         tree.Value,
+		Kind:          entryKinds[tree.Kind],
+		OrderedByUser: tree.OrderedByUser,
+	}
+
+	adapterResponse.Entries = append(adapterResponse.Entries, start)
+
+	for _, child := range tree.Children {
+		appendEntries(child, adapterResponse)
+	}
+
+	end := &adapterResp.SchemaEntry{
+		Name: tree.Name,
+		Tag:  "end",
+	}
+
+	adapterResponse.Entries = append(adapterResponse.Entries, end)
+}
+
+// Build a schema tree from the entries of an adapterResponse, the tree is the first element (e.g. the "data" element), without a parent
+// An end entry closes the element that was started last, the first element is not closed so the rest of its entries are its children
+func FromAdapterResponse(adapterResponse *adapterResp.AdapterResponse) *SchemaTree {
+	root := &SchemaTree{}
+	tree := root
+	for _, entry := range adapterResponse.GetEntries() {
+		if entry.Tag == "end" {
+			if tree.Parent != nil && tree.Parent != root {
+				tree = tree.Parent
+			}
+			continue
+		}
+
+		newTree := &SchemaTree{
+			Name:          entry.Name,
+			Namespace:     entry.Namespace,
+			Parent:        tree,
+			Value:         entry.Value,
+			Kind:          getElemKind(entry),
+			OrderedByUser: entry.OrderedByUser,
+		}
+		tree.Children = append(tree.Children, newTree)
+		tree = newTree
+	}
+
+	if len(root.Children) == 0 {
+		return root
+	}
+
+	// The first element is the root of the tree, the element it was built under is not part of it
+	tree = root.Children[0]
+	tree.Parent = nil
+	return tree
+}
+
+// The kinds of the entries by the kinds of the elements
+var entryKinds = map[ElemKind]adapterResp.EntryKind{
+	KindUnknown:       adapterResp.EntryKind_UNKNOWN,
+	KindContainer:     adapterResp.EntryKind_CONTAINER,
+	KindListEntry:     adapterResp.EntryKind_LIST_ENTRY,
+	KindLeaf:          adapterResp.EntryKind_LEAF,
+	KindLeafListEntry: adapterResp.EntryKind_LEAF_LIST_ENTRY,
+	KindListKey:       adapterResp.EntryKind_LIST_KEY,
+}
+
+// Get the kind of the element of an entry, KindUnknown for an entry without a kind so it is converted back the same
+func getElemKind(entry *adapterResp.SchemaEntry) ElemKind {
+	for kind, entryKind := range entryKinds {
+		if entryKind == entry.Kind {
+			return kind
+		}
+	}
+	return KindUnknown
+}
@@ -4,166 +4,229 @@ package SchemaTreeMethods
 Help functions to generate schema trees, used to update the configuration at the k/v-store
 */
 
-import "errors"
+import (
+	"errors"
+	"sort"
+	"strings"
+	"tsn-service/pkg/RAE/dataStructures/namespaces"
+)
 
 // The data structure of the schema trees
 type SchemaTree struct {
-	Name      string
-	Namespace string
-	Children  []*SchemaTree
-	Parent    *SchemaTree
-	Value     string
+	Name          string
+	Namespace     string
+	Children      []*SchemaTree
+	Parent        *SchemaTree
+	Value         string
+	Kind          ElemKind
+	OrderedByUser bool // the entries of the list or leaf-list keep their order (ordered-by user)
 }
 
-// Go down 1 level down the schema tree only after the name
+// What an element is, the entries of a list and the values of a leaf-list are siblings with the same name
+type ElemKind int
+
+const (
+	KindUnknown       ElemKind = iota // an element without children is taken as a leaf
+	KindContainer                     // a container, also when it is empty
+	KindListEntry                     // an entry of a list
+	KindLeaf                          // a leaf, also when its value is empty
+	KindLeafListEntry                 // one value of a leaf-list
+	KindListKey                       // a key leaf of a list entry
+)
+
+// Check if the element is a leaf or a value of a leaf-list
+func (elem *SchemaTree) IsLeaf() bool {
+	switch elem.Kind {
+	case KindLeaf, KindLeafListEntry, KindListKey:
+		return true
+	case KindContainer, KindListEntry:
+		return false
+	}
+	return len(elem.Children) == 0
+}
+
+// Go down 1 level down the schema tree only after the name, or "module:name" to also go after the namespace of the module
 func OneLvlDown0Keys(root *SchemaTree, name string) *SchemaTree {
+	name, namespace := namespaces.SplitQualifiedName(name)
 	for _, param := range root.Children {
-		if param.Name == name {
+		if hasName(root, param, name, namespace) {
 			return param
 		}
 	}
-	return &SchemaTree{"", "", nil, nil, ""}
+	return &SchemaTree{}
 }
 
-// Return all children with specific name from the schema tree
+// Return all children with specific name, or "module:name", from the schema tree
 func OneLvlDownAllInstances(root *SchemaTree, name string) (instances []*SchemaTree) {
+	name, namespace := namespaces.SplitQualifiedName(name)
 	for _, param := range root.Children {
-		if param.Name == name {
+		if hasName(root, param, name, namespace) {
 			instances = append(instances, param)
 		}
 	}
 	return instances
 }
 
-// Go down 1 level down the schema tree only the name and one set of keys
-func OneLvlDown1Key(root *SchemaTree, name string, key string, val string) *SchemaTree {
+// Go down 1 level down the schema tree after the name and namespace
+// An element without a namespace of its own is in the namespace of its parent
+func OneLvlDownNamespace(root *SchemaTree, name string, namespace string) *SchemaTree {
 	for _, entry := range root.Children {
-		if entry.Name == name {
-			for _, param := range entry.Children {
-				if param.Name == key && param.Value == val {
-					return entry
-				} else if param.Name == key && param.Value != val {
-					break
-				}
-			}
+		if hasName(root, entry, name, namespace) {
+			return entry
 		}
 	}
-	return &SchemaTree{"", "", nil, nil, ""}
+	return &SchemaTree{}
 }
 
-// Go down 1 level down the schema tree after the name and namespace
-func OneLvlDownNamespace(root *SchemaTree, name string, namespace string) *SchemaTree {
+// Go down 1 level down the schema tree after the name (or "module:name") and any number of keys, every key must have its value in the entry
+func OneLvlDownKeys(root *SchemaTree, name string, keys map[string]string) *SchemaTree {
+	name, namespace := namespaces.SplitQualifiedName(name)
 	for _, entry := range root.Children {
-		if entry.Name == name && entry.Namespace == namespace {
+		if hasName(root, entry, name, namespace) && hasKeys(entry, keys) {
 			return entry
 		}
 	}
-	return &SchemaTree{"", "", nil, nil, ""}
+	return &SchemaTree{}
 }
 
-// Go down 1 level down the schema tree after the name and 2 set of keys
-func OneLvlDown2Keys(root *SchemaTree, name string, key1 string, val1 string, key2 string, val2 string) *SchemaTree {
+// Check if a child of root has the name, and is in the namespace unless it is empty
+func hasName(root *SchemaTree, child *SchemaTree, name string, namespace string) bool {
+	if child.Name != name {
+		return false
+	}
+	if namespace == "" {
+		return true
+	}
+	if child.Namespace != "" {
+		return child.Namespace == namespace
+	}
+	return GetClosestNamespace(root) == namespace
+}
 
-	for _, entry := range root.Children {
-		if entry.Name == name {
-			keysFound := 0
-			for _, param := range entry.Children {
-				if param.Name == key1 && param.Value == val1 {
-					keysFound += 1
-				} else if param.Name == key1 && param.Value != val1 {
-					break
-				}
+// Check if the entry has all keys with their values
+func hasKeys(entry *SchemaTree, keys map[string]string) bool {
+	keysFound := 0
+	for _, param := range entry.Children {
+		val, ok := keys[param.Name]
+		if !ok {
+			continue
+		}
+		if param.Value != val {
+			return false
+		}
+		keysFound += 1
+	}
+	return keysFound == len(keys)
+}
 
-				if param.Name == key2 && param.Value == val2 {
-					keysFound += 1
-				} else if param.Name == key2 && param.Value != val2 {
-					break
-				}
-			}
-			if keysFound == 2 {
-				return entry
-			}
+// Go down 1 level down the schema tree only after the name (or "module:name"), the element is created if it is not in the tree
+func GetOrCreate0Keys(root *SchemaTree, name string) *SchemaTree {
+	name, namespace := namespaces.SplitQualifiedName(name)
+	for _, param := range root.Children {
+		if hasName(root, param, name, namespace) {
+			return param
 		}
 	}
-	return &SchemaTree{"", "", nil, nil, ""}
+	return insertChild(root, &SchemaTree{Name: name, Namespace: namespace})
 }
 
-// Go down 1 level down the schema tree after the name and 3 set of keys
-func OneLvlDown3Keys(root *SchemaTree, name string, key1 string, val1 string, key2 string, val2 string, key3 string, val3 string) *SchemaTree {
+// Go down 1 level down the schema tree after the name and namespace, the element is created if it is not in the tree
+func GetOrCreateNamespace(root *SchemaTree, name string, namespace string) *SchemaTree {
+	for _, entry := range root.Children {
+		if hasName(root, entry, name, namespace) {
+			return entry
+		}
+	}
+	return insertChild(root, &SchemaTree{Name: name, Namespace: namespace})
+}
 
+// Go down 1 level down the schema tree after the name (or "module:name") and any number of keys, the entry is created with its keys if it is not in the tree
+func GetOrCreateKeys(root *SchemaTree, name string, keys map[string]string) *SchemaTree {
+	name, namespace := namespaces.SplitQualifiedName(name)
 	for _, entry := range root.Children {
-		if entry.Name == name {
-			keysFound := 0
-			for _, param := range entry.Children {
+		if hasName(root, entry, name, namespace) && hasKeys(entry, keys) {
+			return entry
+		}
+	}
 
-				if param.Name == key1 && param.Value == val1 {
-					keysFound += 1
-				} else if param.Name == key1 && param.Value != val1 {
-					break
-				}
+	// The keys are added in order of name, so the same entry always looks the same
+	var keyNames []string
+	for key := range keys {
+		keyNames = append(keyNames, key)
+	}
+	sort.Strings(keyNames)
 
-				if param.Name == key2 && param.Value == val2 {
-					keysFound += 1
-				} else if param.Name == key2 && param.Value != val2 {
-					break
-				}
+	entry := insertChild(root, &SchemaTree{Name: name, Namespace: namespace, Kind: KindListEntry})
+	for _, key := range keyNames {
+		insertChild(entry, &SchemaTree{Name: key, Value: keys[key], Kind: KindListKey})
+	}
+	return entry
+}
 
-				if param.Name == key3 && param.Value == val3 {
-					keysFound += 1
-				} else if param.Name == key3 && param.Value != val3 {
-					break
-				}
-			}
-			if keysFound == 3 {
-				return entry
-			}
+// Get the key leaves of a list entry (KindListKey) with their values, empty if the element has none
+func GetEntryKeys(entry *SchemaTree) map[string]string {
+	keys := map[string]string{}
+	for _, child := range entry.Children {
+		if child.Kind == KindListKey {
+			keys[child.Name] = child.Value
 		}
 	}
-	return &SchemaTree{"", "", nil, nil, ""}
+	return keys
+}
+
+// Add a child after the last child with the same name, so entries of a list stay together, or last if there is none
+func insertChild(root *SchemaTree, child *SchemaTree) *SchemaTree {
+	child.Parent = root
+
+	index := len(root.Children)
+	for i, sibling := range root.Children {
+		if sibling.Name == child.Name {
+			index = i + 1
+		}
+	}
+
+	root.Children = append(root.Children, nil)
+	copy(root.Children[index+1:], root.Children[index:])
+	root.Children[index] = child
+	return child
 }
 
 // Traverse down the schema tree to the bridge-port
 func LvlsDownToBridgePort(root *SchemaTree, port string) *SchemaTree {
-	lvl1 := OneLvlDown0Keys(root, "interfaces") // HERE IT SHOULD BE A NAMESPACE
-	lvl2 := OneLvlDown1Key(lvl1, "interface", "name", port)
-	return OneLvlDown0Keys(lvl2, "bridge-port")
+	lvl1 := OneLvlDown0Keys(root, "ietf-interfaces:interfaces")
+	lvl2 := OneLvlDownKeys(lvl1, "interface", map[string]string{"name": port})
+	return OneLvlDown0Keys(lvl2, "ieee802-dot1q-bridge:bridge-port")
 }
 
 // Traverse down the schema tree to the bridge-ports
 func LvlsDownToBridgePorts(root *SchemaTree) (bridgePorts []*SchemaTree) {
-	lvl1 := OneLvlDown0Keys(root, "interfaces") // HERE IT SHOULD BE A NAMESPACE
+	lvl1 := OneLvlDown0Keys(root, "ietf-interfaces:interfaces")
 	ports := GetAllKeyValues(lvl1, "interface", "name")
 	for _, port := range ports {
-		lvl2 := OneLvlDown1Key(lvl1, "interface", "name", port)
-		bridgePorts = append(bridgePorts, OneLvlDown0Keys(lvl2, "bridge-port"))
+		lvl2 := OneLvlDownKeys(lvl1, "interface", map[string]string{"name": port})
+		bridgePorts = append(bridgePorts, OneLvlDown0Keys(lvl2, "ieee802-dot1q-bridge:bridge-port"))
 	}
 	return bridgePorts
 }
 
-func GetNamespaceRoot(elem *SchemaTree) *SchemaTree {
-	currentElement := elem
-	for {
+// Walk up from the element to the first element with a namespace, the element itself included
+func GetNamespaceRoot(elem *SchemaTree) (*SchemaTree, error) {
+	for currentElement := elem; currentElement != nil; currentElement = currentElement.Parent {
 		if currentElement.Namespace != "" {
-			return currentElement
-		}
-
-		if currentElement.Parent == nil {
-			return &SchemaTree{"", "", nil, nil, ""}
+			return currentElement, nil
 		}
 	}
+	return nil, errors.New("no element above has a namespace")
 }
 
-func GetNamespaceRootWithName(elem *SchemaTree, nameSpace string) *SchemaTree {
-	currentElement := elem
-	for {
+// Walk up from the element to the first element in a specific namespace, the element itself included
+func GetNamespaceRootWithName(elem *SchemaTree, nameSpace string) (*SchemaTree, error) {
+	for currentElement := elem; currentElement != nil; currentElement = currentElement.Parent {
 		if currentElement.Namespace == nameSpace {
-			return currentElement
-		}
-
-		if currentElement.Parent == nil {
-			return &SchemaTree{"", "", nil, nil, ""}
+			return currentElement, nil
 		}
 	}
+	return nil, errors.New("no element above is in the namespace: " + nameSpace)
 }
 
 func GetAllKeyValues(root *SchemaTree, name string, key string) (values []string) {
@@ -180,41 +243,61 @@ func GetAllKeyValues(root *SchemaTree, name string, key string) (values []string
 	return values
 }
 
-func GetKeyValueInParent(obj *SchemaTree, key string) (string, error) {
-	var currentObj = obj
-	for {
-		if currentObj.Name == key {
-			return currentObj.Value, nil
-		}
+/*
+Get the value of a key of the list entry the element is directly in
+The element can be the key leaf itself, or another element of the entry (a sibling of the key leaf).
+Only the direct parent is searched, the keys of entries further up are not found
 
-		if currentObj.Parent == nil {
-			break
+input:
+
+	obj: the element to start at
+	key: the name of the key leaf
+
+output:
+
+	value: the value of the key
+	err: if the entry of the element has no key leaf with the name
+*/
+func GetKeyValueInParent(obj *SchemaTree, key string) (string, error) {
+	if obj == nil {
+		return "", errors.New("did not find the key: " + key)
+	}
+	if obj.Name == key && obj.IsLeaf() {
+		return obj.Value, nil
+	}
+	if obj.Parent != nil {
+		for _, param := range obj.Parent.Children {
+			if param.Name == key && param.IsLeaf() {
+				return param.Value, nil
+			}
 		}
 	}
 	return "", errors.New("did not find the key: " + key)
-
 }
 
-func GetKeyValuesInParent(obj *SchemaTree, keys ...string) (output []string, err error) {
-	var currentObj = obj
-	var keyPos = 0
-	for {
-		if currentObj.Name == keys[keyPos] {
-			output = append(output, currentObj.Value)
-			keyPos += 1
-		}
+/*
+Get the values of several keys of the list entry the element is directly in, each key is looked up as in GetKeyValueInParent
 
-		if currentObj.Parent == nil {
-			break
+output:
+
+	output: the values, in the order of the keys
+	err: if one of the keys is not found, with all keys that are missing
+*/
+func GetKeyValuesInParent(obj *SchemaTree, keys ...string) (output []string, err error) {
+	var missing []string
+	for _, key := range keys {
+		value, err := GetKeyValueInParent(obj, key)
+		if err != nil {
+			missing = append(missing, key)
+			continue
 		}
+		output = append(output, value)
 	}
 
-	if keyPos+1 < len(keys) {
-		return nil, errors.New("did not find all the keys")
-
+	if len(missing) > 0 {
+		return nil, errors.New("did not find the keys: " + strings.Join(missing, ", "))
 	}
 	return output, nil
-
 }
 
 func HasParameter(root *SchemaTree, parameter string) bool {
@@ -226,3 +309,28 @@ func HasParameter(root *SchemaTree, parameter string) bool {
 	return false
 
 }
+
+// Copy an element and everything below it with all their fields, the copy is not in a tree (it has no parent)
+func Copy(elem *SchemaTree) *SchemaTree {
+	elemCopy := &SchemaTree{Name: elem.Name, Namespace: elem.Namespace, Value: elem.Value, Kind: elem.Kind, OrderedByUser: elem.OrderedByUser}
+	for _, child := range elem.Children {
+		childCopy := Copy(child)
+		childCopy.Parent = elemCopy
+		elemCopy.Children = append(elemCopy.Children, childCopy)
+	}
+	return elemCopy
+}
+
+// Remove an element and everything below it from the schema tree, elements that are not in a tree are left as they are
+func RemoveFromParent(elem *SchemaTree) {
+	if elem.Parent == nil {
+		return
+	}
+	for i, child := range elem.Parent.Children {
+		if child == elem {
+			elem.Parent.Children = append(elem.Parent.Children[:i], elem.Parent.Children[i+1:]...)
+			break
+		}
+	}
+	elem.Parent = nil
+}
@@ -0,0 +1,318 @@
+package SchemaTreeMethods
+
+import (
+	"testing"
+
+	adapterResp "tsn-service/pkg/structures/adapterResponse"
+)
+
+// An element of an adapterResponse, with the elements below it
+type testEntry struct {
+	name      string
+	namespace string
+	value     string
+	kind      adapterResp.EntryKind
+	children  []testEntry
+}
+
+func (e testEntry) appendTo(adapterResponse *adapterResp.AdapterResponse) {
+	adapterResponse.Entries = append(adapterResponse.Entries, &adapterResp.SchemaEntry{
+		Name: e.name, Tag: "start", Namespace: e.namespace, Value: e.value, Kind: e.kind})
+	for _, child := range e.children {
+		child.appendTo(adapterResponse)
+	}
+	adapterResponse.Entries = append(adapterResponse.Entries, &adapterResp.SchemaEntry{Name: e.name, Tag: "end"})
+}
+
+func leaf(name string, value string) testEntry {
+	return testEntry{name: name, value: value, kind: adapterResp.EntryKind_LEAF}
+}
+
+/*
+The configuration of a bridge as the adapter stores it:
+
+	data
+	  interfaces (ietf-interfaces)
+	    interface [name=sw0p1]
+	      name, type
+	      bridge-port (ieee802-dot1q-bridge)
+	        pvid
+	        traffic-class
+	          traffic-class-table
+	            number-of-traffic-classes
+*/
+func getTestTree(t *testing.T) *SchemaTree {
+	t.Helper()
+
+	data := testEntry{name: "data", kind: adapterResp.EntryKind_CONTAINER, children: []testEntry{
+		{name: "interfaces", namespace: "urn:ietf:params:xml:ns:yang:ietf-interfaces", kind: adapterResp.EntryKind_CONTAINER, children: []testEntry{
+			{name: "interface", kind: adapterResp.EntryKind_LIST_ENTRY, children: []testEntry{
+				leaf("name", "sw0p1"),
+				leaf("type", "ethernetCsmacd"),
+				{name: "bridge-port", namespace: "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge", kind: adapterResp.EntryKind_CONTAINER, children: []testEntry{
+					leaf("pvid", "1"),
+					{name: "traffic-class", kind: adapterResp.EntryKind_CONTAINER, children: []testEntry{
+						{name: "traffic-class-table", kind: adapterResp.EntryKind_CONTAINER, children: []testEntry{
+							leaf("number-of-traffic-classes", "8"),
+						}},
+					}},
+				}},
+			}},
+		}},
+	}}
+
+	adapterResponse := &adapterResp.AdapterResponse{}
+	data.appendTo(adapterResponse)
+	return FromAdapterResponse(adapterResponse)
+}
+
+// Follow the names down from the root
+func getTestElem(t *testing.T, root *SchemaTree, names ...string) *SchemaTree {
+	t.Helper()
+
+	elem := root
+	for _, name := range names {
+		elem = OneLvlDown0Keys(elem, name)
+		if elem.Name != name {
+			t.Fatalf("no element %q in the test tree (path %v)", name, names)
+		}
+	}
+	return elem
+}
+
+func TestGetNamespaceRoot(t *testing.T) {
+	root := getTestTree(t)
+
+	tests := []struct {
+		name    string
+		path    []string
+		want    string // the name of the namespace root
+		wantErr bool
+	}{
+		{name: "element with a namespace", path: []string{"interfaces"}, want: "interfaces"},
+		{name: "leaf in a list entry", path: []string{"interfaces", "interface", "name"}, want: "interfaces"},
+		{name: "nearest namespace", path: []string{"interfaces", "interface", "bridge-port", "traffic-class", "traffic-class-table"}, want: "bridge-port"},
+		{name: "no namespace above", path: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetNamespaceRoot(getTestElem(t, root, tt.path...))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetNamespaceRoot() = %q, want an error", got.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetNamespaceRoot() error: %v", err)
+			}
+			if got.Name != tt.want {
+				t.Errorf("GetNamespaceRoot() = %q, want %q", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNamespaceRootWithName(t *testing.T) {
+	root := getTestTree(t)
+
+	tests := []struct {
+		name      string
+		path      []string
+		namespace string
+		want      string
+		wantErr   bool
+	}{
+		{name: "nearest namespace", path: []string{"interfaces", "interface", "bridge-port", "pvid"},
+			namespace: "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge", want: "bridge-port"},
+		{name: "namespace further up", path: []string{"interfaces", "interface", "bridge-port", "pvid"},
+			namespace: "urn:ietf:params:xml:ns:yang:ietf-interfaces", want: "interfaces"},
+		{name: "namespace below the element", path: []string{"interfaces", "interface"},
+			namespace: "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetNamespaceRootWithName(getTestElem(t, root, tt.path...), tt.namespace)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetNamespaceRootWithName() = %q, want an error", got.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetNamespaceRootWithName() error: %v", err)
+			}
+			if got.Name != tt.want {
+				t.Errorf("GetNamespaceRootWithName() = %q, want %q", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetKeyValueInParent(t *testing.T) {
+	root := getTestTree(t)
+
+	tests := []struct {
+		name    string
+		path    []string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{name: "the key leaf itself", path: []string{"interfaces", "interface", "name"}, key: "name", want: "sw0p1"},
+		{name: "sibling of the key leaf", path: []string{"interfaces", "interface", "type"}, key: "name", want: "sw0p1"},
+		{name: "container in the entry", path: []string{"interfaces", "interface", "bridge-port"}, key: "name", want: "sw0p1"},
+		{name: "key of an entry further up", path: []string{"interfaces", "interface", "bridge-port", "pvid"}, key: "name", wantErr: true},
+		{name: "missing key", path: []string{"interfaces", "interface", "type"}, key: "index", wantErr: true},
+		{name: "container is not a key", path: []string{"interfaces", "interface", "name"}, key: "bridge-port", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetKeyValueInParent(getTestElem(t, root, tt.path...), tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetKeyValueInParent() = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetKeyValueInParent() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetKeyValueInParent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetKeyValuesInParent(t *testing.T) {
+	root := getTestTree(t)
+
+	tests := []struct {
+		name    string
+		path    []string
+		keys    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "all keys", path: []string{"interfaces", "interface", "type"}, keys: []string{"name", "type"}, want: []string{"sw0p1", "ethernetCsmacd"}},
+		{name: "one key missing", path: []string{"interfaces", "interface", "type"}, keys: []string{"name", "index"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetKeyValuesInParent(getTestElem(t, root, tt.path...), tt.keys...)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetKeyValuesInParent() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetKeyValuesInParent() error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetKeyValuesInParent() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("GetKeyValuesInParent() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFromAdapterResponseRoot(t *testing.T) {
+	root := getTestTree(t)
+	if root.Name != "data" {
+		t.Fatalf("FromAdapterResponse() = %q, want the data element", root.Name)
+	}
+	if root.Parent != nil {
+		t.Errorf("FromAdapterResponse() has a parent %+v, want none", root.Parent)
+	}
+	if interfaces := getTestElem(t, root, "interfaces"); interfaces.Parent != root {
+		t.Errorf("the parent of interfaces is not the root")
+	}
+}
+
+/*
+A configuration with the elements that only the kinds of the elements tell apart:
+
+	data
+	  interfaces (ietf-interfaces)
+	    interface [name=sw0p1]
+	      gate-parameters (ieee802-dot1q-sched)
+	        admin-control-list [index=1], [index=0] (ordered-by user, not in the order of the keys)
+	  bridges (ieee802-dot1q-bridge)
+	    bridge [name=br0]
+	      component [name=c0]
+	        bridge-port: sw0p2, sw0p1 (leaf-list)
+	        flag (a leaf of the type empty)
+*/
+func getRoundTripTree(t *testing.T) *SchemaTree {
+	t.Helper()
+
+	root := &SchemaTree{Name: "data", Kind: KindContainer}
+	interfaces := GetOrCreate0Keys(root, "ietf-interfaces:interfaces")
+	port := GetOrCreateKeys(interfaces, "interface", map[string]string{"name": "sw0p1"})
+	gateParameters := GetOrCreate0Keys(port, "ieee802-dot1q-sched:gate-parameters")
+	for _, index := range []string{"1", "0"} {
+		entry := GetOrCreateKeys(gateParameters, "admin-control-list", map[string]string{"index": index})
+		entry.OrderedByUser = true
+		GetOrCreate0Keys(entry, "operation-name").SetString("set-gate-states")
+	}
+
+	bridges := GetOrCreate0Keys(root, "ieee802-dot1q-bridge:bridges")
+	bridge := GetOrCreateKeys(bridges, "bridge", map[string]string{"name": "br0"})
+	component := GetOrCreateKeys(bridge, "component", map[string]string{"name": "c0"})
+	SetLeafList(component, "bridge-port", []string{"sw0p2", "sw0p1"})
+	GetOrCreate0Keys(component, "flag").SetEmpty()
+
+	return root
+}
+
+// Check that two trees have the same elements with the same fields, and that every element is the child of its parent
+func assertSameTree(t *testing.T, got *SchemaTree, want *SchemaTree, path string) {
+	t.Helper()
+
+	path += "/" + want.Name
+	if got.Name != want.Name || got.Namespace != want.Namespace || got.Value != want.Value ||
+		got.Kind != want.Kind || got.OrderedByUser != want.OrderedByUser {
+		t.Errorf("%s = %+v, want %+v", path, *got, *want)
+	}
+	if len(got.Children) != len(want.Children) {
+		t.Fatalf("%s has %d children, want %d", path, len(got.Children), len(want.Children))
+	}
+	for i := range want.Children {
+		if got.Children[i].Parent != got {
+			t.Errorf("%s/%s is not a child of its parent", path, got.Children[i].Name)
+		}
+		assertSameTree(t, got.Children[i], want.Children[i], path)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	root := getRoundTripTree(t)
+
+	treeCopy := Copy(root)
+	if treeCopy.Parent != nil {
+		t.Errorf("Copy() has a parent %+v, want none", treeCopy.Parent)
+	}
+	assertSameTree(t, treeCopy, root, "")
+}
+
+func TestAdapterResponseRoundTrip(t *testing.T) {
+	root := getRoundTripTree(t)
+
+	got := FromAdapterResponse(ToAdapterResponse(root))
+	assertSameTree(t, got, root, "")
+
+	component := getTestElem(t, got, "bridges", "bridge", "component")
+	if values := GetLeafList(component, "bridge-port"); len(values) != 2 || values[0] != "sw0p2" || values[1] != "sw0p1" {
+		t.Errorf("bridge-port = %v, want [sw0p2 sw0p1]", values)
+	}
+}
@@ -4,26 +4,21 @@ package SchemaTreeMethods
 Help functions to traverse schema trees
 */
 
-import (
-	"strconv"
-)
-
 // --- FUNCTIONS FOR FINDING FEATURES IN THE BRIDGES
 // Get number of components
 // NOTE: Unlike many other examples, this method assume that "root" contain "data" element instead of its children
 func getNumberofComponentsInBridge(root *SchemaTree, bridgeName string) int64 {
 	//lvl1 := OneLvlDownNamespace(root, "data", "urn:ietf:params:xml:ns:netconf:base:1.0")
-	lvl2 := OneLvlDownNamespace(root, "bridges", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
-	lvl3 := OneLvlDown1Key(lvl2, "bridge", "name", bridgeName)
-	numberOfComponents_str := OneLvlDown0Keys(lvl3, "components").Value
-	numberOfComponents_int, _ := strconv.ParseInt(numberOfComponents_str, 10, 32)
-	return numberOfComponents_int
+	lvl2 := OneLvlDown0Keys(root, "ieee802-dot1q-bridge:bridges")
+	lvl3 := OneLvlDownKeys(lvl2, "bridge", map[string]string{"name": bridgeName})
+	numberOfComponents, _ := OneLvlDown0Keys(lvl3, "components").GetInt64()
+	return numberOfComponents
 }
 
 func getComponentIdsInBridge(root *SchemaTree, bridgeName string) (componentIds []int64) {
 	//lvl1 := OneLvlDownNamespace(root, "data", "urn:ietf:params:xml:ns:netconf:base:1.0")
-	lvl2 := OneLvlDownNamespace(root, "bridges", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
-	bridge := OneLvlDown1Key(lvl2, "bridge", "name", bridgeName)
+	lvl2 := OneLvlDown0Keys(root, "ieee802-dot1q-bridge:bridges")
+	bridge := OneLvlDownKeys(lvl2, "bridge", map[string]string{"name": bridgeName})
 
 	// Find each component in the bridge
 	for _, bridge_param := range bridge.Children {
@@ -33,7 +28,7 @@ func getComponentIdsInBridge(root *SchemaTree, bridgeName string) (componentIds
 			component := bridge_param
 			for _, component_param := range component.Children {
 				if component_param.Name == "id" {
-					componentId, _ := strconv.ParseInt(component_param.Value, 10, 32)
+					componentId, _ := component_param.GetInt64()
 					componentIds = append(componentIds, componentId)
 				}
 			}
@@ -45,7 +40,7 @@ func getComponentIdsInBridge(root *SchemaTree, bridgeName string) (componentIds
 
 func getAllBridgeNames(root *SchemaTree) (bridgeNames []string) {
 	//lvl1 := OneLvlDownNamespace(root, "data", "urn:ietf:params:xml:ns:netconf:base:1.0")
-	bridges := OneLvlDownNamespace(root, "bridges", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
+	bridges := OneLvlDown0Keys(root, "ieee802-dot1q-bridge:bridges")
 
 	// Find each bridge
 	for _, bridge := range bridges.Children {
@@ -64,33 +59,32 @@ func getAllBridgeNames(root *SchemaTree) (bridgeNames []string) {
 // NOTE: Unlike many other examples, this method assume that "root" contain "data" element instead of its children
 func GetNumberofPortsInBridge(root *SchemaTree, bridgeName string) int64 {
 	//lvl1 := OneLvlDownNamespace(root, "data", "urn:ietf:params:xml:ns:netconf:base:1.0")
-	lvl2 := OneLvlDownNamespace(root, "bridges", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
-	lvl3 := OneLvlDown1Key(lvl2, "bridge", "name", bridgeName)
-	numberOfPorts_str := OneLvlDown0Keys(lvl3, "ports").Value
-	numberOfPorts_int, _ := strconv.ParseInt(numberOfPorts_str, 10, 32)
-	return numberOfPorts_int
+	lvl2 := OneLvlDown0Keys(root, "ieee802-dot1q-bridge:bridges")
+	lvl3 := OneLvlDownKeys(lvl2, "bridge", map[string]string{"name": bridgeName})
+	numberOfPorts, _ := OneLvlDown0Keys(lvl3, "ports").GetInt64()
+	return numberOfPorts
 }
 
 func GetMstids(root *SchemaTree, bridgeName string, componentId string) (mstids []int32) {
 	//lvl1 := OneLvlDownNamespace(root, "data", "urn:ietf:params:xml:ns:netconf:base:1.0")
-	lvl2 := OneLvlDownNamespace(root, "bridges", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
-	lvl3 := OneLvlDown1Key(lvl2, "bridge", "name", bridgeName)
-	lvl4 := OneLvlDown1Key(lvl3, "component", "id", componentId)
+	lvl2 := OneLvlDown0Keys(root, "ieee802-dot1q-bridge:bridges")
+	lvl3 := OneLvlDownKeys(lvl2, "bridge", map[string]string{"name": bridgeName})
+	lvl4 := OneLvlDownKeys(lvl3, "component", map[string]string{"id": componentId})
 	bridge_mst := OneLvlDown0Keys(lvl4, "bridge-mst")
 	for _, mstid_obj := range bridge_mst.Children {
-		mstid, _ := strconv.ParseInt(mstid_obj.Value, 10, 32)
+		mstid, _ := mstid_obj.GetInt64()
 		mstids = append(mstids, int32(mstid))
 	}
 	return mstids
 }
 
 func GetBridgesNamespaceSubtree(root *SchemaTree) SchemaTree {
-	bridgeSubtree := OneLvlDownNamespace(root, "bridges", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
+	bridgeSubtree := OneLvlDown0Keys(root, "ieee802-dot1q-bridge:bridges")
 	return *bridgeSubtree
 }
 
 func GetMSTPNamespaceSubtree(root *SchemaTree) SchemaTree {
-	mstpSubtree := OneLvlDownNamespace(root, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
+	mstpSubtree := OneLvlDown0Keys(root, "ieee8021-mstp:ieee8021-mstp")
 	return *mstpSubtree
 }
 
@@ -0,0 +1,243 @@
+package SchemaTreeMethods
+
+/*
+Typed getters and setters of the values of leaves, the value is kept as the text of the adapter's SchemaEntry (the canonical
+form of the YANG type, RFC 7950 chapter 9), so what is set is read back the same and sent to the adapter as it is:
+
+	boolean:     true or false
+	integers:    decimal, without leading zeros or +
+	decimal64:   decimal with at least one digit after the point and no trailing zeros (e.g. 1.5 or 2.0)
+	binary:      base64
+	enumeration: the name of the enum
+	identityref: module:identity, without the module when the identity is in the namespace of the leaf
+	empty:       no value, the element is marked as a leaf (KindLeaf) so it is not taken as an empty container
+	leaf-list:   one leaf with the same name for each value, in order (KindLeafListEntry)
+
+A getter gives an error if the value is not in that form, e.g. "1" is not a boolean
+*/
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"tsn-service/pkg/RAE/dataStructures/namespaces"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Set the value of a leaf, an empty string is a value too
+func (elem *SchemaTree) SetString(value string) {
+	elem.setLeafValue(value)
+}
+
+// Set a leaf of the type empty, it has no value
+func (elem *SchemaTree) SetEmpty() {
+	elem.setLeafValue("")
+}
+
+// Set the value and mark the element as a leaf, a value of a leaf-list stays one
+func (elem *SchemaTree) setLeafValue(value string) {
+	elem.Value = value
+	if elem.Kind != KindLeafListEntry {
+		elem.Kind = KindLeaf
+	}
+}
+
+func (elem *SchemaTree) SetBool(value bool) {
+	elem.setLeafValue(strconv.FormatBool(value))
+}
+
+func (elem *SchemaTree) GetBool() (bool, error) {
+	switch elem.Value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, errors.New("Invalid boolean " + elem.Name + ". Value: " + elem.Value + ". Must be true or false")
+}
+
+func (elem *SchemaTree) SetUint64(value uint64) {
+	elem.setLeafValue(strconv.FormatUint(value, 10))
+}
+
+func (elem *SchemaTree) GetUint64() (uint64, error) {
+	value, err := strconv.ParseUint(elem.Value, 10, 64)
+	if err != nil || !isCanonicalInteger(elem.Value) {
+		return 0, errors.New("Invalid unsigned integer " + elem.Name + ". Value: " + elem.Value)
+	}
+	return value, nil
+}
+
+func (elem *SchemaTree) SetInt64(value int64) {
+	elem.setLeafValue(strconv.FormatInt(value, 10))
+}
+
+func (elem *SchemaTree) GetInt64() (int64, error) {
+	value, err := strconv.ParseInt(elem.Value, 10, 64)
+	if err != nil || !isCanonicalInteger(elem.Value) {
+		return 0, errors.New("Invalid integer " + elem.Name + ". Value: " + elem.Value)
+	}
+	return value, nil
+}
+
+// Check that an integer has no leading zeros or +, as 07 or +7 would not be read back the same
+func isCanonicalInteger(value string) bool {
+	digits := strings.TrimPrefix(value, "-")
+	return !strings.HasPrefix(value, "+") && (digits == "0" || !strings.HasPrefix(digits, "0")) && value != "-0"
+}
+
+// Set a decimal64 as digits * 10^-precision, the gNMI Decimal64
+func (elem *SchemaTree) SetDecimal64(value *pb.Decimal64) {
+	number := new(big.Rat).SetFrac(big.NewInt(value.GetDigits()), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(value.GetPrecision())), nil))
+	text := number.FloatString(int(value.GetPrecision()))
+	if !strings.Contains(text, ".") {
+		text += ".0"
+	}
+	text = strings.TrimRight(text, "0")
+	if strings.HasSuffix(text, ".") {
+		text += "0"
+	}
+	elem.setLeafValue(text)
+}
+
+/*
+Get a decimal64 with the fraction-digits of its type
+
+input:
+
+	fractionDigits: the fraction-digits of the leaf (1 to 18), the precision of the result
+
+output:
+
+	value: the digits of the value with the precision of fractionDigits
+	err: if the value is not a decimal, or has more digits after the point than fractionDigits (a value without a point is an integer)
+*/
+func (elem *SchemaTree) GetDecimal64(fractionDigits uint32) (*pb.Decimal64, error) {
+	integer, fraction, hasPoint := strings.Cut(elem.Value, ".")
+	negative := strings.HasPrefix(integer, "-")
+	integer = strings.TrimPrefix(integer, "-")
+	if integer == "" || (hasPoint && fraction == "") || uint32(len(fraction)) > fractionDigits || strings.Trim(integer+fraction, "0123456789") != "" {
+		return nil, errors.New("Invalid decimal64 " + elem.Name + ". Value: " + elem.Value + ". Must be a decimal with at most " + fmt.Sprint(fractionDigits) + " digits after the point")
+	}
+
+	digits, err := strconv.ParseInt(integer+fraction+strings.Repeat("0", int(fractionDigits)-len(fraction)), 10, 64)
+	if err != nil {
+		return nil, errors.New("Invalid decimal64 " + elem.Name + ". Value: " + elem.Value + ". It does not fit 64 bits")
+	}
+	if negative {
+		digits = -digits
+	}
+	return &pb.Decimal64{Digits: digits, Precision: fractionDigits}, nil
+}
+
+func (elem *SchemaTree) SetBytes(value []byte) {
+	elem.setLeafValue(base64.StdEncoding.EncodeToString(value))
+}
+
+func (elem *SchemaTree) GetBytes() ([]byte, error) {
+	value, err := base64.StdEncoding.DecodeString(elem.Value)
+	if err != nil {
+		return nil, errors.New("Invalid binary " + elem.Name + ". Value: " + elem.Value + ". Must be base64")
+	}
+	return value, nil
+}
+
+func (elem *SchemaTree) SetEnum(value string) {
+	elem.setLeafValue(value)
+}
+
+// Get the name of an enum, it must be one of the enums of the type unless none are given
+func (elem *SchemaTree) GetEnum(enums ...string) (string, error) {
+	if len(enums) == 0 {
+		return elem.Value, nil
+	}
+	for _, enum := range enums {
+		if elem.Value == enum {
+			return elem.Value, nil
+		}
+	}
+	return "", errors.New("Invalid enum " + elem.Name + ". Value: " + elem.Value + ". Must be one of: " + strings.Join(enums, ", "))
+}
+
+/*
+Set an identityref, the identity in a module
+
+input:
+
+	module: the name of the module that defines the identity, e.g. ieee802-dot1q-types
+	identity: the name of the identity
+*/
+func (elem *SchemaTree) SetIdentityref(module string, identity string) {
+	if module == "" || namespaces.GetNamespace(module) == GetClosestNamespace(elem) {
+		elem.setLeafValue(identity)
+		return
+	}
+	elem.setLeafValue(module + ":" + identity)
+}
+
+/*
+Get an identityref
+
+output:
+
+	namespace: the namespace of the module that defines the identity, the namespace of the leaf if the value has no module
+	identity: the name of the identity
+*/
+func (elem *SchemaTree) GetIdentityref() (namespace string, identity string, err error) {
+	identity, namespace = namespaces.SplitQualifiedName(elem.Value)
+	if identity == "" || strings.Contains(identity, ":") {
+		return "", "", errors.New("Invalid identityref " + elem.Name + ". Value: " + elem.Value)
+	}
+	if namespace == "" {
+		namespace = GetClosestNamespace(elem)
+	}
+	return namespace, identity, nil
+}
+
+/*
+Set the values of a leaf-list, one leaf for each value
+The leaves that are in the tree are replaced, the new leaves are put where the first of them was (or last if there are none),
+and are ordered-by user if the leaves they replace were
+
+input:
+
+	root: the element the leaf-list is in
+	name: the name of the leaf-list
+	values: the values as text, e.g. with strconv.FormatUint
+*/
+func SetLeafList(root *SchemaTree, name string, values []string) (leaves []*SchemaTree) {
+	index := -1
+	orderedByUser := false
+	var children []*SchemaTree
+	for _, child := range root.Children {
+		if child.Name == name {
+			if index < 0 {
+				index = len(children)
+				orderedByUser = child.OrderedByUser
+			}
+			continue
+		}
+		children = append(children, child)
+	}
+	if index < 0 {
+		index = len(children)
+	}
+
+	for _, value := range values {
+		leaves = append(leaves, &SchemaTree{Name: name, Parent: root, Value: value, Kind: KindLeafListEntry, OrderedByUser: orderedByUser})
+	}
+	root.Children = append(children[:index], append(leaves, children[index:]...)...)
+	return leaves
+}
+
+// Get the values of a leaf-list in order
+func GetLeafList(root *SchemaTree, name string) (values []string) {
+	for _, leaf := range OneLvlDownAllInstances(root, name) {
+		values = append(values, leaf.Value)
+	}
+	return values
+}
@@ -0,0 +1,264 @@
+package composit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+A configuration with the elements that only the kinds of the elements tell apart:
+
+	data
+	  interfaces (ietf-interfaces)
+	    interface [name=sw0p1]
+	      gate-parameters (ieee802-dot1q-sched)
+	        admin-control-list [index=1], [index=0] (ordered-by user, not in the order of the keys)
+	  bridges (ieee802-dot1q-bridge)
+	    bridge [name=br0]
+	      component [name=c0]
+	        bridge-port: sw0p2, sw0p1 (leaf-list)
+	        flag (a leaf of the type empty)
+*/
+func getRoundTripTree(t *testing.T) *st.SchemaTree {
+	t.Helper()
+
+	root := &st.SchemaTree{Name: "data", Kind: st.KindContainer}
+	interfaces := st.GetOrCreate0Keys(root, "ietf-interfaces:interfaces")
+	port := st.GetOrCreateKeys(interfaces, "interface", map[string]string{"name": "sw0p1"})
+	gateParameters := st.GetOrCreate0Keys(port, "ieee802-dot1q-sched:gate-parameters")
+	for _, index := range []string{"1", "0"} {
+		entry := st.GetOrCreateKeys(gateParameters, "admin-control-list", map[string]string{"index": index})
+		entry.OrderedByUser = true
+		st.GetOrCreate0Keys(entry, "operation-name").SetString("set-gate-states")
+	}
+
+	bridges := st.GetOrCreate0Keys(root, "ieee802-dot1q-bridge:bridges")
+	bridge := st.GetOrCreateKeys(bridges, "bridge", map[string]string{"name": "br0"})
+	component := st.GetOrCreateKeys(bridge, "component", map[string]string{"name": "c0"})
+	st.SetLeafList(component, "bridge-port", []string{"sw0p2", "sw0p1"})
+	st.GetOrCreate0Keys(component, "flag").SetEmpty()
+
+	return root
+}
+
+// Follow the names down from the root
+func getTestElem(t *testing.T, root *st.SchemaTree, names ...string) *st.SchemaTree {
+	t.Helper()
+
+	elem := root
+	for _, name := range names {
+		elem = st.OneLvlDown0Keys(elem, name)
+		if elem.Name != name {
+			t.Fatalf("no element %q in the test tree (path %v)", name, names)
+		}
+	}
+	return elem
+}
+
+func TestGetSetRequestSameTree(t *testing.T) {
+	root := getRoundTripTree(t)
+
+	tests := []struct {
+		name    string
+		desired *st.SchemaTree
+	}{
+		{name: "copy", desired: st.Copy(root)},
+		{name: "through adapterResponse", desired: st.FromAdapterResponse(st.ToAdapterResponse(root))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRequest, err := GetSetRequest("10.0.0.1", root, tt.desired)
+			if err != nil {
+				t.Fatalf("GetSetRequest() error: %v", err)
+			}
+			if len(setRequest.Delete) > 0 || len(setRequest.Replace) > 0 || len(setRequest.Update) > 0 {
+				t.Errorf("GetSetRequest() = %v, want an empty SetRequest", setRequest)
+			}
+		})
+	}
+}
+
+// Get the operations of a SetRequest as text, e.g. "replace /a[namespace=m]/b[k=1]/c = "v"", strings quoted
+func getTestOperations(setRequest *pb.SetRequest) (operations []string) {
+	getPath := func(path *pb.Path) (text string) {
+		for _, elem := range path.GetElem() {
+			text += "/" + elem.GetName()
+			var keys []string
+			for key, value := range elem.GetKey() {
+				keys = append(keys, key+"="+value)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				text += "[" + key + "]"
+			}
+		}
+		return text
+	}
+	getValue := func(value *pb.TypedValue) string {
+		switch v := value.GetValue().(type) {
+		case *pb.TypedValue_StringVal:
+			return fmt.Sprintf("%q", v.StringVal)
+		case *pb.TypedValue_LeaflistVal:
+			var elements []string
+			for _, element := range v.LeaflistVal.GetElement() {
+				elements = append(elements, fmt.Sprintf("%q", element.GetStringVal()))
+			}
+			return "[" + strings.Join(elements, " ") + "]"
+		}
+		return fmt.Sprint(value.GetValue())
+	}
+
+	for _, path := range setRequest.GetDelete() {
+		operations = append(operations, "delete "+getPath(path))
+	}
+	for _, update := range setRequest.GetReplace() {
+		operations = append(operations, "replace "+getPath(update.GetPath())+" = "+getValue(update.GetVal()))
+	}
+	for _, update := range setRequest.GetUpdate() {
+		operations = append(operations, "update "+getPath(update.GetPath())+" = "+getValue(update.GetVal()))
+	}
+	return operations
+}
+
+// Without YANG modules the values are strings, also the ones that look like numbers or booleans
+func TestGetSetRequestChanges(t *testing.T) {
+	const (
+		interfacesNs   = "urn:ietf:params:xml:ns:yang:ietf-interfaces"
+		bridgeNs       = "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge"
+		schedNs        = "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"
+		port           = "/interfaces[namespace=" + interfacesNs + "]/interface[name=sw0p1]"
+		gateParameters = port + "/gate-parameters[namespace=" + schedNs + "]"
+		component      = "/bridges[namespace=" + bridgeNs + "]/bridge[name=br0]/component[name=c0]"
+	)
+
+	tests := []struct {
+		name   string
+		change func(t *testing.T, desired *st.SchemaTree)
+		want   []string
+	}{
+		{
+			name: "changed leaf",
+			change: func(t *testing.T, desired *st.SchemaTree) {
+				entry := st.GetOrCreateKeys(getTestElem(t, desired, "interfaces", "interface", "gate-parameters"), "admin-control-list", map[string]string{"index": "0"})
+				getTestElem(t, entry, "operation-name").SetString("set-and-hold-mac")
+			},
+			want: []string{`replace ` + gateParameters + `/admin-control-list[index=0]/operation-name = "set-and-hold-mac"`},
+		},
+		{
+			name: "new list entry",
+			change: func(t *testing.T, desired *st.SchemaTree) {
+				entry := st.GetOrCreateKeys(getTestElem(t, desired, "interfaces", "interface", "gate-parameters"), "admin-control-list", map[string]string{"index": "2"})
+				st.GetOrCreate0Keys(entry, "time-interval-value").SetUint64(1000)
+			},
+			want: []string{
+				`update ` + gateParameters + `/admin-control-list[index=2]/index = "2"`,
+				`update ` + gateParameters + `/admin-control-list[index=2]/time-interval-value = "1000"`,
+			},
+		},
+		{
+			name: "removed list entry",
+			change: func(t *testing.T, desired *st.SchemaTree) {
+				st.RemoveFromParent(st.GetOrCreateKeys(getTestElem(t, desired, "interfaces", "interface", "gate-parameters"), "admin-control-list", map[string]string{"index": "1"}))
+			},
+			want: []string{`delete ` + gateParameters + `/admin-control-list[index=1]`},
+		},
+		{
+			name: "reordered leaf-list",
+			change: func(t *testing.T, desired *st.SchemaTree) {
+				st.SetLeafList(getTestElem(t, desired, "bridges", "bridge", "component"), "bridge-port", []string{"sw0p1", "sw0p2"})
+			},
+			want: []string{`replace ` + component + `/bridge-port = ["sw0p1" "sw0p2"]`},
+		},
+		{
+			name: "namespace switch",
+			change: func(t *testing.T, desired *st.SchemaTree) {
+				bridgePort := st.GetOrCreate0Keys(getTestElem(t, desired, "interfaces", "interface"), "ieee802-dot1q-bridge:bridge-port")
+				st.GetOrCreate0Keys(bridgePort, "pvid").SetUint64(1)
+				st.GetOrCreate0Keys(bridgePort, "ieee802-dot1q-sched:max-sdu").SetBool(true)
+			},
+			want: []string{
+				`update ` + port + `/bridge-port[namespace=` + bridgeNs + `]/pvid = "1"`,
+				`update ` + port + `/bridge-port[namespace=` + bridgeNs + `]/max-sdu[namespace=` + schedNs + `] = "true"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := getRoundTripTree(t)
+			desired := st.Copy(current)
+			tt.change(t, desired)
+
+			setRequest, err := GetSetRequest("10.0.0.1", current, desired)
+			if err != nil {
+				t.Fatalf("GetSetRequest() error: %v", err)
+			}
+			if got := getTestOperations(setRequest); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("GetSetRequest() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+			for _, update := range append(setRequest.GetReplace(), setRequest.GetUpdate()...) {
+				if target := update.GetPath().GetTarget(); target != "10.0.0.1" {
+					t.Errorf("update of %v has target %q, want 10.0.0.1", update.GetPath().GetElem(), target)
+				}
+			}
+		})
+	}
+}
+
+func TestJsonIetfRoundTrip(t *testing.T) {
+	root := getRoundTripTree(t)
+
+	content, err := EncodeJsonIetf(root)
+	if err != nil {
+		t.Fatalf("EncodeJsonIetf() error: %v", err)
+	}
+	decoded := &st.SchemaTree{Name: "data"}
+	if err := DecodeJsonIetf(decoded, content); err != nil {
+		t.Fatalf("DecodeJsonIetf(%s) error: %v", content, err)
+	}
+
+	// The tree encodes the same again
+	reencoded, err := EncodeJsonIetf(decoded)
+	if err != nil {
+		t.Fatalf("EncodeJsonIetf() of the decoded tree error: %v", err)
+	}
+	if string(reencoded) != string(content) {
+		t.Errorf("EncodeJsonIetf() of the decoded tree = %s, want %s", reencoded, content)
+	}
+
+	component := getTestElem(t, decoded, "bridges", "bridge", "component")
+	if values := st.GetLeafList(component, "bridge-port"); len(values) != 2 || values[0] != "sw0p2" || values[1] != "sw0p1" {
+		t.Errorf("bridge-port = %v, want [sw0p2 sw0p1]", values)
+	}
+	for _, child := range component.Children {
+		if child.Name == "bridge-port" && child.Kind != st.KindLeafListEntry {
+			t.Errorf("bridge-port %q has kind %v, want a leaf-list entry", child.Value, child.Kind)
+		}
+	}
+	if flag := getTestElem(t, component, "flag"); flag.Kind != st.KindLeaf || flag.Value != "" {
+		t.Errorf("flag = %+v, want a leaf without a value", *flag)
+	}
+
+	gateParameters := getTestElem(t, decoded, "interfaces", "interface", "gate-parameters")
+	var indexes []string
+	for _, entry := range gateParameters.Children {
+		if entry.Kind != st.KindListEntry {
+			t.Errorf("admin-control-list %+v is not a list entry", *entry)
+		}
+		index, err := st.GetKeyValueInParent(getTestElem(t, entry, "index"), "index")
+		if err != nil {
+			t.Fatalf("admin-control-list without index: %v", err)
+		}
+		indexes = append(indexes, index)
+	}
+	if len(indexes) != 2 || indexes[0] != "1" || indexes[1] != "0" {
+		t.Errorf("admin-control-list indexes = %v, want [1 0]", indexes)
+	}
+}
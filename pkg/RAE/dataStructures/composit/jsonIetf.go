@@ -0,0 +1,287 @@
+package composit
+
+/*
+Encode schema trees as JSON_IETF (RFC 7951) and decode them again, so a whole container or list can be set in one update
+
+	names:  an element is named "module:name" when its namespace is not the one of its parent, the module is found by its namespace in the namespaces package
+	lists:  the entries of a list or values of a leaf-list are one array, also when there is only one (see isListEntry and ElemKind)
+	leaves: the type of the leaf in the YANG modules decides (see yangSchema.GetJsonType): booleans are true or false, integers of up to
+	        32 bits are numbers, everything else is a string, also every leaf the modules do not have. A leaf of the type empty is [null]
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/RAE/dataStructures/namespaces"
+	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
+	"tsn-service/pkg/RAE/dataStructures/yangSchema"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Get an update that sets an element with everything below it, e.g. to replace a whole table as one
+
+input:
+
+	deviceIp: the device the configuration is on
+	prePath: the path to the parent of the element
+	elem: the element, it must be in the tree (it gets the namespace of its parents)
+
+output:
+
+	update: the path to the element and its content as JSON_IETF
+*/
+func GetJsonIetfUpdate(deviceIp string, prePath []*pb.PathElem, elem *st.SchemaTree) (update *pb.Update, err error) {
+	namespace := ""
+	if elem.Parent != nil {
+		namespace = st.GetClosestNamespace(elem.Parent)
+	}
+	path := getElemPath(prePath, elem, namespace)
+
+	content, err := EncodeJsonIetf(elem)
+	if err != nil {
+		return nil, err
+	}
+	return pbMethods.GetTypedValUpdate(deviceIp, path, pbMethods.GetPbJsonIetfTypeVal(content)), nil
+}
+
+// Encode the content of an element as JSON_IETF, the object of its children (the element itself is named by the path)
+func EncodeJsonIetf(elem *st.SchemaTree) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := encodeChildren(&buffer, elem, st.GetClosestNamespace(elem), getSchemaPath(elem)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Get the path of an element in the YANG modules from the elements above it, the root of the tree (e.g. data) is not in it
+func getSchemaPath(elem *st.SchemaTree) (path []*pb.PathElem) {
+	for current := elem; current != nil && current.Parent != nil; current = current.Parent {
+		path = append([]*pb.PathElem{getSchemaElem(current)}, path...)
+	}
+	return path
+}
+
+// The path element of an element in the YANG modules, named with its namespace if it has its own
+func getSchemaElem(elem *st.SchemaTree) *pb.PathElem {
+	schemaElem := &pb.PathElem{Name: elem.Name, Key: map[string]string{}}
+	if elem.Namespace != "" {
+		schemaElem.Key["namespace"] = elem.Namespace
+	}
+	return schemaElem
+}
+
+// Write the children of an element as one object, with the entries of a list (or leaf-list) as one array
+// path is the path of the element in the YANG modules, the types of the leaves are looked up with it
+func encodeChildren(buffer *bytes.Buffer, elem *st.SchemaTree, namespace string, path []*pb.PathElem) error {
+	buffer.WriteString("{")
+
+	written := map[string]bool{}
+	for _, child := range elem.Children {
+		childNamespace := namespace
+		if child.Namespace != "" {
+			childNamespace = child.Namespace
+		}
+		name := child.Name
+		if childNamespace != namespace {
+			name = namespaces.GetModuleName(childNamespace) + ":" + child.Name
+		}
+		if written[name] {
+			continue
+		}
+		written[name] = true
+
+		if len(written) > 1 {
+			buffer.WriteString(",")
+		}
+		nameJson, _ := json.Marshal(name)
+		buffer.Write(nameJson)
+		buffer.WriteString(":")
+
+		childPath := append(append([]*pb.PathElem{}, path...), getSchemaElem(child))
+		var instances []*st.SchemaTree
+		for _, sibling := range elem.Children {
+			if sibling.Name == child.Name && sibling.Namespace == child.Namespace {
+				instances = append(instances, sibling)
+			}
+		}
+		if len(instances) == 1 && !isListEntry(child) && child.Kind != st.KindLeafListEntry {
+			if err := encodeElem(buffer, child, childNamespace, childPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		buffer.WriteString("[")
+		for index, instance := range instances {
+			if index > 0 {
+				buffer.WriteString(",")
+			}
+			if err := encodeElem(buffer, instance, childNamespace, childPath); err != nil {
+				return err
+			}
+		}
+		buffer.WriteString("]")
+	}
+
+	buffer.WriteString("}")
+	return nil
+}
+
+// Write a container or list entry as an object, a leaf as its value in the JSON type of the leaf
+func encodeElem(buffer *bytes.Buffer, elem *st.SchemaTree, namespace string, path []*pb.PathElem) error {
+	if !elem.IsLeaf() || (elem.Kind == st.KindUnknown && elem.Value == "" && isListEntry(elem)) {
+		return encodeChildren(buffer, elem, namespace, path)
+	}
+	if elem.Value == "" && elem.Kind == st.KindLeaf {
+		buffer.WriteString("[null]")
+		return nil
+	}
+	if elem.Value == "" && elem.Kind == st.KindUnknown {
+		buffer.WriteString("{}")
+		return nil
+	}
+
+	// A value that does not fit the type of the leaf is written as a string, the update is then rejected as invalid
+	switch yangSchema.GetJsonType(&pb.Path{Elem: path}, elem.Value) {
+	case yangSchema.JsonBoolean:
+		if elem.Value == "true" || elem.Value == "false" {
+			buffer.WriteString(elem.Value)
+			return nil
+		}
+	case yangSchema.JsonNumber:
+		if val, err := strconv.ParseInt(elem.Value, 10, 64); err == nil {
+			buffer.WriteString(fmt.Sprint(val))
+			return nil
+		}
+	}
+	valueJson, err := json.Marshal(elem.Value)
+	if err != nil {
+		return err
+	}
+	buffer.Write(valueJson)
+	return nil
+}
+
+// Check if the element is an entry of a list, it is marked as one or has key leaves
+func isListEntry(elem *st.SchemaTree) bool {
+	return elem.Kind == st.KindListEntry || len(st.GetEntryKeys(elem)) > 0
+}
+
+/*
+Decode JSON_IETF, e.g. the value of an update in a gNMI GetResponse, into the children of an element
+
+input:
+
+	elem: the element at the path of the value, the children are added to it
+	content: the JSON_IETF of the element
+
+output:
+
+	err: if the content is not a JSON object
+*/
+func DecodeJsonIetf(elem *st.SchemaTree, content []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return errors.New("JSON_IETF of an element must be an object")
+	}
+	return decodeObject(decoder, elem, st.GetClosestNamespace(elem))
+}
+
+// Add the members of an object to the element, the opening { has been read
+func decodeObject(decoder *json.Decoder, elem *st.SchemaTree, namespace string) error {
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := token.(string)
+
+		childNamespace := namespace
+		if module, elemName, ok := strings.Cut(name, ":"); ok {
+			childNamespace = namespaces.GetNamespace(module)
+			name = elemName
+		}
+
+		// An array is a list, or a leaf-list, with one element per entry
+		token, err = decoder.Token()
+		if err != nil {
+			return err
+		}
+		if token == json.Delim('[') {
+			for decoder.More() {
+				valueToken, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				if err = decodeValue(decoder, elem, name, childNamespace, namespace, valueToken, true); err != nil {
+					return err
+				}
+			}
+			if _, err = decoder.Token(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = decodeValue(decoder, elem, name, childNamespace, namespace, token, false); err != nil {
+			return err
+		}
+	}
+
+	// The closing }
+	_, err := decoder.Token()
+	if err == io.EOF {
+		return errors.New("JSON_IETF ends before the object is closed")
+	}
+	return err
+}
+
+// Add one child to the element from the token that starts its value, inArray if the value is in the array of a list or leaf-list
+func decodeValue(decoder *json.Decoder, elem *st.SchemaTree, name string, namespace string, parentNamespace string, token json.Token, inArray bool) error {
+	child := &st.SchemaTree{Name: name, Parent: elem, Kind: st.KindLeaf}
+	if namespace != parentNamespace {
+		child.Namespace = namespace
+	}
+	elem.Children = append(elem.Children, child)
+
+	switch value := token.(type) {
+	case json.Delim:
+		if value != '{' {
+			return errors.New("unexpected " + value.String() + " in the JSON_IETF of " + name)
+		}
+		child.Kind = st.KindContainer
+		if inArray {
+			child.Kind = st.KindListEntry
+		}
+		return decodeObject(decoder, child, namespace)
+	case nil:
+		// [null] is a leaf of the type empty
+		if !inArray || decoder.More() {
+			return errors.New("unexpected null in the JSON_IETF of " + name)
+		}
+		return nil
+	case string:
+		child.Value = value
+	case json.Number:
+		child.Value = value.String()
+	case bool:
+		child.Value = fmt.Sprint(value)
+	}
+	if inArray {
+		child.Kind = st.KindLeafListEntry
+	}
+	return nil
+}
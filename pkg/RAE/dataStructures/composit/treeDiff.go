@@ -0,0 +1,214 @@
+package composit
+
+/*
+Compare the current configuration of a device with the one it should have, and get the gNMI SetRequest that changes
+the one into the other. Only what differs is in the request:
+
+	delete:  elements that are only in the current tree, the whole element with everything below it
+	replace: leaves that are in both trees with different values, and leaf-lists with other values or another order
+	update:  leaves that are only in the tree it should have, the leaves of new entries included
+
+A leaf without a value is a change only if it is marked as a leaf (KindLeaf, e.g. set with SetEmpty or SetString),
+a leaf-list is sent as a whole, all its values in one update. The values get the type of the leaf in the YANG modules
+(see yangSchema.GetTypedValue), and are sent as strings when the modules are not loaded
+
+Every path names every element down to the change, the entries of lists with their keys, and the namespace where it changes.
+The keys of a list are the ones in the YANG modules when they are loaded (see yangSchema.GetListKeys), otherwise the key
+leaves of the entry in the tree (KindListKey, as GetParamKeys creates them and the adapter stores them)
+*/
+
+import (
+	"fmt"
+	"sort"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
+	"tsn-service/pkg/RAE/dataStructures/yangSchema"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Get the SetRequest that changes the configuration of a device from the current tree to the desired tree
+
+input:
+
+	deviceIp: the device the configuration is on
+	current: the configuration the device has (e.g. from storewrapper.GetDeviceConfig), nil if it has none
+	desired: the configuration the device should have, e.g. a copy of current the setters have changed
+
+output:
+
+	setRequest: the deletes, replaces and updates, empty if the trees are the same
+	err: the updates that are not valid in the YANG modules (see yangSchema.ValidateSetRequest), they are not sent
+*/
+func GetSetRequest(deviceIp string, current *st.SchemaTree, desired *st.SchemaTree) (setRequest *pb.SetRequest, err error) {
+	if current == nil {
+		current = &st.SchemaTree{}
+	}
+
+	setRequest = &pb.SetRequest{}
+	diffChildren(deviceIp, current, desired, nil, st.GetClosestNamespace(desired), setRequest)
+
+	if err = yangSchema.ValidateSetRequest(setRequest); err != nil {
+		return nil, err
+	}
+	return setRequest, nil
+}
+
+// Compare the children of two elements at the same path, namespace is the namespace the children are in
+func diffChildren(deviceIp string, current *st.SchemaTree, desired *st.SchemaTree, prePath []*pb.PathElem, namespace string, setRequest *pb.SetRequest) {
+	currentIds, currentChildren := getChildIds(current, prePath, namespace)
+	desiredIds, desiredChildren := getChildIds(desired, prePath, namespace)
+	diffLeafLists(deviceIp, current, desired, prePath, namespace, setRequest)
+
+	for _, id := range currentIds {
+		if _, ok := desiredChildren[id]; !ok {
+			setRequest.Delete = append(setRequest.Delete, pbMethods.GetDelete(deviceIp, getElemPath(prePath, currentChildren[id], namespace)))
+		}
+	}
+
+	for _, id := range desiredIds {
+		desiredChild := desiredChildren[id]
+		currentChild, ok := currentChildren[id]
+		path := getElemPath(prePath, desiredChild, namespace)
+
+		// A leaf
+		if desiredChild.IsLeaf() {
+			if !ok && (desiredChild.Value != "" || desiredChild.Kind == st.KindLeaf) {
+				setRequest.Update = append(setRequest.Update, pbMethods.GetTypedValUpdate(deviceIp, path, yangSchema.GetTypedValue(&pb.Path{Elem: path}, desiredChild.Value)))
+			} else if ok && desiredChild.Value != currentChild.Value {
+				if desiredChild.Value == "" && desiredChild.Kind != st.KindLeaf {
+					setRequest.Delete = append(setRequest.Delete, pbMethods.GetDelete(deviceIp, path))
+				} else {
+					setRequest.Replace = append(setRequest.Replace, pbMethods.GetTypedValUpdate(deviceIp, path, yangSchema.GetTypedValue(&pb.Path{Elem: path}, desiredChild.Value)))
+				}
+			}
+			continue
+		}
+
+		// A container, list entry or table, a new one is compared with nothing so all its leaves are updates
+		if !ok {
+			currentChild = &st.SchemaTree{}
+		}
+		childNamespace := namespace
+		if desiredChild.Namespace != "" {
+			childNamespace = desiredChild.Namespace
+		}
+		diffChildren(deviceIp, currentChild, desiredChild, path, childNamespace, setRequest)
+	}
+}
+
+// Compare the leaf-lists of two elements at the same path, a leaf-list with other values or in another order is replaced
+func diffLeafLists(deviceIp string, current *st.SchemaTree, desired *st.SchemaTree, prePath []*pb.PathElem, namespace string, setRequest *pb.SetRequest) {
+	currentIds, currentLeafLists := getLeafLists(current)
+	desiredIds, desiredLeafLists := getLeafLists(desired)
+
+	for _, id := range currentIds {
+		if _, ok := desiredLeafLists[id]; !ok {
+			setRequest.Delete = append(setRequest.Delete, pbMethods.GetDelete(deviceIp, getElemPath(prePath, currentLeafLists[id][0], namespace)))
+		}
+	}
+
+	for _, id := range desiredIds {
+		values := getValues(desiredLeafLists[id])
+		if fmt.Sprintf("%q", values) == fmt.Sprintf("%q", getValues(currentLeafLists[id])) {
+			continue
+		}
+		path := getElemPath(prePath, desiredLeafLists[id][0], namespace)
+		setRequest.Replace = append(setRequest.Replace, pbMethods.GetTypedValUpdate(deviceIp, path, yangSchema.GetLeafListTypedValue(&pb.Path{Elem: path}, values)))
+	}
+}
+
+// Get the values of the leaf-lists of an element in order, by the name and namespace of the leaf-list
+func getLeafLists(elem *st.SchemaTree) (ids []string, leafLists map[string][]*st.SchemaTree) {
+	leafLists = map[string][]*st.SchemaTree{}
+	for _, child := range elem.Children {
+		if child.Kind != st.KindLeafListEntry {
+			continue
+		}
+		id := child.Name + "|" + child.Namespace
+		if _, ok := leafLists[id]; !ok {
+			ids = append(ids, id)
+		}
+		leafLists[id] = append(leafLists[id], child)
+	}
+	return ids, leafLists
+}
+
+func getValues(leaves []*st.SchemaTree) (values []string) {
+	for _, leaf := range leaves {
+		values = append(values, leaf.Value)
+	}
+	return values
+}
+
+// Get the children of an element by what identifies them: the name, namespace and the keys of list entries
+// Elements with the same name that have no keys are told apart by their order, the values of leaf-lists are not included
+func getChildIds(elem *st.SchemaTree, prePath []*pb.PathElem, namespace string) (ids []string, children map[string]*st.SchemaTree) {
+	children = map[string]*st.SchemaTree{}
+	occurrences := map[string]int{}
+	for _, child := range elem.Children {
+		if child.Kind == st.KindLeafListEntry {
+			continue
+		}
+		id := child.Name + "|" + child.Namespace
+		keys := getEntryKeys(prePath, child, namespace)
+		for _, key := range getSortedKeys(keys) {
+			id += "|" + key + "=" + keys[key]
+		}
+		occurrences[id] += 1
+		if occurrences[id] > 1 {
+			id += "#" + fmt.Sprint(occurrences[id])
+		}
+
+		ids = append(ids, id)
+		children[id] = child
+	}
+	return ids, children
+}
+
+// Get the path to a child, the child gets the keys of its list and the namespace if it is not the one of its parent
+func getElemPath(prePath []*pb.PathElem, child *st.SchemaTree, namespace string) []*pb.PathElem {
+	keys := getEntryKeys(prePath, child, namespace)
+	if child.Namespace != "" && child.Namespace != namespace {
+		keys["namespace"] = child.Namespace
+	}
+	return pbMethods.GetPath1lvlDownKeys(prePath, child.Name, keys)
+}
+
+// Get the keys of a list entry with their values, empty if the element is not a list entry
+// The keys are the ones of the list in the YANG modules, or the key leaves of the entry if the modules do not have the list
+func getEntryKeys(prePath []*pb.PathElem, elem *st.SchemaTree, namespace string) map[string]string {
+	if elem.IsLeaf() {
+		return map[string]string{}
+	}
+
+	listPath := pbMethods.GetPath1lvlDownKeys(prePath, elem.Name, nil)
+	if elem.Namespace != "" && elem.Namespace != namespace {
+		listPath[len(listPath)-1].Key["namespace"] = elem.Namespace
+	}
+	keyNames := yangSchema.GetListKeys(&pb.Path{Elem: listPath})
+	if keyNames == nil {
+		return st.GetEntryKeys(elem)
+	}
+
+	keys := map[string]string{}
+	for _, key := range keyNames {
+		for _, param := range elem.Children {
+			if param.Name == key && param.IsLeaf() {
+				keys[key] = param.Value
+				break
+			}
+		}
+	}
+	return keys
+}
+
+func getSortedKeys(keys map[string]string) []string {
+	var names []string
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
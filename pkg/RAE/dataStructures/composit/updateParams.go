@@ -1,5 +1,11 @@
 package composit
 
+/*
+Functions to go down the configuration to where a value is set
+Elements that are not in the SchemaTree yet are created on the way (entries of tables and lists with their keys),
+so the value that is set ends up in the tree of the device
+*/
+
 import (
 	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
@@ -8,8 +14,9 @@ import (
 )
 
 // Returns both the pointer to the element "elemName" in a SchemaTree and the path to the element "elemName" as []*pb.PathElem
+// The element can be named "module:name", it is then in the namespace of the module (see the namespaces package)
 func GetParam0Keys(preNode *st.SchemaTree, prePath []*pb.PathElem, elemName string) (treePath *st.SchemaTree, pbPath []*pb.PathElem) {
-	treePath = st.OneLvlDown0Keys(preNode, elemName)
+	treePath = st.GetOrCreate0Keys(preNode, elemName)
 	pbPath = pbMethods.GetPath1lvlDown0Keys(prePath, elemName)
 
 	return treePath, pbPath
@@ -17,83 +24,48 @@ func GetParam0Keys(preNode *st.SchemaTree, prePath []*pb.PathElem, elemName stri
 
 // Composit function, returns both the SchemaTree pointer and the []*pb.PathElem path to the "bridge-port"
 func GetPath2Bridge(preNode *st.SchemaTree, port string) (treePath *st.SchemaTree, path []*pb.PathElem) {
-	treePathLvl1, pbPathLvl1 := GetParamNamespace(preNode, nil, "interfaces", "urn:ietf:params:xml:ns:yang:ietf-interfaces")
-	treePathLvl2, pbPathLvl2 := GetParam1Key(treePathLvl1, pbPathLvl1, "", "interface", "name", port)
-	treePathLvl3, pbPathLvl3 := GetParamNamespace(treePathLvl2, pbPathLvl2, "bridge-port", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
+	treePathLvl1, pbPathLvl1 := GetParam0Keys(preNode, nil, "ietf-interfaces:interfaces")
+	treePathLvl2, pbPathLvl2 := GetParamKeys(treePathLvl1, pbPathLvl1, "", "interface", map[string]string{"name": port})
+	treePathLvl3, pbPathLvl3 := GetParam0Keys(treePathLvl2, pbPathLvl2, "ieee802-dot1q-bridge:bridge-port")
 
 	return treePathLvl3, pbPathLvl3
 }
 
 // Returns both the SchemaTree pointer and the []*pb.PathElem path to the element "elemName" the a given namespace
 func GetParamNamespace(preNode *st.SchemaTree, prePath []*pb.PathElem, elemName string, namespace string) (treePath *st.SchemaTree, pbPath []*pb.PathElem) {
-	treePath = st.OneLvlDownNamespace(preNode, elemName, namespace)
-	pbPath = pbMethods.GetPath1lvlDown1Key(prePath, elemName, "namespace", namespace)
+	treePath = st.GetOrCreateNamespace(preNode, elemName, namespace)
+	pbPath = pbMethods.GetPath1lvlDownKeys(prePath, elemName, map[string]string{"namespace": namespace})
 
 	return treePath, pbPath
 }
 
-// Returns an SchemaTree-pointer and []*pb.PathElem to an element from a table or list with one key and one key-value
+// Returns an SchemaTree-pointer and []*pb.PathElem to an element from a table or list with any number of keys, by key name
 // When an element is selected from a table: use tableName to name the table and entryName to name the entry
 // When an element is selected from a list: use entryName to name the entry in the list
-func GetParam1Key(preNode *st.SchemaTree, prePath []*pb.PathElem, tableName string, entryName string, key1 string, val1 string) (treePath *st.SchemaTree, pbPath []*pb.PathElem) {
+func GetParamKeys(preNode *st.SchemaTree, prePath []*pb.PathElem, tableName string, entryName string, keys map[string]string) (treePath *st.SchemaTree, pbPath []*pb.PathElem) {
 
 	var treeEntry *st.SchemaTree
 	var elem string
 
 	if tableName != "" {
-		treeEntry = st.OneLvlDown0Keys(preNode, tableName)
+		treeEntry = st.GetOrCreate0Keys(preNode, tableName)
 		elem = tableName
 	} else {
 		treeEntry = preNode
 		elem = entryName
 	}
 
-	treePath = st.OneLvlDown1Key(treeEntry, entryName, key1, val1)
-	pbPath = pbMethods.GetPath1lvlDown1Key(prePath, elem, key1, val1)
+	treePath = st.GetOrCreateKeys(treeEntry, entryName, keys)
+	pbPath = pbMethods.GetPath1lvlDownKeys(prePath, elem, keys)
 
 	return treePath, pbPath
 }
 
-// Returns an SchemaTree-pointer and []*pb.PathElem to an element from a table or list with two keys with corresponding key-values
-// When an element is selected from a table: use tableName to name the table and entryName to name the entry
-// When an element is selected from a list: use entryName to name the entry in the list
-func GetParam2Keys(preNode *st.SchemaTree, prePath []*pb.PathElem, tableName string, entryName string, key1 string, val1 string, key2 string, val2 string) (treePath *st.SchemaTree, pbPath []*pb.PathElem) {
-
-	var treeEntry *st.SchemaTree
-	var elem string
-
-	if tableName != "" {
-		treeEntry = st.OneLvlDown0Keys(preNode, tableName)
-		elem = tableName
-	} else {
-		treeEntry = preNode
-		elem = entryName
-	}
-
-	treePath = st.OneLvlDown2Keys(treeEntry, entryName, key1, val1, key2, val2)
-	pbPath = pbMethods.GetPath1lvlDown2Keys(prePath, elem, key1, val1, key2, val2)
-
-	return treePath, pbPath
-}
-
-// Returns an SchemaTree-pointer and []*pb.PathElem to an element from a table or list with three keys with corresponding key-values
-// When an element is selected from a table: use tableName to name the table and entryName to name the entry
-// When an element is selected from a list: use entryName to name the entry in the list
-func GetParam3Keys(preNode *st.SchemaTree, prePath []*pb.PathElem, tableName string, entryName string, key1 string, val1 string, key2 string, val2 string, key3 string, val3 string) (treePath *st.SchemaTree, pbPath []*pb.PathElem) {
-
-	var treeEntry *st.SchemaTree
-	var elem string
-
-	if tableName != "" {
-		treeEntry = st.OneLvlDown0Keys(preNode, tableName)
-		elem = tableName
-	} else {
-		treeEntry = preNode
-		elem = entryName
-	}
-
-	treePath = st.OneLvlDown3Keys(treeEntry, entryName, key1, val1, key2, val2, key3, val3)
-	pbPath = pbMethods.GetPath1lvlDown3Keys(prePath, elem, key1, val1, key2, val2, key3, val3)
+// Sets the values of the leaf-list "elemName" in a SchemaTree, and returns its leaves and the []*pb.PathElem path to the leaf-list
+// The leaves that were in the tree are replaced, the update of the path sets all values at once (e.g. GetPbUintLeafListTypeVal)
+func SetParamLeafList(preNode *st.SchemaTree, prePath []*pb.PathElem, elemName string, values []string) (leaves []*st.SchemaTree, pbPath []*pb.PathElem) {
+	leaves = st.SetLeafList(preNode, elemName, values)
+	pbPath = pbMethods.GetPath1lvlDown0Keys(prePath, elemName)
 
-	return treePath, pbPath
+	return leaves, pbPath
 }
@@ -0,0 +1,183 @@
+package namespaces
+
+/*
+The YANG modules of the configuration, to go between a module name (or prefix), its namespace (URN) and its revision
+The modules the RAE configures are known from the start, the adapter's capabilities and the loaded YANG modules add to them
+
+Elements can be named "module:name" instead of by name and namespace, the module by its name or its prefix
+(e.g. ietf-interfaces:interfaces or if:interfaces), as in the paths of gNMI and the names of JSON_IETF (RFC 7951)
+*/
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// A YANG module, the fields that are not known are empty
+type Module struct {
+	Name      string
+	Prefix    string
+	Namespace string
+	Revision  string
+}
+
+// The modules the RAE configures, before anything is registered
+var modules = []*Module{
+	{Name: "ietf-interfaces", Prefix: "if", Namespace: "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
+	{Name: "ieee802-dot1q-bridge", Prefix: "dot1q", Namespace: "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge"},
+	{Name: "ieee802-dot1q-sched", Prefix: "sched", Namespace: "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
+	{Name: "ieee802-dot1cb-frer", Namespace: "urn:ieee:std:802.1Q:yang:ieee802-dot1cb-frer"},
+	{Name: "ieee8021-mstp", Namespace: "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp"},
+}
+
+var modulesLock sync.RWMutex
+
+/*
+Add a module, or add what is known of it to the module with the same name (or namespace if it has no name)
+Fields that are empty are not changed
+*/
+func Register(module Module) {
+	modulesLock.Lock()
+	defer modulesLock.Unlock()
+
+	for _, known := range modules {
+		if (module.Name != "" && known.Name == module.Name) || (module.Name == "" && known.Namespace == module.Namespace) {
+			if module.Prefix != "" {
+				known.Prefix = module.Prefix
+			}
+			if module.Namespace != "" {
+				known.Namespace = module.Namespace
+			}
+			if module.Revision != "" {
+				known.Revision = module.Revision
+			}
+			return
+		}
+	}
+	modules = append(modules, &module)
+}
+
+/*
+Register the modules in the capabilities of the adapter (gNMI CapabilityResponse)
+The name of a model is either the module name, with the revision as version, or a NETCONF capability (see RegisterNetconfCapability)
+
+output:
+
+	err: the capabilities that could not be read, the others are registered
+*/
+func RegisterCapabilities(capabilities *pb.CapabilityResponse) error {
+	var errs []error
+	for _, model := range capabilities.GetSupportedModels() {
+		if strings.Contains(model.GetName(), "?") {
+			if err := RegisterNetconfCapability(model.GetName()); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if model.GetName() == "" {
+			errs = append(errs, errors.New("a model in the capabilities has no name"))
+			continue
+		}
+		Register(Module{Name: model.GetName(), Revision: model.GetVersion()})
+	}
+	return errors.Join(errs...)
+}
+
+/*
+Register the module of a NETCONF capability, e.g. urn:ietf:params:xml:ns:yang:ietf-interfaces?module=ietf-interfaces&revision=2018-02-20
+Capabilities without a module (e.g. urn:ietf:params:netconf:base:1.1) are not modules and are skipped
+*/
+func RegisterNetconfCapability(capability string) error {
+	namespace, query, ok := strings.Cut(capability, "?")
+	if !ok {
+		return nil
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return errors.New("Invalid capability. Value: " + capability)
+	}
+	if params.Get("module") == "" {
+		return nil
+	}
+
+	Register(Module{Name: params.Get("module"), Namespace: namespace, Revision: params.Get("revision")})
+	return nil
+}
+
+// Get a module by its name or prefix
+func GetModule(nameOrPrefix string) (Module, bool) {
+	modulesLock.RLock()
+	defer modulesLock.RUnlock()
+
+	for _, known := range modules {
+		if known.Name == nameOrPrefix {
+			return *known, true
+		}
+	}
+	for _, known := range modules {
+		if known.Prefix != "" && known.Prefix == nameOrPrefix {
+			return *known, true
+		}
+	}
+	return Module{}, false
+}
+
+// Get the module with a namespace
+func GetModuleByNamespace(namespace string) (Module, bool) {
+	modulesLock.RLock()
+	defer modulesLock.RUnlock()
+
+	for _, known := range modules {
+		if known.Namespace == namespace {
+			return *known, true
+		}
+	}
+	return Module{}, false
+}
+
+// Get all known modules
+func GetModules() (known []Module) {
+	modulesLock.RLock()
+	defer modulesLock.RUnlock()
+
+	for _, module := range modules {
+		known = append(known, *module)
+	}
+	return known
+}
+
+// Get the namespace of a module by its name or prefix, the name is used if the module is not known
+func GetNamespace(nameOrPrefix string) string {
+	if module, ok := GetModule(nameOrPrefix); ok && module.Namespace != "" {
+		return module.Namespace
+	}
+	return nameOrPrefix
+}
+
+// Get the name of the module with a namespace, the last part of the namespace if the module is not known
+func GetModuleName(namespace string) string {
+	if module, ok := GetModuleByNamespace(namespace); ok && module.Name != "" {
+		return module.Name
+	}
+	return namespace[strings.LastIndex(namespace, ":")+1:]
+}
+
+/*
+Split a name that can be qualified with a module, "module:name" or just "name"
+
+output:
+
+	name: the name without the module
+	namespace: the namespace of the module, empty if the name is not qualified
+*/
+func SplitQualifiedName(qualifiedName string) (name string, namespace string) {
+	module, name, ok := strings.Cut(qualifiedName, ":")
+	if !ok {
+		return qualifiedName, ""
+	}
+	return name, GetNamespace(module)
+}
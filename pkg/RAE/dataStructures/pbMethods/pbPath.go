@@ -6,6 +6,8 @@ Is used to set the values of the gNMI (gRPC Network Managment Interface) configu
 */
 
 import (
+	"tsn-service/pkg/RAE/dataStructures/namespaces"
+
 	pb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
@@ -13,14 +15,9 @@ import (
 Get path down to bridge-port: interfaces -> interface at specific port -> bridge-port
 */
 func GetPath2bridge(port string) (path []*pb.PathElem) {
-	path1lvl := []*pb.PathElem{
-		{
-			Name: "interfaces",
-			Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-		},
-	}
-	path2lvl := GetPath1lvlDown1Key(path1lvl, "interface", "name", port)
-	path = GetPath1lvlDown1Key(path2lvl, "bridge-port", "namespace", "urn:ieee:std:802.1Q:yang:ieee802-dot1q-bridge")
+	path1lvl := GetPath1lvlDown0Keys(nil, "ietf-interfaces:interfaces")
+	path2lvl := GetPath1lvlDownKeys(path1lvl, "interface", map[string]string{"name": port})
+	path = GetPath1lvlDown0Keys(path2lvl, "ieee802-dot1q-bridge:bridge-port")
 
 	return path
 }
@@ -29,84 +26,91 @@ func GetPath2bridge(port string) (path []*pb.PathElem) {
 Get path down one level bellow the provided prePath, where no keys are required
 */
 func GetPath1lvlDown0Keys(prePath []*pb.PathElem, name1 string) (path []*pb.PathElem) {
-	postPath := []*pb.PathElem{
-		{
-			Name: name1,
-			Key:  map[string]string{},
-		},
-	}
-	if prePath == nil {
-		path = postPath
-	} else {
-		path = append(prePath, postPath...)
-	}
-	return path
+	return GetPath1lvlDownKeys(prePath, name1, nil)
 }
 
 /*
-Get path down one level bellow the provided prePath, where 1 key are required
+Get path down one level bellow the provided prePath, with any number of keys
+The name can be "module:name", the element then gets the namespace of the module as a key
+The prePath and keys are copied, so paths to several elements can be built from the same prePath
 */
-func GetPath1lvlDown1Key(prePath []*pb.PathElem, name string, leftKey string, rightkey string) (path []*pb.PathElem) {
-	postPath := []*pb.PathElem{
-		{
-			Name: name,
-			Key:  map[string]string{leftKey: rightkey},
-		},
-	}
-	if prePath == nil {
-		path = postPath
-	} else {
-		path = append(prePath, postPath...)
+func GetPath1lvlDownKeys(prePath []*pb.PathElem, name string, keys map[string]string) (path []*pb.PathElem) {
+	name, namespace := namespaces.SplitQualifiedName(name)
+	elem := &pb.PathElem{
+		Name: name,
+		Key:  map[string]string{},
 	}
-	return path
-}
-
-/*
-Get path down one level bellow the provided prePath, where 2 keys are required
-*/
-func GetPath1lvlDown2Keys(prePath []*pb.PathElem, name string, leftKey1 string, rightkey1 string, leftKey2 string, rightkey2 string) (path []*pb.PathElem) {
-	postPath := []*pb.PathElem{
-		{
-			Name: name,
-			Key:  map[string]string{leftKey1: rightkey1, leftKey2: rightkey2},
-		},
+	for key, val := range keys {
+		elem.Key[key] = val
 	}
-	if prePath == nil {
-		path = postPath
-	} else {
-		path = append(prePath, postPath...)
+	if namespace != "" {
+		elem.Key["namespace"] = namespace
 	}
-	return path
+
+	path = make([]*pb.PathElem, 0, len(prePath)+1)
+	path = append(path, prePath...)
+	return append(path, elem)
 }
 
 /*
-Get path down one level bellow the provided prePath, where 3 keys are required
+Help function to get config update from the path
 */
-func GetPath1lvlDown3Keys(prePath []*pb.PathElem, name1 string, leftKey1 string, rightkey1 string, leftKey2 string, rightkey2 string, leftKey3 string, rightKey3 string) (path []*pb.PathElem) {
-	postPath := []*pb.PathElem{
-		{
-			Name: name1,
-			Key:  map[string]string{leftKey1: rightkey1, leftKey2: rightkey2, leftKey3: rightKey3},
+func GetUpdate(deviceIp string, configPath []*pb.PathElem, configValue pb.TypedValue) (update *pb.Update) {
+	update = &pb.Update{
+		Path: &pb.Path{
+			Elem:   configPath,
+			Target: deviceIp,
 		},
+		Val: &configValue,
 	}
-	if prePath == nil {
-		path = postPath
-	} else {
-		path = append(prePath, postPath...)
-	}
-	return path
+	return update
 }
 
 /*
-Help function to get config update from the path
+Help function to get config update from the path, with a value that is already a *pb.TypedValue
 */
-func GetUpdate(deviceIp string, configPath []*pb.PathElem, configValue pb.TypedValue) (update *pb.Update) {
+func GetTypedValUpdate(deviceIp string, configPath []*pb.PathElem, configValue *pb.TypedValue) (update *pb.Update) {
 	update = &pb.Update{
 		Path: &pb.Path{
 			Elem:   configPath,
 			Target: deviceIp,
 		},
-		Val: &configValue,
+		Val: configValue,
 	}
 	return update
 }
+
+/*
+Help function to get the path of a config delete, everything below the path is removed from the device
+*/
+func GetDelete(deviceIp string, configPath []*pb.PathElem) (deletePath *pb.Path) {
+	deletePath = &pb.Path{
+		Elem:   configPath,
+		Target: deviceIp,
+	}
+	return deletePath
+}
+
+/*
+Help function to group config updates and deletes into one SetRequest per device, by the target of their paths
+The updates and deletes keep their order within the SetRequest of the device
+*/
+func GetSetRequests(updates []*pb.Update, deletes []*pb.Path) (setRequests map[string]*pb.SetRequest) {
+	setRequests = map[string]*pb.SetRequest{}
+	getSetRequest := func(deviceIp string) *pb.SetRequest {
+		if _, ok := setRequests[deviceIp]; !ok {
+			setRequests[deviceIp] = &pb.SetRequest{}
+		}
+		return setRequests[deviceIp]
+	}
+
+	for _, deletePath := range deletes {
+		setRequest := getSetRequest(deletePath.GetTarget())
+		setRequest.Delete = append(setRequest.Delete, deletePath)
+	}
+	for _, update := range updates {
+		setRequest := getSetRequest(update.GetPath().GetTarget())
+		setRequest.Update = append(setRequest.Update, update)
+	}
+	return setRequests
+}
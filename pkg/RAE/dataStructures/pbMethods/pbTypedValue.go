@@ -53,3 +53,30 @@ func GetPbBytesTypeVal(input []byte) (value pb.TypedValue) {
 		}}
 	return value
 }
+
+// Return *pb.TypedValue for a JSON_IETF encoded container or list (RFC 7951)
+func GetPbJsonIetfTypeVal(input []byte) *pb.TypedValue {
+	return &pb.TypedValue{Value: &pb.TypedValue_JsonIetfVal{JsonIetfVal: input}}
+}
+
+// Return *pb.TypedValue for a leaf-list of strings
+func GetPbStringLeafListTypeVal(input []string) *pb.TypedValue {
+	var elements []*pb.TypedValue
+	for _, val := range input {
+		elements = append(elements, &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: val}})
+	}
+	return getPbLeafListTypeVal(elements)
+}
+
+// Return *pb.TypedValue for a leaf-list of unsigned integers
+func GetPbUintLeafListTypeVal(input []uint) *pb.TypedValue {
+	var elements []*pb.TypedValue
+	for _, val := range input {
+		elements = append(elements, &pb.TypedValue{Value: &pb.TypedValue_UintVal{UintVal: uint64(val)}})
+	}
+	return getPbLeafListTypeVal(elements)
+}
+
+func getPbLeafListTypeVal(elements []*pb.TypedValue) *pb.TypedValue {
+	return &pb.TypedValue{Value: &pb.TypedValue_LeaflistVal{LeaflistVal: &pb.ScalarArray{Element: elements}}}
+}
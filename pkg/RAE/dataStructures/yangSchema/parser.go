@@ -0,0 +1,190 @@
+package yangSchema
+
+/*
+Parse the statements of a YANG module (RFC 7950 6.3): a keyword, an optional argument, and either ";" or a block of
+statements. Only the generic syntax is parsed here, what the statements mean is in schema.go
+*/
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// One statement of a YANG module
+type statement struct {
+	keyword  string
+	argument string
+	children []*statement
+}
+
+// Get the first child statement with a keyword, nil if there is none
+func (s *statement) child(keyword string) *statement {
+	for _, child := range s.children {
+		if child.keyword == keyword {
+			return child
+		}
+	}
+	return nil
+}
+
+// Get the argument of the first child statement with a keyword, empty if there is none
+func (s *statement) childArgument(keyword string) string {
+	if child := s.child(keyword); child != nil {
+		return child.argument
+	}
+	return ""
+}
+
+// Parse all statements of a YANG file
+func parseStatements(data string) ([]*statement, error) {
+	tokens, err := getTokens(data)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, rest, err := parseBlock(tokens)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("unexpected " + rest[0].text + " at line " + fmt.Sprint(rest[0].line))
+	}
+	return statements, nil
+}
+
+// Parse statements until the end of the tokens or a "}", which is left in the rest
+func parseBlock(tokens []token) (statements []*statement, rest []token, err error) {
+	for len(tokens) > 0 && !(tokens[0].text == "}" && !tokens[0].quoted) {
+		keyword := tokens[0]
+		if keyword.isSymbol() {
+			return nil, nil, errors.New("expected a keyword at line " + fmt.Sprint(keyword.line) + ", got " + keyword.text)
+		}
+		stmt := &statement{keyword: keyword.text}
+		tokens = tokens[1:]
+
+		// The argument, quoted strings can be concatenated with +
+		for len(tokens) > 0 && !tokens[0].isSymbol() {
+			stmt.argument += tokens[0].text
+			tokens = tokens[1:]
+			if len(tokens) > 1 && tokens[0].text == "+" && !tokens[0].quoted {
+				tokens = tokens[1:]
+				continue
+			}
+			break
+		}
+
+		if len(tokens) == 0 {
+			return nil, nil, errors.New("statement " + stmt.keyword + " at line " + fmt.Sprint(keyword.line) + " is not ended")
+		}
+		switch tokens[0].text {
+		case ";":
+			tokens = tokens[1:]
+		case "{":
+			stmt.children, tokens, err = parseBlock(tokens[1:])
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(tokens) == 0 {
+				return nil, nil, errors.New("block of " + stmt.keyword + " at line " + fmt.Sprint(keyword.line) + " is not closed")
+			}
+			tokens = tokens[1:]
+		default:
+			return nil, nil, errors.New("expected ; or { at line " + fmt.Sprint(tokens[0].line) + ", got " + tokens[0].text)
+		}
+		statements = append(statements, stmt)
+	}
+	return statements, tokens, nil
+}
+
+// One token of a YANG file, quoted strings are never symbols
+type token struct {
+	text   string
+	quoted bool
+	line   int
+}
+
+func (t token) isSymbol() bool {
+	return !t.quoted && (t.text == ";" || t.text == "{" || t.text == "}")
+}
+
+// Split a YANG file into tokens, without comments
+func getTokens(data string) (tokens []token, err error) {
+	line := 1
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(data[i:], "//"):
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(data[i:], "/*"):
+			end := strings.Index(data[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.New("comment at line " + fmt.Sprint(line) + " is not closed")
+			}
+			line += strings.Count(data[i:i+2+end], "\n")
+			i += end + 4
+		case c == ';' || c == '{' || c == '}':
+			tokens = append(tokens, token{text: string(c), line: line})
+			i++
+		case c == '"' || c == '\'':
+			text, length, err := getQuotedString(data[i:])
+			if err != nil {
+				return nil, errors.New(err.Error() + " at line " + fmt.Sprint(line))
+			}
+			tokens = append(tokens, token{text: text, quoted: true, line: line})
+			line += strings.Count(data[i:i+length], "\n")
+			i += length
+		default:
+			start := i
+			for i < len(data) && !strings.ContainsRune(" \t\r\n;{}", rune(data[i])) &&
+				!strings.HasPrefix(data[i:], "//") && !strings.HasPrefix(data[i:], "/*") {
+				i++
+			}
+			tokens = append(tokens, token{text: data[start:i], line: line})
+		}
+	}
+	return tokens, nil
+}
+
+// Get the text of a quoted string at the start of data, and the number of bytes it takes with the quotes
+// Double quoted strings can have escapes (\n, \t, \" and \\), single quoted strings are taken as they are
+func getQuotedString(data string) (string, int, error) {
+	quote := data[0]
+	if quote == '\'' {
+		end := strings.IndexByte(data[1:], '\'')
+		if end < 0 {
+			return "", 0, errors.New("string is not closed")
+		}
+		return data[1 : end+1], end + 2, nil
+	}
+
+	var text strings.Builder
+	for i := 1; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			return text.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(data) {
+				i++
+				switch data[i] {
+				case 'n':
+					text.WriteByte('\n')
+				case 't':
+					text.WriteByte('\t')
+				default:
+					text.WriteByte(data[i])
+				}
+			}
+		default:
+			text.WriteByte(data[i])
+		}
+	}
+	return "", 0, errors.New("string is not closed")
+}
@@ -0,0 +1,431 @@
+package yangSchema
+
+/*
+Build the schema of the data nodes from the statements of YANG modules:
+
+	modules:    module and submodule (include), import with prefixes, the namespace of every node is the one of the module it is in
+	data nodes: container, list (with key), leaf, leaf-list, choice and case (their nodes are put in the parent)
+	reuse:      typedef, grouping and uses (the nodes get the namespace of the module that uses the grouping), augment
+	types:      the built-in types, with range, length, enumeration and union
+
+What the modules say about state, features, when/must and defaults is not used, only what is needed to check an update
+*/
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// A data node of the schema: container, list, leaf or leaf-list
+type Node struct {
+	Name      string
+	Namespace string
+	Kind      string   // container, list, leaf or leaf-list
+	Keys      []string // list
+	Type      *Type    // leaf and leaf-list
+	Children  []*Node
+
+	choices map[string]bool // the choices and cases in the node, an augment path can name them
+}
+
+// The type of a leaf, with the restrictions of all typedefs it is derived from
+type Type struct {
+	Base    string   // the built-in type, e.g. uint32, string or enumeration
+	Ranges  []bounds // integers and decimal64, empty if only the built-in type limits it
+	Lengths []bounds // string and binary
+	Enums   []string // enumeration
+	Union   []*Type  // union
+}
+
+// An allowed part of a range or length, a nil bound is not limited
+type bounds struct {
+	min *big.Rat
+	max *big.Rat
+}
+
+// The loaded modules and the data nodes at the top of the configuration
+type Schema struct {
+	modules map[string]*module
+	Roots   []*Node
+}
+
+type module struct {
+	name      string
+	namespace string
+	prefix    string
+	revision  string            // the latest revision
+	imports   map[string]string // module name by prefix
+	typedefs  map[string]*statement
+	groupings map[string]*statement
+	body      []*statement
+}
+
+// The smallest and largest value of the built-in integer types
+var integerLimits = map[string][2]string{
+	"int8":   {"-128", "127"},
+	"int16":  {"-32768", "32767"},
+	"int32":  {"-2147483648", "2147483647"},
+	"int64":  {"-9223372036854775808", "9223372036854775807"},
+	"uint8":  {"0", "255"},
+	"uint16": {"0", "65535"},
+	"uint32": {"0", "4294967295"},
+	"uint64": {"0", "18446744073709551615"},
+}
+
+var builtinTypes = map[string]bool{
+	"binary": true, "bits": true, "boolean": true, "decimal64": true, "empty": true, "enumeration": true,
+	"identityref": true, "instance-identifier": true, "leafref": true, "string": true, "union": true,
+	"int8": true, "int16": true, "int32": true, "int64": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+}
+
+/*
+Build the schema from the statements of YANG files, every file has one module or submodule
+
+output:
+
+	schema: the data nodes of all modules, with the augments of the modules applied
+	err: if a module is not complete (e.g. an import, typedef or grouping that is not in the files)
+*/
+func buildSchema(files [][]*statement) (*Schema, error) {
+	schema := &Schema{modules: map[string]*module{}}
+	submodules := map[string]*statement{}
+
+	var moduleStatements []*statement
+	for _, statements := range files {
+		for _, stmt := range statements {
+			switch stmt.keyword {
+			case "module":
+				moduleStatements = append(moduleStatements, stmt)
+			case "submodule":
+				submodules[stmt.argument] = stmt
+			}
+		}
+	}
+
+	for _, stmt := range moduleStatements {
+		mod := &module{
+			name:      stmt.argument,
+			namespace: stmt.childArgument("namespace"),
+			prefix:    stmt.childArgument("prefix"),
+			revision:  getLatestRevision(stmt),
+			imports:   map[string]string{},
+			typedefs:  map[string]*statement{},
+			groupings: map[string]*statement{},
+		}
+
+		// The statements of the submodules are part of the module, with their own imports
+		body := stmt.children
+		for _, include := range getChildren(stmt, "include") {
+			submodule, ok := submodules[include.argument]
+			if !ok {
+				return nil, errors.New("module " + mod.name + " includes submodule " + include.argument + ", which is not loaded")
+			}
+			body = append(body, submodule.children...)
+		}
+		mod.body = body
+
+		for _, child := range body {
+			if child.keyword == "import" {
+				mod.imports[child.childArgument("prefix")] = child.argument
+			}
+		}
+		collectDefinitions(body, mod)
+		schema.modules[mod.name] = mod
+	}
+
+	for _, stmt := range moduleStatements {
+		mod := schema.modules[stmt.argument]
+		nodes, _, err := schema.buildNodes(mod.body, mod, mod.namespace)
+		if err != nil {
+			return nil, errors.New("module " + mod.name + ": " + err.Error())
+		}
+		schema.Roots = append(schema.Roots, nodes...)
+	}
+
+	if err := schema.applyAugments(moduleStatements); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// Get the latest revision of a module, revisions are dates so the latest is the largest
+func getLatestRevision(stmt *statement) (latest string) {
+	for _, revision := range getChildren(stmt, "revision") {
+		if revision.argument > latest {
+			latest = revision.argument
+		}
+	}
+	return latest
+}
+
+// Collect the typedefs and groupings of a module, also those that are inside other statements
+func collectDefinitions(statements []*statement, mod *module) {
+	for _, stmt := range statements {
+		switch stmt.keyword {
+		case "typedef":
+			mod.typedefs[stmt.argument] = stmt
+		case "grouping":
+			mod.groupings[stmt.argument] = stmt
+		}
+		collectDefinitions(stmt.children, mod)
+	}
+}
+
+// Build the data nodes of statements, mod is the module the statements are written in and namespace the one the nodes get
+// The names of the choices and cases are given as well, their nodes are put in the parent
+func (schema *Schema) buildNodes(statements []*statement, mod *module, namespace string) (nodes []*Node, choices map[string]bool, err error) {
+	choices = map[string]bool{}
+	for _, stmt := range statements {
+		switch stmt.keyword {
+		case "container", "list", "leaf", "leaf-list":
+			node := &Node{Name: stmt.argument, Namespace: namespace, Kind: stmt.keyword}
+			if stmt.keyword == "list" {
+				node.Keys = strings.Fields(stmt.childArgument("key"))
+			}
+			if typeStmt := stmt.child("type"); typeStmt != nil {
+				if node.Type, err = schema.resolveType(typeStmt, mod); err != nil {
+					return nil, nil, errors.New(stmt.keyword + " " + stmt.argument + ": " + err.Error())
+				}
+			}
+			if node.Children, node.choices, err = schema.buildNodes(stmt.children, mod, namespace); err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, node)
+		case "choice", "case", "uses":
+			body := stmt.children
+			definitionModule := mod
+			if stmt.keyword == "uses" {
+				var grouping *statement
+				grouping, definitionModule, err = schema.getDefinition(stmt.argument, mod, func(m *module) map[string]*statement { return m.groupings })
+				if err != nil {
+					return nil, nil, errors.New("uses " + stmt.argument + ": " + err.Error())
+				}
+				body = grouping.children
+			} else {
+				choices[stmt.argument] = true
+			}
+
+			children, childChoices, err := schema.buildNodes(body, definitionModule, namespace)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, children...)
+			for choice := range childChoices {
+				choices[choice] = true
+			}
+		}
+	}
+	return nodes, choices, nil
+}
+
+// Get a typedef or grouping by its (prefixed) name, and the module it is in
+func (schema *Schema) getDefinition(name string, mod *module, definitions func(*module) map[string]*statement) (*statement, *module, error) {
+	definitionModule := mod
+	if prefix, localName, ok := strings.Cut(name, ":"); ok {
+		name = localName
+		if prefix != mod.prefix {
+			moduleName, ok := mod.imports[prefix]
+			if !ok {
+				return nil, nil, errors.New("unknown prefix " + prefix)
+			}
+			if definitionModule, ok = schema.modules[moduleName]; !ok {
+				return nil, nil, errors.New("module " + moduleName + " is not loaded")
+			}
+		}
+	}
+
+	definition, ok := definitions(definitionModule)[name]
+	if !ok {
+		return nil, nil, errors.New(name + " is not in module " + definitionModule.name)
+	}
+	return definition, definitionModule, nil
+}
+
+// Get the type of a type statement, following the typedefs down to the built-in type
+func (schema *Schema) resolveType(typeStmt *statement, mod *module) (*Type, error) {
+	var resolved *Type
+	if builtinTypes[typeStmt.argument] {
+		resolved = &Type{Base: typeStmt.argument}
+	} else {
+		typedef, typedefModule, err := schema.getDefinition(typeStmt.argument, mod, func(m *module) map[string]*statement { return m.typedefs })
+		if err != nil {
+			return nil, errors.New("type " + typeStmt.argument + ": " + err.Error())
+		}
+		baseType, err := schema.resolveType(typedef.child("type"), typedefModule)
+		if err != nil {
+			return nil, err
+		}
+		copied := *baseType
+		resolved = &copied
+	}
+
+	// The restrictions of the statement replace those of the typedef
+	if rangeStmt := typeStmt.child("range"); rangeStmt != nil {
+		ranges, err := parseBounds(rangeStmt.argument, resolved.Base)
+		if err != nil {
+			return nil, err
+		}
+		resolved.Ranges = ranges
+	}
+	if lengthStmt := typeStmt.child("length"); lengthStmt != nil {
+		lengths, err := parseBounds(lengthStmt.argument, "uint64")
+		if err != nil {
+			return nil, err
+		}
+		resolved.Lengths = lengths
+	}
+	if enums := getChildren(typeStmt, "enum"); len(enums) > 0 {
+		resolved.Enums = nil
+		for _, enum := range enums {
+			resolved.Enums = append(resolved.Enums, enum.argument)
+		}
+	}
+	if resolved.Base == "union" && typeStmt.argument == "union" {
+		for _, memberStmt := range getChildren(typeStmt, "type") {
+			member, err := schema.resolveType(memberStmt, mod)
+			if err != nil {
+				return nil, err
+			}
+			resolved.Union = append(resolved.Union, member)
+		}
+	}
+	return resolved, nil
+}
+
+// Parse a range or length argument, e.g. "0..255 | 300" or "min..10"
+func parseBounds(argument string, base string) (parts []bounds, err error) {
+	for _, part := range strings.Split(argument, "|") {
+		lower, upper, isRange := strings.Cut(strings.TrimSpace(part), "..")
+		if !isRange {
+			upper = lower
+		}
+
+		var b bounds
+		if b.min, err = parseBound(strings.TrimSpace(lower), base); err != nil {
+			return nil, err
+		}
+		if b.max, err = parseBound(strings.TrimSpace(upper), base); err != nil {
+			return nil, err
+		}
+		parts = append(parts, b)
+	}
+	return parts, nil
+}
+
+// Parse one bound, min and max are the limits of the built-in type (nil if it has none)
+func parseBound(bound string, base string) (*big.Rat, error) {
+	if bound == "min" || bound == "max" {
+		limits, ok := integerLimits[base]
+		if !ok {
+			return nil, nil
+		}
+		limit := limits[0]
+		if bound == "max" {
+			limit = limits[1]
+		}
+		value, _ := new(big.Rat).SetString(limit)
+		return value, nil
+	}
+
+	value, ok := new(big.Rat).SetString(bound)
+	if !ok {
+		return nil, errors.New("invalid bound " + bound)
+	}
+	return value, nil
+}
+
+// Add the nodes of the augments of all modules to the nodes they augment
+// An augment can be of nodes another augment adds, so they are tried until no more can be applied
+func (schema *Schema) applyAugments(moduleStatements []*statement) error {
+	type augment struct {
+		stmt *statement
+		mod  *module
+	}
+	var pending []augment
+	for _, stmt := range moduleStatements {
+		mod := schema.modules[stmt.argument]
+		for _, child := range getChildren(&statement{children: mod.body}, "augment") {
+			pending = append(pending, augment{child, mod})
+		}
+	}
+
+	for len(pending) > 0 {
+		var left []augment
+		for _, aug := range pending {
+			target := schema.findAugmentTarget(aug.stmt.argument, aug.mod)
+			if target == nil {
+				left = append(left, aug)
+				continue
+			}
+			nodes, choices, err := schema.buildNodes(aug.stmt.children, aug.mod, aug.mod.namespace)
+			if err != nil {
+				return errors.New("module " + aug.mod.name + ", augment " + aug.stmt.argument + ": " + err.Error())
+			}
+			target.Children = append(target.Children, nodes...)
+			if target.choices == nil {
+				target.choices = map[string]bool{}
+			}
+			for choice := range choices {
+				target.choices[choice] = true
+			}
+		}
+
+		if len(left) == len(pending) {
+			return errors.New("module " + left[0].mod.name + " augments " + left[0].stmt.argument + ", which is not in the loaded modules")
+		}
+		pending = left
+	}
+	return nil
+}
+
+// Find the node an augment path (e.g. /if:interfaces/if:interface) points at, nil if it is not in the schema (yet)
+// Choices and cases are in the path but not in the schema, the nodes of an augment of a case are put in the parent of the choice
+func (schema *Schema) findAugmentTarget(path string, mod *module) *Node {
+	current := &Node{Children: schema.Roots}
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		namespace := mod.namespace
+		name := segment
+		if prefix, localName, ok := strings.Cut(segment, ":"); ok {
+			name = localName
+			if prefix != mod.prefix {
+				targetModule, ok := schema.modules[mod.imports[prefix]]
+				if !ok {
+					return nil
+				}
+				namespace = targetModule.namespace
+			}
+		}
+
+		next := current.getChild(name, namespace)
+		if next == nil && !current.choices[name] {
+			return nil
+		}
+		if next != nil {
+			current = next
+		}
+	}
+	if current.Name == "" {
+		return nil
+	}
+	return current
+}
+
+// Get the child node with a name in a namespace, nil if there is none
+func (node *Node) getChild(name string, namespace string) *Node {
+	for _, child := range node.Children {
+		if child.Name == name && child.Namespace == namespace {
+			return child
+		}
+	}
+	return nil
+}
+
+func getChildren(stmt *statement, keyword string) (children []*statement) {
+	for _, child := range stmt.children {
+		if child.keyword == keyword {
+			children = append(children, child)
+		}
+	}
+	return children
+}
@@ -0,0 +1,458 @@
+package yangSchema
+
+/*
+Check the gNMI updates against the YANG modules before they are sent to the configuration service:
+the path must be in the schema, with valid keys for the lists, and the value must have the type, range, length or enum of the leaf
+*/
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"tsn-service/pkg/RAE/dataStructures/namespaces"
+	"unicode/utf8"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// The schema the updates are checked against, nil until LoadModules is run
+var loadedSchema *Schema
+
+/*
+Load the YANG modules in a directory (*.yang), the updates are checked against them from then on
+Without loaded modules, nothing is checked. The modules are also registered in the namespaces package
+
+input:
+
+	dir: the directory, e.g. configs/yang
+*/
+func LoadModules(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yang"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("no YANG modules in " + dir)
+	}
+
+	var statements [][]*statement
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		fileStatements, err := parseStatements(string(data))
+		if err != nil {
+			return fmt.Errorf("failed parsing %s: %w", filepath.Base(file), err)
+		}
+		statements = append(statements, fileStatements)
+	}
+
+	schema, err := buildSchema(statements)
+	if err != nil {
+		return err
+	}
+	loadedSchema = schema
+
+	for _, mod := range schema.modules {
+		namespaces.Register(namespaces.Module{Name: mod.name, Prefix: mod.prefix, Namespace: mod.namespace, Revision: mod.revision})
+	}
+	return nil
+}
+
+/*
+Check every update, replace and delete of a SetRequest
+
+output:
+
+	err: the problems of all updates that are invalid, nil if all are valid or no modules are loaded
+*/
+func ValidateSetRequest(setRequest *pb.SetRequest) error {
+	var errs []error
+	for _, path := range setRequest.GetDelete() {
+		if _, err := getNode(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, update := range append(setRequest.GetReplace(), setRequest.GetUpdate()...) {
+		if err := ValidateUpdate(update); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+/*
+Check the path and value of one update
+
+output:
+
+	err: why the update is invalid, nil if it is valid or no modules are loaded
+*/
+func ValidateUpdate(update *pb.Update) error {
+	node, err := getNode(update.GetPath())
+	if err != nil || node == nil {
+		return err
+	}
+
+	switch update.GetVal().GetValue().(type) {
+	case *pb.TypedValue_JsonIetfVal, *pb.TypedValue_JsonVal:
+		if node.Kind == "leaf" {
+			return errors.New("Invalid value of " + getPathString(update.GetPath()) + ". A leaf can not be set with JSON")
+		}
+		return nil
+	}
+
+	if node.Kind != "leaf" && node.Kind != "leaf-list" {
+		return errors.New("Invalid path " + getPathString(update.GetPath()) + ". A " + node.Kind + " can only be set with JSON")
+	}
+
+	values := []*pb.TypedValue{update.GetVal()}
+	if leafList, ok := update.GetVal().GetValue().(*pb.TypedValue_LeaflistVal); ok {
+		if node.Kind != "leaf-list" {
+			return errors.New("Invalid value of " + getPathString(update.GetPath()) + ". A leaf can not be set with a leaf-list")
+		}
+		values = leafList.LeaflistVal.GetElement()
+	}
+	for _, value := range values {
+		if err := checkValue(node.Type, value); err != nil {
+			return errors.New("Invalid value of " + getPathString(update.GetPath()) + ". Value: " + getValueString(value) + ". " + err.Error())
+		}
+	}
+	return nil
+}
+
+// Get the keys of the list at the end of a path, nil if no modules are loaded or the path does not end at a list
+func GetListKeys(path *pb.Path) []string {
+	node, err := getNode(path)
+	if err != nil || node == nil || node.Kind != "list" {
+		return nil
+	}
+	return node.Keys
+}
+
+// How a value is written in JSON_IETF, Ref: RFC 7951 6.1-6.3
+const (
+	JsonString  = "string"
+	JsonNumber  = "number"
+	JsonBoolean = "boolean"
+)
+
+/*
+Get how the value of the leaf or leaf-list at the end of a path is written in JSON_IETF: integers of up to 32 bits are numbers,
+booleans are true or false, everything else is a string (64-bit integers and decimal64 included).
+In a union, the type is the one of the first member the value is valid for
+
+output:
+
+	jsonType: JsonString if no modules are loaded or the path is not a leaf in them
+*/
+func GetJsonType(path *pb.Path, value string) (jsonType string) {
+	node, err := getNode(path)
+	if err != nil || node == nil || (node.Kind != "leaf" && node.Kind != "leaf-list") {
+		return JsonString
+	}
+	return getJsonType(node.Type, value)
+}
+
+func getJsonType(t *Type, value string) string {
+	if t == nil {
+		return JsonString
+	}
+
+	switch t.Base {
+	case "union":
+		text := &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: value}}
+		for _, member := range t.Union {
+			if checkValue(member, text) == nil {
+				return getJsonType(member, value)
+			}
+		}
+	case "boolean":
+		return JsonBoolean
+	case "int8", "int16", "int32", "uint8", "uint16", "uint32":
+		return JsonNumber
+	}
+	return JsonString
+}
+
+/*
+Get the gNMI value of the text of a leaf or a leaf-list entry at the end of a path, with the type of the leaf in the YANG modules:
+integers are IntVal or UintVal, booleans BoolVal and everything else a StringVal (decimal64, enumerations and identities included).
+In a union, the type is the one of the first member the value is valid for
+
+output:
+
+	value: a StringVal if no modules are loaded, the path is not a leaf in them or the text is not valid for the type
+*/
+func GetTypedValue(path *pb.Path, value string) *pb.TypedValue {
+	node, err := getNode(path)
+	if err != nil || node == nil || (node.Kind != "leaf" && node.Kind != "leaf-list") {
+		return &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: value}}
+	}
+	return getTypedValue(node.Type, value)
+}
+
+// Get the gNMI value of the texts of a leaf-list at the end of a path, each value is typed as in GetTypedValue
+func GetLeafListTypedValue(path *pb.Path, values []string) *pb.TypedValue {
+	var elements []*pb.TypedValue
+	for _, value := range values {
+		elements = append(elements, GetTypedValue(path, value))
+	}
+	return &pb.TypedValue{Value: &pb.TypedValue_LeaflistVal{LeaflistVal: &pb.ScalarArray{Element: elements}}}
+}
+
+func getTypedValue(t *Type, value string) *pb.TypedValue {
+	text := &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: value}}
+	if t == nil {
+		return text
+	}
+
+	switch t.Base {
+	case "union":
+		for _, member := range t.Union {
+			if checkValue(member, text) == nil {
+				return getTypedValue(member, value)
+			}
+		}
+	case "boolean":
+		if value == "true" || value == "false" {
+			return &pb.TypedValue{Value: &pb.TypedValue_BoolVal{BoolVal: value == "true"}}
+		}
+	case "int8", "int16", "int32", "int64":
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return &pb.TypedValue{Value: &pb.TypedValue_IntVal{IntVal: number}}
+		}
+	case "uint8", "uint16", "uint32", "uint64":
+		if number, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return &pb.TypedValue{Value: &pb.TypedValue_UintVal{UintVal: number}}
+		}
+	}
+	return text
+}
+
+// Get the schema node at the end of a path, with the keys of the lists on the way checked, nil if no modules are loaded
+// As GetParamKeys does, the path can name a table with the keys of its entry, instead of the entry itself
+func getNode(path *pb.Path) (*Node, error) {
+	if loadedSchema == nil {
+		return nil, nil
+	}
+
+	current := &Node{Kind: "container", Children: loadedSchema.Roots}
+	for _, elem := range path.GetElem() {
+		next := findChild(current, elem)
+		if next == nil {
+			return nil, errors.New("Invalid path " + getPathString(path) + ". " + elem.GetName() + " is not in the schema")
+		}
+
+		keys := map[string]string{}
+		for key, value := range elem.GetKey() {
+			if key != "namespace" {
+				keys[key] = value
+			}
+		}
+		if len(keys) > 0 && next.Kind == "container" && len(next.Children) == 1 && next.Children[0].Kind == "list" {
+			next = next.Children[0]
+		}
+
+		if len(keys) > 0 {
+			if next.Kind != "list" {
+				return nil, errors.New("Invalid path " + getPathString(path) + ". " + elem.GetName() + " is not a list and has no keys")
+			}
+			if err := checkKeys(next, keys); err != nil {
+				return nil, errors.New("Invalid path " + getPathString(path) + ". " + err.Error())
+			}
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// Find the child a path element names, in the namespace of the element if it has one
+func findChild(node *Node, elem *pb.PathElem) *Node {
+	namespace, hasNamespace := elem.GetKey()["namespace"]
+	for _, child := range node.Children {
+		if child.Name == elem.GetName() && (!hasNamespace || child.Namespace == namespace) {
+			return child
+		}
+	}
+	return nil
+}
+
+// Check that the keys are keys of the list, and that their values fit the key leaves
+func checkKeys(list *Node, keys map[string]string) error {
+	for key, value := range keys {
+		var keyLeaf *Node
+		for _, child := range list.Children {
+			if child.Name == key && strings.Contains(" "+strings.Join(list.Keys, " ")+" ", " "+key+" ") {
+				keyLeaf = child
+			}
+		}
+		if keyLeaf == nil {
+			return errors.New(key + " is not a key of " + list.Name + " (keys: " + strings.Join(list.Keys, ", ") + ")")
+		}
+		if err := checkValue(keyLeaf.Type, &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: value}}); err != nil {
+			return errors.New("Invalid key " + key + ". Value: " + value + ". " + err.Error())
+		}
+	}
+	return nil
+}
+
+// Check a value against a type, values of other types are accepted as text when they are valid for the type
+func checkValue(t *Type, value *pb.TypedValue) error {
+	if t == nil {
+		return nil
+	}
+
+	switch t.Base {
+	case "union":
+		for _, member := range t.Union {
+			if checkValue(member, value) == nil {
+				return nil
+			}
+		}
+		return errors.New("Not valid for any type of the union")
+
+	case "boolean":
+		switch v := value.GetValue().(type) {
+		case *pb.TypedValue_BoolVal:
+			return nil
+		case *pb.TypedValue_StringVal:
+			if v.StringVal == "true" || v.StringVal == "false" {
+				return nil
+			}
+		}
+		return errors.New("Must be a boolean")
+
+	case "enumeration":
+		text, ok := value.GetValue().(*pb.TypedValue_StringVal)
+		if !ok {
+			return errors.New("Must be one of: " + strings.Join(t.Enums, ", "))
+		}
+		for _, enum := range t.Enums {
+			if text.StringVal == enum {
+				return nil
+			}
+		}
+		return errors.New("Must be one of: " + strings.Join(t.Enums, ", "))
+
+	case "string":
+		text, ok := value.GetValue().(*pb.TypedValue_StringVal)
+		if !ok {
+			return errors.New("Must be a string")
+		}
+		return checkBounds(t.Lengths, big.NewRat(int64(utf8.RuneCountInString(text.StringVal)), 1), "Length")
+
+	case "binary":
+		switch v := value.GetValue().(type) {
+		case *pb.TypedValue_BytesVal:
+			return checkBounds(t.Lengths, big.NewRat(int64(len(v.BytesVal)), 1), "Length")
+		case *pb.TypedValue_StringVal:
+			return nil
+		}
+		return errors.New("Must be bytes")
+
+	case "decimal64", "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+		number, err := getNumber(value, t.Base == "decimal64")
+		if err != nil {
+			return err
+		}
+		if limits, ok := integerLimits[t.Base]; ok {
+			min, _ := new(big.Rat).SetString(limits[0])
+			max, _ := new(big.Rat).SetString(limits[1])
+			if err := checkBounds([]bounds{{min, max}}, number, "Value of "+t.Base); err != nil {
+				return err
+			}
+		}
+		return checkBounds(t.Ranges, number, "Value")
+	}
+
+	// leafref, identityref, instance-identifier, bits and empty are not checked
+	return nil
+}
+
+// Get the number of a value, integers can also be text (as 64-bit integers are in JSON)
+func getNumber(value *pb.TypedValue, decimal bool) (*big.Rat, error) {
+	switch v := value.GetValue().(type) {
+	case *pb.TypedValue_IntVal:
+		return big.NewRat(v.IntVal, 1), nil
+	case *pb.TypedValue_UintVal:
+		return new(big.Rat).SetUint64(v.UintVal), nil
+	case *pb.TypedValue_StringVal:
+		if number, ok := new(big.Rat).SetString(v.StringVal); ok && (decimal || number.IsInt()) {
+			return number, nil
+		}
+	}
+
+	if decimal {
+		switch v := value.GetValue().(type) {
+		case *pb.TypedValue_DoubleVal:
+			return new(big.Rat).SetFloat64(v.DoubleVal), nil
+		case *pb.TypedValue_FloatVal:
+			return new(big.Rat).SetFloat64(float64(v.FloatVal)), nil
+		case *pb.TypedValue_DecimalVal:
+			number := big.NewRat(v.DecimalVal.GetDigits(), 1)
+			return number.Quo(number, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(v.DecimalVal.GetPrecision())), nil))), nil
+		}
+		return nil, errors.New("Must be a decimal number")
+	}
+	return nil, errors.New("Must be an integer")
+}
+
+// Check that a number is in one of the parts of a range or length, all numbers are if there are none
+func checkBounds(parts []bounds, number *big.Rat, what string) error {
+	if len(parts) == 0 {
+		return nil
+	}
+
+	var allowed []string
+	for _, part := range parts {
+		if (part.min == nil || number.Cmp(part.min) >= 0) && (part.max == nil || number.Cmp(part.max) <= 0) {
+			return nil
+		}
+		allowed = append(allowed, getRatString(part.min)+".."+getRatString(part.max))
+	}
+	return errors.New(what + " " + getRatString(number) + " is not in the range [" + strings.Join(allowed, " | ") + "]")
+}
+
+func getRatString(number *big.Rat) string {
+	if number == nil {
+		return ""
+	}
+	if number.IsInt() {
+		return number.Num().String()
+	}
+	return strings.TrimRight(number.FloatString(18), "0")
+}
+
+// Get the path as text, e.g. /interfaces/interface[name=sw0p1]/bridge-port
+func getPathString(path *pb.Path) string {
+	var text string
+	for _, elem := range path.GetElem() {
+		text += "/" + elem.GetName()
+		for key, value := range elem.GetKey() {
+			if key != "namespace" {
+				text += "[" + key + "=" + value + "]"
+			}
+		}
+	}
+	return text
+}
+
+func getValueString(value *pb.TypedValue) string {
+	switch v := value.GetValue().(type) {
+	case *pb.TypedValue_StringVal:
+		return v.StringVal
+	case *pb.TypedValue_IntVal:
+		return fmt.Sprint(v.IntVal)
+	case *pb.TypedValue_UintVal:
+		return fmt.Sprint(v.UintVal)
+	case *pb.TypedValue_BoolVal:
+		return fmt.Sprint(v.BoolVal)
+	}
+	return value.String()
+}
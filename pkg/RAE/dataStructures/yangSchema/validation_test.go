@@ -0,0 +1,104 @@
+package yangSchema
+
+import (
+	"fmt"
+	"testing"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const testModule = `
+module test-types {
+  namespace "urn:test:types";
+  prefix tt;
+
+  typedef priority {
+    type uint8 {
+      range "0..7";
+    }
+  }
+
+  container settings {
+    leaf count { type uint32; }
+    leaf offset { type int16; }
+    leaf enabled { type boolean; }
+    leaf name { type string; }
+    leaf speed { type decimal64 { fraction-digits 2; } }
+    leaf mode {
+      type union {
+        type priority;
+        type enumeration { enum auto; }
+      }
+    }
+    leaf-list ports { type uint16; }
+  }
+}
+`
+
+// Load the test module as the schema, until the test ends
+func loadTestSchema(t *testing.T) {
+	t.Helper()
+
+	statements, err := parseStatements(testModule)
+	if err != nil {
+		t.Fatalf("parseStatements() error: %v", err)
+	}
+	schema, err := buildSchema([][]*statement{statements})
+	if err != nil {
+		t.Fatalf("buildSchema() error: %v", err)
+	}
+	loadedSchema = schema
+	t.Cleanup(func() { loadedSchema = nil })
+}
+
+func getTestPath(names ...string) *pb.Path {
+	path := &pb.Path{}
+	for _, name := range names {
+		path.Elem = append(path.Elem, &pb.PathElem{Name: name})
+	}
+	return path
+}
+
+func TestGetTypedValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		leaf  string
+		value string
+		want  *pb.TypedValue
+	}{
+		{name: "uint32", leaf: "count", value: "1", want: &pb.TypedValue{Value: &pb.TypedValue_UintVal{UintVal: 1}}},
+		{name: "int16", leaf: "offset", value: "-3", want: &pb.TypedValue{Value: &pb.TypedValue_IntVal{IntVal: -3}}},
+		{name: "boolean", leaf: "enabled", value: "true", want: &pb.TypedValue{Value: &pb.TypedValue_BoolVal{BoolVal: true}}},
+		{name: "string that looks like a number", leaf: "name", value: "1", want: &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: "1"}}},
+		{name: "string that looks like a boolean", leaf: "name", value: "true", want: &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: "true"}}},
+		{name: "decimal64", leaf: "speed", value: "2.50", want: &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: "2.50"}}},
+		{name: "union member typedef", leaf: "mode", value: "5", want: &pb.TypedValue{Value: &pb.TypedValue_UintVal{UintVal: 5}}},
+		{name: "union member enumeration", leaf: "mode", value: "auto", want: &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: "auto"}}},
+		{name: "not valid for the type", leaf: "count", value: "many", want: &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: "many"}}},
+		{name: "not in the schema", leaf: "other", value: "1", want: &pb.TypedValue{Value: &pb.TypedValue_StringVal{StringVal: "1"}}},
+	}
+
+	loadTestSchema(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetTypedValue(getTestPath("settings", tt.leaf), tt.value)
+			if fmt.Sprint(got.GetValue()) != fmt.Sprint(tt.want.GetValue()) {
+				t.Errorf("GetTypedValue(%s, %q) = %v, want %v", tt.leaf, tt.value, got.GetValue(), tt.want.GetValue())
+			}
+		})
+	}
+
+	leafList := GetLeafListTypedValue(getTestPath("settings", "ports"), []string{"2", "1"})
+	if elements := leafList.GetLeaflistVal().GetElement(); len(elements) != 2 || elements[0].GetUintVal() != 2 || elements[1].GetUintVal() != 1 {
+		t.Errorf("GetLeafListTypedValue(ports, [2 1]) = %v, want the uint values 2 and 1", leafList)
+	}
+}
+
+// Without loaded modules nothing is known about the types, every value is a string
+func TestGetTypedValueWithoutSchema(t *testing.T) {
+	for _, value := range []string{"1", "-3", "true", "auto"} {
+		if got := GetTypedValue(getTestPath("settings", "count"), value); got.GetStringVal() != value {
+			t.Errorf("GetTypedValue(count, %q) without schema = %v, want the string %q", value, got.GetValue(), value)
+		}
+	}
+}
@@ -112,10 +112,10 @@ func invalidPseudoRootId(pseudoRootId []byte) error {
 // Key parameters: componentId, port, deviceIp
 // The root of the config tree: root
 func setMstpCistPortAdminPathCost(root *st.SchemaTree, pathCost int, componentId uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", "ieee8021MstpCistPortComponentId", fmt.Sprint(componentId), "ieee8021MstpCistPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", map[string]string{"ieee8021MstpCistPortComponentId": fmt.Sprint(componentId), "ieee8021MstpCistPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistPortAdminPathCost")
-	treeLvl3.Value = fmt.Sprint(pathCost)
+	treeLvl3.SetInt64(int64(pathCost))
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbIntTypeVal(pathCost))
 	return treeLvl3, update
 }
@@ -125,10 +125,10 @@ func setMstpCistPortAdminPathCost(root *st.SchemaTree, pathCost int, componentId
 // Key parameters: componentId, port, deviceIp
 // The root of the config tree: root
 func setMstpCistPortAdminEdgePort(root *st.SchemaTree, edgePort bool, componentId uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", "ieee8021MstpCistPortComponentId", fmt.Sprint(componentId), "ieee8021MstpCistPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", map[string]string{"ieee8021MstpCistPortComponentId": fmt.Sprint(componentId), "ieee8021MstpCistPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistPortAdminEdgePort")
-	treeLvl3.Value = fmt.Sprint(edgePort)
+	treeLvl3.SetBool(edgePort)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbBoolTypeVal(edgePort))
 	return treeLvl3, update
 }
@@ -138,10 +138,10 @@ func setMstpCistPortAdminEdgePort(root *st.SchemaTree, edgePort bool, componentI
 // Key parameters: componentId, port, deviceIp
 // The root of the config tree: root
 func setMstpCistPortMacEnabled(root *st.SchemaTree, macEnabled bool, componentId uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", "ieee8021MstpCistPortComponentId", fmt.Sprint(componentId), "ieee8021MstpCistPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", map[string]string{"ieee8021MstpCistPortComponentId": fmt.Sprint(componentId), "ieee8021MstpCistPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistPortMacEnabled")
-	treeLvl3.Value = fmt.Sprint(macEnabled)
+	treeLvl3.SetBool(macEnabled)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbBoolTypeVal(macEnabled))
 	return treeLvl3, update
 }
@@ -151,10 +151,10 @@ func setMstpCistPortMacEnabled(root *st.SchemaTree, macEnabled bool, componentId
 // Key parameters: componentId, port, deviceIp
 // The root of the config tree: root
 func setMstpCistPortRestrictedRole(root *st.SchemaTree, restrictedRole bool, componentId uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", "ieee8021MstpCistPortComponentId", fmt.Sprint(componentId), "ieee8021MstpCistPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", map[string]string{"ieee8021MstpCistPortComponentId": fmt.Sprint(componentId), "ieee8021MstpCistPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistPortRestrictedRole")
-	treeLvl3.Value = fmt.Sprint(restrictedRole)
+	treeLvl3.SetBool(restrictedRole)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbBoolTypeVal(restrictedRole))
 	return treeLvl3, update
 }
@@ -164,10 +164,10 @@ func setMstpCistPortRestrictedRole(root *st.SchemaTree, restrictedRole bool, com
 // Key values: componentId, port, deviceIp
 // The root of the config tree: root
 func setMstpCistPortRestrictedTcn(root *st.SchemaTree, restrictedTcn bool, componentId uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", "ieee8021MstpCistPortComponentId", fmt.Sprint(componentId), "ieee8021MstpCistPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", map[string]string{"ieee8021MstpCistPortComponentId": fmt.Sprint(componentId), "ieee8021MstpCistPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistPortRestrictedTcn")
-	treeLvl3.Value = fmt.Sprint(restrictedTcn)
+	treeLvl3.SetBool(restrictedTcn)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbBoolTypeVal(restrictedTcn))
 	return treeLvl3, update
 }
@@ -177,10 +177,10 @@ func setMstpCistPortRestrictedTcn(root *st.SchemaTree, restrictedTcn bool, compo
 // Key parameters: componentId, port, deviceIp
 // The root of the config tree: root
 func setMstpCistPortProtocolMigration(root *st.SchemaTree, protocolMigration bool, componentId uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", "ieee8021MstpCistPortComponentId", fmt.Sprint(componentId), "ieee8021MstpCistPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", map[string]string{"ieee8021MstpCistPortComponentId": fmt.Sprint(componentId), "ieee8021MstpCistPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistPortProtocolMigration")
-	treeLvl3.Value = fmt.Sprint(protocolMigration)
+	treeLvl3.SetBool(protocolMigration)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbBoolTypeVal(protocolMigration))
 	return treeLvl3, update
 }
@@ -190,10 +190,10 @@ func setMstpCistPortProtocolMigration(root *st.SchemaTree, protocolMigration boo
 // Key parameters: componentId, port, deviceIp
 // The root of the config tree: root
 func setMstpCistPortEnableBPDURx(root *st.SchemaTree, bpduRx bool, componentId uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", "ieee8021MstpCistPortComponentId", fmt.Sprint(componentId), "ieee8021MstpCistPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", map[string]string{"ieee8021MstpCistPortComponentId": fmt.Sprint(componentId), "ieee8021MstpCistPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistPortEnableBPDURx")
-	treeLvl3.Value = fmt.Sprint(bpduRx)
+	treeLvl3.SetBool(bpduRx)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbBoolTypeVal(bpduRx))
 	return treeLvl3, update
 }
@@ -203,10 +203,10 @@ func setMstpCistPortEnableBPDURx(root *st.SchemaTree, bpduRx bool, componentId u
 // Key parameters: componentId, port, deviceIp
 // The root of the config tree: root
 func setMstpCistPortEnableBPDUTx(root *st.SchemaTree, bpduTx bool, componentId uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", "ieee8021MstpCistPortComponentId", fmt.Sprint(componentId), "ieee8021MstpCistPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", map[string]string{"ieee8021MstpCistPortComponentId": fmt.Sprint(componentId), "ieee8021MstpCistPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistPortEnableBPDUTx")
-	treeLvl3.Value = fmt.Sprint(bpduTx)
+	treeLvl3.SetBool(bpduTx)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbBoolTypeVal(bpduTx))
 	return treeLvl3, update
 }
@@ -216,10 +216,10 @@ func setMstpCistPortEnableBPDUTx(root *st.SchemaTree, bpduTx bool, componentId u
 // Key parameters: componentId, port, deviceIp
 // The root of the config tree: root
 func setMstpCistPortPseudoRootId(root *st.SchemaTree, pseudoRootId []byte, componentId uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", "ieee8021MstpCistPortComponentId", fmt.Sprint(componentId), "ieee8021MstpCistPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", map[string]string{"ieee8021MstpCistPortComponentId": fmt.Sprint(componentId), "ieee8021MstpCistPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistPortPseudoRootId")
-	treeLvl3.Value = fmt.Sprint(pseudoRootId)
+	treeLvl3.SetBytes(pseudoRootId)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbBytesTypeVal(pseudoRootId))
 	return treeLvl3, update
 }
@@ -229,10 +229,10 @@ func setMstpCistPortPseudoRootId(root *st.SchemaTree, pseudoRootId []byte, compo
 // Key parameters: componentId, port, deviceIp
 // The root of the config tree: root
 func setMstpCistPortIsL2Gp(root *st.SchemaTree, isL2Gp bool, componentId uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", "ieee8021MstpCistPortComponentId", fmt.Sprint(componentId), "ieee8021MstpCistPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistPortTable", "ieee8021MstpCistPortEntry", map[string]string{"ieee8021MstpCistPortComponentId": fmt.Sprint(componentId), "ieee8021MstpCistPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistPortIsL2Gp")
-	treeLvl3.Value = fmt.Sprint(isL2Gp)
+	treeLvl3.SetBool(isL2Gp)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbBoolTypeVal(isL2Gp))
 	return treeLvl3, update
 }
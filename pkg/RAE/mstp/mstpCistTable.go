@@ -71,10 +71,10 @@ func inValidMaxHops(maxHops int) error {
 // Parameter to set: maxHops (6 <= maxHops <= 40, default: 20)
 // Key parameters: componentId, deviceIp
 func setMstpCistMaxHops(root *st.SchemaTree, maxHops int, componentId uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam1Key(treeLvl1, pbLvl1, "ieee8021MstpCistTable", "ieee8021MstpCistEntry", "ieee8021MstpCistComponentId", fmt.Sprint(componentId))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpCistTable", "ieee8021MstpCistEntry", map[string]string{"ieee8021MstpCistComponentId": fmt.Sprint(componentId)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpCistMaxHops")
-	treeLvl3.Value = fmt.Sprint(maxHops)
+	treeLvl3.SetInt64(int64(maxHops))
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbIntTypeVal(maxHops))
 	return treeLvl3, update
 }
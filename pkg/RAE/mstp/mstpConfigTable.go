@@ -98,10 +98,10 @@ func invalidRevisionLevel(revisionLevel uint32) error {
 // Parameter to set: formatSelector (1 <= formatSelector <= 200000000, int32, default: 0)
 // Key parameters: componentId (uint32), deviceIp
 func setMstpConfigIdFormatSelector(root *st.SchemaTree, formatSelector int, componentId uint, deviceIp string) *pb.Update {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam1Key(treeLvl1, pbLvl1, "ieee8021MstpConfigIdTable", "ieee8021MstpConfigIdEntry", "ieee8021MstpConfigIdComponentId", fmt.Sprint(componentId))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpConfigIdTable", "ieee8021MstpConfigIdEntry", map[string]string{"ieee8021MstpConfigIdComponentId": fmt.Sprint(componentId)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpConfigIdFormatSelector")
-	treeLvl3.Value = fmt.Sprint(formatSelector)
+	treeLvl3.SetInt64(int64(formatSelector))
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbIntTypeVal(formatSelector))
 	return update
 }
@@ -110,10 +110,10 @@ func setMstpConfigIdFormatSelector(root *st.SchemaTree, formatSelector int, comp
 // Parameter to set: configurationName (Max 32 characters)
 // Key parameters: componentId (uint32), deviceIp
 func setMstpConfigIdConfigurationName(root *st.SchemaTree, configurationName string, componentId uint, deviceIp string) *pb.Update {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam1Key(treeLvl1, pbLvl1, "ieee8021MstpConfigIdTable", "ieee8021MstpConfigIdEntry", "ieee8021MstpConfigIdComponentId", fmt.Sprint(componentId))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpConfigIdTable", "ieee8021MstpConfigIdEntry", map[string]string{"ieee8021MstpConfigIdComponentId": fmt.Sprint(componentId)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpConfigurationName")
-	treeLvl3.Value = fmt.Sprint(configurationName)
+	treeLvl3.SetString(configurationName)
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbStringTypeVal(configurationName))
 	return update
 }
@@ -122,10 +122,10 @@ func setMstpConfigIdConfigurationName(root *st.SchemaTree, configurationName str
 // Parameter to set: revisionLevel (0 <= revisionLevel <= 65535, uint32, default: 0)
 // Key parameters: componentId (uint32), deviceIp
 func setMstpConfigIdRevisionLevel(root *st.SchemaTree, revisionLevel uint, componentId uint, deviceIp string) *pb.Update {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam1Key(treeLvl1, pbLvl1, "ieee8021MstpConfigIdTable", "ieee8021MstpConfigIdEntry", "ieee8021MstpConfigIdComponentId", fmt.Sprint(componentId))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpConfigIdTable", "ieee8021MstpConfigIdEntry", map[string]string{"ieee8021MstpConfigIdComponentId": fmt.Sprint(componentId)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpRevisionLevel")
-	treeLvl3.Value = fmt.Sprint(revisionLevel)
+	treeLvl3.SetUint64(uint64(revisionLevel))
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbUintTypeVal(revisionLevel))
 	return update
 }
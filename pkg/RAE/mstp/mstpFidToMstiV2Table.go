@@ -58,10 +58,10 @@ func validFid(fid uint) error {
 // Paramter to set: fid (uint32, 0 <= fid <= 409)
 // Key parameters: fid, componentId, deviceIpey
 func setFidValueForFidToMstiEntry(root *st.SchemaTree, fid uint, componentId string, deviceIp string) *pb.Update {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:pras:xml:ns:yang:smiv2:ieee8021mstp")
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpFidToMstiV2Table", "ieee8021MstpFidToMstiV2Entry", "ieee8021MstpFidToMstiV2ComponentId", componentId, "ieee8021MstpFidToMstV2Fid", fmt.Sprint(fid))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpFidToMstiV2Table", "ieee8021MstpFidToMstiV2Entry", map[string]string{"ieee8021MstpFidToMstiV2ComponentId": componentId, "ieee8021MstpFidToMstV2Fid": fmt.Sprint(fid)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpFidToMstV2Fid")
-	treeLvl3.Value = fmt.Sprint(fid)
+	treeLvl3.SetUint64(uint64(fid))
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbUintTypeVal(fid))
 	return update
 }
@@ -77,10 +77,10 @@ func invalidMstpPortPathCost(pathCost int32) error {
 // Parameter to set: portPriority (0 <= portPriority <= 15, int32)
 // Key parameters: componentId (uint32), mstid (uint32), port (uint32), deviceIp
 func setMstpPortPriority(root *st.SchemaTree, portPriority int, componentId uint, mstid uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam3Keys(treeLvl1, pbLvl1, "ieee8021MstpPortTable", "ieee8021MstpPortEntry", "ieee8021MstpPortComponentId", fmt.Sprint(componentId), "ieee8021MstpPortMstId", fmt.Sprint(mstid), "ieee8021MstpPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpPortTable", "ieee8021MstpPortEntry", map[string]string{"ieee8021MstpPortComponentId": fmt.Sprint(componentId), "ieee8021MstpPortMstId": fmt.Sprint(mstid), "ieee8021MstpPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpPortPriority")
-	treeLvl3.Value = fmt.Sprint(portPriority)
+	treeLvl3.SetInt64(int64(portPriority))
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbIntTypeVal(portPriority))
 	return treeLvl3, update
 }
@@ -89,11 +89,11 @@ func setMstpPortPriority(root *st.SchemaTree, portPriority int, componentId uint
 // Parameter to set: pathCost (1 <= pathCost <= 200000000, int32)
 // Key parameters: componentId (uint32), mstid (uint32), port (uint32), deviceIp
 func setMstpPathCost(root *st.SchemaTree, pathCost int, componentId uint, mstid uint, port uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
-	treeLvl2, pbLvl2 := path.GetParam3Keys(treeLvl1, pbLvl1, "ieee8021MstpPortTable", "ieee8021MstpPortEntry", "ieee8021MstpPortComponentId", fmt.Sprint(componentId), "ieee8021MstpPortMstId", fmt.Sprint(mstid), "ieee8021MstpPortNum", fmt.Sprint(port))
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpPortTable", "ieee8021MstpPortEntry", map[string]string{"ieee8021MstpPortComponentId": fmt.Sprint(componentId), "ieee8021MstpPortMstId": fmt.Sprint(mstid), "ieee8021MstpPortNum": fmt.Sprint(port)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpPortPathCost")
 
-	treeLvl3.Value = fmt.Sprint(pathCost)
+	treeLvl3.SetInt64(int64(pathCost))
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbIntTypeVal(pathCost))
 	return treeLvl3, update
 }
@@ -60,11 +60,11 @@ func invalidMstpTableBridgePriority(bridgePriority int32) error {
 // Parameter to set: bridgePriority (0 <= bridgePriortiy <= 15)
 // Key parameters: componentId, port, deviceIp
 func setMstpBridgePriority(root *st.SchemaTree, bridgePriority int32, msti uint, componentId uint, deviceIp string) (*st.SchemaTree, *pb.Update) {
-	treeLvl1, pbLvl1 := path.GetParamNamespace(root, nil, "ieee8021-mstp", "urn:ietf:params:xml:ns:yang:smiv2:ieee8021-mstp")
+	treeLvl1, pbLvl1 := path.GetParam0Keys(root, nil, "ieee8021-mstp:ieee8021-mstp")
 
-	treeLvl2, pbLvl2 := path.GetParam2Keys(treeLvl1, pbLvl1, "ieee8021MstpTable", "ieee8021MstpEntry", "ieee8021MstpComponentId", fmt.Sprint(componentId), "ieee8021MstpId", fmt.Sprint(msti))
+	treeLvl2, pbLvl2 := path.GetParamKeys(treeLvl1, pbLvl1, "ieee8021MstpTable", "ieee8021MstpEntry", map[string]string{"ieee8021MstpComponentId": fmt.Sprint(componentId), "ieee8021MstpId": fmt.Sprint(msti)})
 	treeLvl3, pbLvl3 := path.GetParam0Keys(treeLvl2, pbLvl2, "ieee8021MstpBridgePriority")
-	treeLvl3.Value = fmt.Sprint(bridgePriority)
+	treeLvl3.SetInt64(int64(bridgePriority))
 	update := pbMethods.GetUpdate(deviceIp, pbLvl3, pbMethods.GetPbIntTypeVal(int(bridgePriority)))
 	return treeLvl3, update
 }
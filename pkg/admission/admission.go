@@ -0,0 +1,425 @@
+package admission
+
+/*
+Admission control of stream requests against the streams that are already admitted.
+The controller keeps the admitted streams and what they use of every egress port on their paths:
+
+	bandwidth: the reserved bandwidth of all streams must stay below a share of the port speed
+	gate time: the streams of a traffic class must be sent within the window of the traffic class in the schedule of the port
+	latency: the worst-case latency of the new stream, and of the admitted streams it delays, must stay within their MaxLatency
+
+A new request is checked against the current state only, and is either admitted without changing the admitted streams,
+or rejected with the 802.1Qcc failure code and the port that can not take it.
+
+Ref:
+	IEEE 802.1Qcc-2018 46.2.5 (status group)
+	IEEE 802.1Qcc-2018 Table 46-1 (failure codes)
+*/
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	pe "tsn-service/pkg/PE"
+	cbs "tsn-service/pkg/RAE/CBS"
+	"tsn-service/pkg/analysis"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Failure codes, Ref: IEEE 802.1Qcc-2018 Table 46-1
+const (
+	FailureInsufficientBandwidth             = 1
+	FailureInsufficientTrafficClassBandwidth = 3
+	FailureStreamIdInUse                     = 4
+	FailureMaxFrameSizeTooLarge              = 14
+	FailureMaxLatencyExceeded                = analysis.FailureMaxLatencyExceeded
+)
+
+// Limits of the ports
+type Limits struct {
+	MaxReservedPercent uint // share of the port speed all streams may reserve together
+}
+
+// Limits used when nothing else is configured, the same share as for the credit-based shaper
+var DefaultLimits = Limits{MaxReservedPercent: cbs.DefaultLimits.MaxReservedPercent}
+
+// A stream request that can not be admitted, and the port that can not take it
+type Rejection struct {
+	FailureCode uint32
+	Node        string // empty if the rejection is not caused by a port
+	Port        string
+	Reason      string
+}
+
+func (r *Rejection) Error() string {
+	return r.Reason
+}
+
+// What an admitted stream uses of a port
+type portUsage struct {
+	key          string // node.port
+	trafficClass string // empty if the port has no schedule
+	bandwidth    uint64 // bits/sec
+	gateTime     uint64 // ns per gating cycle
+}
+
+// A stream that is admitted
+type admittedStream struct {
+	id     string
+	stream analysis.Stream
+	usages []portUsage
+}
+
+// Keeps the admitted streams and what they use of the ports
+type Controller struct {
+	mutex     sync.Mutex
+	topo      *topology.Topology
+	gclConfig *schedule.GclConfiguration
+	limits    Limits
+	streams   map[string]*admittedStream // by stream id
+	order     []string                   // the stream ids in the order they were admitted
+	bandwidth map[string]uint64          // bits/sec, by node.port
+	gateTime  map[string]uint64          // ns per gating cycle, by node.port/traffic class
+}
+
+/*
+Create a controller without admitted streams
+
+input:
+
+	topo: the topology the paths of the streams are computed in
+	gclConfig: the schedule of each port, ports that are not in it send without gating (may be nil)
+	limits: how much of the ports may be reserved
+*/
+func NewController(topo *topology.Topology, gclConfig *schedule.GclConfiguration, limits Limits) (*Controller, error) {
+	if limits.MaxReservedPercent == 0 || limits.MaxReservedPercent > 100 {
+		return nil, errors.New("Invalid reserved share of the ports. Value: " + fmt.Sprint(limits.MaxReservedPercent) + "%. Range is [1-100]")
+	}
+
+	return &Controller{
+		topo:      topo,
+		gclConfig: gclConfig,
+		limits:    limits,
+		streams:   map[string]*admittedStream{},
+		bandwidth: map[string]uint64{},
+		gateTime:  map[string]uint64{},
+	}, nil
+}
+
+/*
+Get a copy of the controller with the same admitted streams, what is changed in the copy does not change the controller
+A caller that must store the configuration of the streams first can admit into a copy, and keep the copy once it is stored
+*/
+func (c *Controller) Clone() *Controller {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return &Controller{
+		topo:      c.topo,
+		gclConfig: c.gclConfig,
+		limits:    c.limits,
+		streams:   maps.Clone(c.streams),
+		order:     append([]string{}, c.order...),
+		bandwidth: maps.Clone(c.bandwidth),
+		gateTime:  maps.Clone(c.gateTime),
+	}
+}
+
+/*
+Change the topology and the schedules the streams are admitted in
+The admitted streams are checked again, in the order they were admitted, a stream that does not fit any more is withdrawn.
+If a stream can not be checked, the controller keeps the old topology and schedules.
+
+output:
+
+	dropped: the requests of the streams that were withdrawn
+*/
+func (c *Controller) SetTopology(topo *topology.Topology, gclConfig *schedule.GclConfiguration) (dropped []*configuration.Request, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	oldTopo, oldGclConfig, oldStreams, oldOrder := c.topo, c.gclConfig, c.streams, c.order
+	oldBandwidth, oldGateTime := c.bandwidth, c.gateTime
+	c.topo, c.gclConfig = topo, gclConfig
+	c.streams, c.order = map[string]*admittedStream{}, nil
+	c.bandwidth, c.gateTime = map[string]uint64{}, map[string]uint64{}
+
+	for _, id := range oldOrder {
+		_, stream, err := c.check(oldStreams[id].stream.Request)
+
+		var rejection *Rejection
+		switch {
+		case err == nil:
+			c.insert(stream, len(c.order))
+		case errors.As(err, &rejection):
+			dropped = append(dropped, oldStreams[id].stream.Request)
+		default:
+			c.topo, c.gclConfig, c.streams, c.order = oldTopo, oldGclConfig, oldStreams, oldOrder
+			c.bandwidth, c.gateTime = oldBandwidth, oldGateTime
+			return nil, err
+		}
+	}
+	return dropped, nil
+}
+
+/*
+Check a stream request against the admitted streams, and admit it if it fits
+
+output:
+
+	latency: the worst-case latency of the stream to each of its listeners
+	err: a *Rejection with the failure code if the stream does not fit, other errors if it could not be checked
+*/
+func (c *Controller) Admit(req *configuration.Request) (*analysis.StreamLatency, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	latency, stream, err := c.check(req)
+	if err != nil {
+		return nil, err
+	}
+	c.insert(stream, len(c.order))
+	return latency, nil
+}
+
+/*
+Withdraw an admitted stream, the bandwidth and gate time it used at its ports are free for other streams
+
+output:
+
+	req: the request of the stream
+	ports: the ports the stream used (node.port), their configuration can be recalculated
+*/
+func (c *Controller) Remove(strId *configuration.StreamId) (req *configuration.Request, ports []string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stream, _, err := c.release(GetStreamKey(strId))
+	if err != nil {
+		return nil, nil, err
+	}
+	return stream.stream.Request, getUsageKeys(stream.usages), nil
+}
+
+/*
+Change the traffic specification of an admitted stream
+The stream is checked again with the new traffic specification against the other admitted streams,
+and keeps its old traffic specification if it is rejected.
+
+output:
+
+	latency: the worst-case latency of the stream to each of its listeners, with the new traffic specification
+	ports: the ports the stream used before or uses now (node.port), their configuration can be recalculated
+	err: a *Rejection with the failure code if the new traffic specification does not fit
+*/
+func (c *Controller) Modify(strId *configuration.StreamId, trafficSpec *configuration.TrafficSpecification) (
+	latency *analysis.StreamLatency, ports []string, err error) {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id := GetStreamKey(strId)
+	old, index, err := c.release(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := proto.Clone(old.stream.Request).(*configuration.Request)
+	req.Talker.TrafficSpecification = trafficSpec
+
+	// The stream keeps its place in the order it was admitted, with the old traffic specification if the new one does not fit
+	latency, stream, err := c.check(req)
+	if err != nil {
+		c.insert(old, index)
+		return nil, nil, err
+	}
+	c.insert(stream, index)
+
+	return latency, getUsageKeys(append(old.usages, stream.usages...)), nil
+}
+
+/*
+Admit the stream requests one by one, in order, and build the response
+The accumulated latency is filled in for the admitted streams, the rejected streams are failed with the failure code,
+and the port that could not take the stream is in FailedInterfaces (as node.port)
+*/
+func (c *Controller) AdmitRequests(reqs []*configuration.Request) (*configuration.ConfigResponse, error) {
+	resp := &configuration.ConfigResponse{}
+	for _, req := range reqs {
+		latency, err := c.Admit(req)
+
+		var rejection *Rejection
+		switch {
+		case err == nil:
+			analysis.UpdateResponse(resp, []analysis.StreamLatency{*latency})
+		case errors.As(err, &rejection):
+			resp.Responses = append(resp.Responses, getRejectedResponse(req, rejection))
+		default:
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// Get the admitted stream requests, in the order they were admitted
+func (c *Controller) GetAdmittedRequests() (reqs []*configuration.Request) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, id := range c.order {
+		reqs = append(reqs, c.streams[id].stream.Request)
+	}
+	return reqs
+}
+
+// Get the bandwidth (bits/sec) the admitted streams reserve at a port
+func (c *Controller) GetReservedBandwidth(node string, port string) uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.bandwidth[getPortKey(node, port)]
+}
+
+// Check a stream request against the admitted streams, without admitting it. The caller holds the lock
+func (c *Controller) check(req *configuration.Request) (*analysis.StreamLatency, *admittedStream, error) {
+	talker := req.GetTalker()
+	if talker == nil || talker.TrafficSpecification == nil {
+		return nil, nil, errors.New("the request has no talker with a traffic specification")
+	}
+
+	id := GetStreamKey(talker.GetStrId())
+	if _, ok := c.streams[id]; ok {
+		return nil, nil, &Rejection{FailureCode: FailureStreamIdInUse, Reason: "stream " + id + " is already admitted"}
+	}
+
+	paths, err := pe.GetStreamPaths(c.topo, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	redundantPaths, err := pe.GetStreamDisjointPaths(c.topo, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	stream := analysis.Stream{Request: req, Paths: paths, RedundantPaths: redundantPaths}
+
+	usages, err := c.getPortUsages(stream)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = c.checkPortUsages(talker, usages); err != nil {
+		return nil, nil, err
+	}
+
+	latency, err := c.checkLatency(stream)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return latency, &admittedStream{id: id, stream: stream, usages: usages}, nil
+}
+
+// Take an admitted stream out of the state, and give back its place in the order
+func (c *Controller) release(id string) (*admittedStream, int, error) {
+	stream, ok := c.streams[id]
+	if !ok {
+		return nil, 0, errors.New("stream " + id + " is not admitted")
+	}
+
+	for _, usage := range stream.usages {
+		c.bandwidth[usage.key] -= usage.bandwidth
+		if usage.trafficClass != "" {
+			c.gateTime[usage.key+"/"+usage.trafficClass] -= usage.gateTime
+		}
+	}
+
+	index := 0
+	for i, admitted := range c.order {
+		if admitted == id {
+			index = i
+			break
+		}
+	}
+	c.order = append(c.order[:index], c.order[index+1:]...)
+	delete(c.streams, id)
+
+	return stream, index, nil
+}
+
+// Put a stream into the state at a place in the order
+func (c *Controller) insert(stream *admittedStream, index int) {
+	c.streams[stream.id] = stream
+	c.order = append(c.order[:index], append([]string{stream.id}, c.order[index:]...)...)
+	for _, usage := range stream.usages {
+		c.bandwidth[usage.key] += usage.bandwidth
+		if usage.trafficClass != "" {
+			c.gateTime[usage.key+"/"+usage.trafficClass] += usage.gateTime
+		}
+	}
+}
+
+// Check that the ports can take what the stream uses besides the admitted streams
+func (c *Controller) checkPortUsages(talker *configuration.TalkerGroup, usages []portUsage) error {
+	for _, usage := range usages {
+		node, port := c.getPort(usage.key)
+		capabilities := port.GetCapabilities()
+
+		if mtu := capabilities.GetMaximumTransmissionUnit(); mtu > 0 && talker.TrafficSpecification.MaxFrameSize > uint32(mtu) {
+			return &Rejection{FailureCode: FailureMaxFrameSizeTooLarge, Node: node.Name, Port: port.Name,
+				Reason: "MaxFrameSize " + fmt.Sprint(talker.TrafficSpecification.MaxFrameSize) + " is larger than the MTU (" +
+					fmt.Sprint(mtu) + ") of port " + usage.key}
+		}
+
+		limit := uint64(capabilities.GetPortSpeed()) * 1000000 / 100 * uint64(c.limits.MaxReservedPercent)
+		if reserved := c.bandwidth[usage.key] + usage.bandwidth; reserved > limit {
+			return &Rejection{FailureCode: FailureInsufficientBandwidth, Node: node.Name, Port: port.Name,
+				Reason: "port " + usage.key + " is over-subscribed. Reserved: " + fmt.Sprint(reserved) + " bits/sec, limit: " +
+					fmt.Sprint(limit) + " bits/sec (" + fmt.Sprint(c.limits.MaxReservedPercent) + "% of the port speed)"}
+		}
+
+		if usage.trafficClass == "" {
+			continue
+		}
+		_, window := analysis.GetWindow(c.getSchedule(usage.key), usage.trafficClass)
+		if gateTime := c.gateTime[usage.key+"/"+usage.trafficClass] + usage.gateTime; gateTime > window {
+			return &Rejection{FailureCode: FailureInsufficientTrafficClassBandwidth, Node: node.Name, Port: port.Name,
+				Reason: "the window of traffic class " + usage.trafficClass + " at port " + usage.key + " is over-subscribed. Needed: " +
+					fmt.Sprint(gateTime) + " ns per cycle, window: " + fmt.Sprint(window) + " ns"}
+		}
+	}
+	return nil
+}
+
+// Check that the new stream and the admitted streams it passes through the same ports as meet their MaxLatency
+func (c *Controller) checkLatency(stream analysis.Stream) (*analysis.StreamLatency, error) {
+	streams := []analysis.Stream{stream}
+	for _, id := range c.order {
+		streams = append(streams, c.streams[id].stream)
+	}
+
+	latencies, err := analysis.AnalyzeStreams(c.topo, streams, c.gclConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, latency := range latencies {
+		for _, listener := range latency.Listeners {
+			if listener.MeetsRequirement() {
+				continue
+			}
+			reason := "the latency to listener " + fmt.Sprint(listener.Listener) + " is " + fmt.Sprint(listener.WorstCase) +
+				" ns, more than its MaxLatency of " + fmt.Sprint(listener.MaxLatency) + " ns"
+			if i > 0 {
+				reason = "admitted stream " + GetStreamKey(latency.StrId) + " would no longer meet its MaxLatency, " + reason
+			}
+			node, port := getSlowestHop(listener)
+			return nil, &Rejection{FailureCode: FailureMaxLatencyExceeded, Node: node, Port: port, Reason: reason}
+		}
+	}
+
+	return &latencies[0], nil
+}
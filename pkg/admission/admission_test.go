@@ -0,0 +1,190 @@
+package admission
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+)
+
+/*
+A talker and a listener on one bridge, all ports at 100 Mbps with an MTU of 1500 octets:
+
+	t.p0 -(100 ns)- sw0.p1  sw0.p2 -(100 ns)- l.p0
+
+The bridge takes 2000 ns to process a frame
+*/
+func getTestTopology() *topology.Topology {
+	capabilities := &topology.InterfaceCapabilities{PortSpeed: 100, MaximumTransmissionUnit: 1500}
+	return &topology.Topology{
+		Nodes: []*topology.Node{
+			{Name: "t", Type: topology.NodeRole_END_STATION, Ports: []*topology.Port{{Name: "p0", MacAddress: "00:00:00:00:00:01", Capabilities: capabilities}}},
+			{Name: "sw0", Type: topology.NodeRole_BRIDGE, ManagementInfo: &topology.ManagementInfo{IpAddress: "10.0.0.1"},
+				Properties: &topology.NodeProperties{Bridge: &topology.BridgeProperties{ProcessingDelayNs: 2000}},
+				Ports:      []*topology.Port{{Name: "p1", Capabilities: capabilities}, {Name: "p2", Capabilities: capabilities}}},
+			{Name: "l", Type: topology.NodeRole_END_STATION, Ports: []*topology.Port{{Name: "p0", MacAddress: "00:00:00:00:00:02", Capabilities: capabilities}}},
+		},
+		Links: []*topology.Link{
+			{Id: "l0", SourceNode: "t", SourcePort: "p0", TargetNode: "sw0", TargetPort: "p1", PropagationDelayNs: 100},
+			{Id: "l1", SourceNode: "sw0", SourcePort: "p2", TargetNode: "l", TargetPort: "p0", PropagationDelayNs: 100},
+		},
+	}
+}
+
+/*
+A stream from t to l with priority 7 of frames of a size every ms, the max latency of the listener in ns (0 for none)
+One frame of 1000 octets is 8160000 bits/sec, 81600 ns on the wire, and has a worst-case latency of 288760 ns without schedule
+*/
+func getTestRequest(id string, frames uint32, frameSize uint32, maxLatency uint32) *configuration.Request {
+	getInterfaces := func(mac string) []*configuration.Interface {
+		return []*configuration.Interface{{InterfaceId: &configuration.InterfaceId{MacAddress: mac}}}
+	}
+	return &configuration.Request{
+		Talker: &configuration.TalkerGroup{
+			StrId:                  &configuration.StreamId{MacAddress: "00:00:00:00:00:01", UniqueId: id},
+			EndStationInterfaces:   getInterfaces("00:00:00:00:00:01"),
+			DataFrameSpecification: []*configuration.DataFrameSpecification{{VlanTag: &configuration.IeeeVlanTag{PriorityCodePoint: 7, VlanId: 10}}},
+			TrafficSpecification: &configuration.TrafficSpecification{
+				Interval:             &configuration.Interval{Numerator: 1, Denominator: 1000},
+				MaxFramesPerInterval: frames,
+				MaxFrameSize:         frameSize,
+			},
+		},
+		ListenerList: []*configuration.ListenerGroup{{
+			Index:                0,
+			EndStationInterfaces: getInterfaces("00:00:00:00:00:02"),
+			UserToNetReq:         &configuration.UserToNetworkRequirements{MaxLatency: maxLatency},
+		}},
+	}
+}
+
+// A schedule at sw0.p2 with a cycle of 1 ms and a window of 100000 ns for isochronous, which takes one frame of 1000 octets
+func getTestGclConfiguration() *schedule.GclConfiguration {
+	return &schedule.GclConfiguration{Configs: []*schedule.ConfigMap{{
+		NodePort: "sw0.p2",
+		Sched: &schedule.Schedule{GatingCycle: 1000000, TrafficClasses: []*schedule.TrafficClass{
+			{Name: "isochronous", AssignedPortion: 10},
+			{Name: "best-effort", AssignedPortion: 90},
+		}},
+	}}}
+}
+
+// The first requests are admitted, the last one is checked against them
+func TestAdmit(t *testing.T) {
+	tests := []struct {
+		name        string
+		gclConfig   *schedule.GclConfiguration
+		reqs        []*configuration.Request
+		failureCode uint32 // 0 if the last request is admitted
+		port        string // node.port that can not take the last request
+		reason      string // in the rejection
+	}{
+		{name: "admitted", reqs: []*configuration.Request{getTestRequest("a", 1, 1000, 0), getTestRequest("b", 1, 1000, 0)}},
+		{name: "stream id in use", reqs: []*configuration.Request{getTestRequest("a", 1, 1000, 0), getTestRequest("a", 1, 1000, 0)},
+			failureCode: FailureStreamIdInUse},
+		{name: "frame larger than the MTU", reqs: []*configuration.Request{getTestRequest("a", 1, 2000, 0)},
+			failureCode: FailureMaxFrameSizeTooLarge, port: "t.p0"},
+		// 40800000 bits/sec each, 75000000 bits/sec may be reserved
+		{name: "bandwidth", reqs: []*configuration.Request{getTestRequest("a", 5, 1000, 0), getTestRequest("b", 5, 1000, 0)},
+			failureCode: FailureInsufficientBandwidth, port: "t.p0"},
+		// 81600 ns of gate time each, the window is 100000 ns
+		{name: "window", gclConfig: getTestGclConfiguration(), reqs: []*configuration.Request{getTestRequest("a", 1, 1000, 0), getTestRequest("b", 1, 1000, 0)},
+			failureCode: FailureInsufficientTrafficClassBandwidth, port: "sw0.p2"},
+		{name: "max latency", reqs: []*configuration.Request{getTestRequest("a", 1, 1000, 200000)},
+			failureCode: FailureMaxLatencyExceeded, port: "sw0.p2", reason: "288760 ns"},
+		// The frame of b is sent before the frame of a: 288760 + 81600 ns
+		{name: "max latency of an admitted stream", reqs: []*configuration.Request{getTestRequest("a", 1, 1000, 300000), getTestRequest("b", 1, 1000, 0)},
+			failureCode: FailureMaxLatencyExceeded, port: "sw0.p2", reason: "admitted stream 00:00:00:00:00:01:a would no longer meet its MaxLatency, the latency to listener 0 is 370360 ns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewController(getTestTopology(), tt.gclConfig, DefaultLimits)
+			if err != nil {
+				t.Fatalf("NewController() error: %v", err)
+			}
+			last := len(tt.reqs) - 1
+			for _, req := range tt.reqs[:last] {
+				if _, err := c.Admit(req); err != nil {
+					t.Fatalf("Admit() of stream %s error: %v", req.Talker.StrId.UniqueId, err)
+				}
+			}
+
+			_, err = c.Admit(tt.reqs[last])
+			if tt.failureCode == 0 {
+				if err != nil {
+					t.Errorf("Admit() error: %v", err)
+				}
+				return
+			}
+			var rejection *Rejection
+			if !errors.As(err, &rejection) {
+				t.Fatalf("Admit() = %v, want a rejection with failure code %d", err, tt.failureCode)
+			}
+			if port := getPortKey(rejection.Node, rejection.Port); rejection.FailureCode != tt.failureCode || (tt.port != "" && port != tt.port) {
+				t.Errorf("Admit() rejected with failure code %d at %s, want %d at %s", rejection.FailureCode, port, tt.failureCode, tt.port)
+			}
+			if !strings.Contains(rejection.Reason, tt.reason) {
+				t.Errorf("Admit() rejected with %q, want %q in it", rejection.Reason, tt.reason)
+			}
+			if got := len(c.GetAdmittedRequests()); got != last {
+				t.Errorf("GetAdmittedRequests() = %d streams after the rejection, want %d", got, last)
+			}
+		})
+	}
+}
+
+// A withdrawn stream leaves its bandwidth to the next
+func TestRemove(t *testing.T) {
+	c, err := NewController(getTestTopology(), nil, DefaultLimits)
+	if err != nil {
+		t.Fatalf("NewController() error: %v", err)
+	}
+	if _, err = c.Admit(getTestRequest("a", 5, 1000, 0)); err != nil {
+		t.Fatalf("Admit() error: %v", err)
+	}
+	if got := c.GetReservedBandwidth("sw0", "p2"); got != 40800000 {
+		t.Errorf("GetReservedBandwidth() = %d bits/sec, want 40800000", got)
+	}
+
+	_, ports, err := c.Remove(&configuration.StreamId{MacAddress: "00:00:00:00:00:01", UniqueId: "a"})
+	if err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if strings.Join(ports, " ") != "t.p0 sw0.p2" {
+		t.Errorf("Remove() ports = %v, want [t.p0 sw0.p2]", ports)
+	}
+	if _, err = c.Admit(getTestRequest("b", 5, 1000, 0)); err != nil {
+		t.Errorf("Admit() after Remove() error: %v", err)
+	}
+}
+
+func TestAdmitRequests(t *testing.T) {
+	c, err := NewController(getTestTopology(), getTestGclConfiguration(), DefaultLimits)
+	if err != nil {
+		t.Fatalf("NewController() error: %v", err)
+	}
+
+	resp, err := c.AdmitRequests([]*configuration.Request{getTestRequest("a", 1, 1000, 0), getTestRequest("b", 1, 1000, 0)})
+	if err != nil {
+		t.Fatalf("AdmitRequests() error: %v", err)
+	}
+	if len(resp.Responses) != 2 {
+		t.Fatalf("AdmitRequests() = %d responses, want 2", len(resp.Responses))
+	}
+
+	// The window closed just before the frame of a: 900000 ns besides the 165400 ns of the path
+	admitted := resp.Responses[0].GetStatusGroup()
+	if got := admitted.GetStatusTalkerListener()[0].GetAccumulatedLatency().GetAccumulatedLatency(); got != 1065400 {
+		t.Errorf("AdmitRequests() latency of stream a = %d ns, want 1065400", got)
+	}
+
+	rejected := resp.Responses[1].GetStatusGroup()
+	if rejected.GetStatusInfo().GetFailureCode() != FailureInsufficientTrafficClassBandwidth ||
+		len(rejected.GetFailedInterfaces()) != 1 || rejected.GetFailedInterfaces()[0].GetInterfaceName() != "sw0.p2" {
+		t.Errorf("AdmitRequests() response of stream b = %v, want failure code %d at sw0.p2",
+			rejected, FailureInsufficientTrafficClassBandwidth)
+	}
+}
@@ -0,0 +1,173 @@
+package admission
+
+/*
+Help functions for admission.go
+*/
+
+import (
+	"errors"
+	"fmt"
+	pe "tsn-service/pkg/PE"
+	qos "tsn-service/pkg/QoS"
+	cbs "tsn-service/pkg/RAE/CBS"
+	"tsn-service/pkg/analysis"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+)
+
+// Get the key a stream is admitted by, from the MAC address and unique id of its stream id
+func GetStreamKey(strId *configuration.StreamId) string {
+	return strId.GetMacAddress() + ":" + strId.GetUniqueId()
+}
+
+/*
+Get what a stream uses of every egress port on its paths, the egress port of the talker and the disjoint paths of FRER included
+A port the paths to several listeners share is only counted once. The port of the talker is left out when it is not
+in the topology or has no port speed, as nothing is known about it.
+*/
+func (c *Controller) getPortUsages(stream analysis.Stream) (usages []portUsage, err error) {
+	talker := stream.Request.GetTalker()
+	bandwidth, err := cbs.GetStreamBandwidth(talker.TrafficSpecification)
+	if err != nil {
+		return nil, err
+	}
+	priority := analysis.GetStreamPriority(talker)
+
+	counted := map[string]bool{}
+	addPort := func(node string, port string) error {
+		key := getPortKey(node, port)
+		if counted[key] {
+			return nil
+		}
+		counted[key] = true
+
+		_, topoPort := c.getPort(key)
+		if topoPort == nil || topoPort.GetCapabilities().GetPortSpeed() == 0 {
+			return nil
+		}
+		usage := portUsage{key: key, bandwidth: uint64(bandwidth)}
+
+		if sched := c.getSchedule(key); sched != nil {
+			usage.trafficClass, err = qos.GetTrafficClassOfPriority(sched, priority)
+			if err != nil {
+				return fmt.Errorf("failed mapping the priority of stream %s at port %s: %w", GetStreamKey(talker.GetStrId()), key, err)
+			}
+			cycle, _ := analysis.GetWindow(sched, usage.trafficClass)
+			usage.gateTime = getGateTime(usage.bandwidth, cycle, uint64(topoPort.GetCapabilities().GetPortSpeed()))
+		}
+
+		usages = append(usages, usage)
+		return nil
+	}
+
+	for _, listener := range stream.Request.ListenerList {
+		paths := stream.GetListenerPaths(listener.Index)
+		if len(paths) == 0 || len(paths[0]) == 0 {
+			return nil, errors.New("no path to listener " + fmt.Sprint(listener.Index) + " of stream " + GetStreamKey(talker.GetStrId()))
+		}
+
+		talkerNode, talkerPort, err := c.getTalkerPort(paths[0][0])
+		if err != nil {
+			return nil, err
+		}
+		if err = addPort(talkerNode, talkerPort); err != nil {
+			return nil, err
+		}
+
+		for _, path := range paths {
+			for _, hop := range path {
+				if err = addPort(hop.Node, hop.EgressPort); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return usages, nil
+}
+
+// Get the node and port of the talker, at the other end of the link to the ingress port of the first bridge
+func (c *Controller) getTalkerPort(first pe.Hop) (string, string, error) {
+	for _, link := range c.topo.GetLinks() {
+		if link.TargetNode == first.Node && link.TargetPort == first.IngressPort {
+			return link.SourceNode, link.SourcePort, nil
+		}
+		if link.SourceNode == first.Node && link.SourcePort == first.IngressPort {
+			return link.TargetNode, link.TargetPort, nil
+		}
+	}
+	return "", "", errors.New("no link is connected to port " + getPortKey(first.Node, first.IngressPort))
+}
+
+// Get a node and its port in the topology from node.port, the port is nil if it is not in the topology
+func (c *Controller) getPort(key string) (*topology.Node, *topology.Port) {
+	for _, node := range c.topo.GetNodes() {
+		for _, port := range node.GetPorts() {
+			if getPortKey(node.Name, port.Name) == key {
+				return node, port
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Get the schedule of a port, nil if the port sends without gating
+func (c *Controller) getSchedule(key string) *schedule.Schedule {
+	for _, configMap := range c.gclConfig.GetConfigs() {
+		if configMap.NodePort == key {
+			return configMap.Sched
+		}
+	}
+	return nil
+}
+
+// Get the time (ns) a bandwidth (bits/sec) takes of every gating cycle (ns) at a port speed (Mbps), rounded up
+func getGateTime(bandwidth uint64, cycle uint64, portSpeed uint64) uint64 {
+	bitsPerCycle := (bandwidth*cycle + 1000000000 - 1) / 1000000000
+	return (bitsPerCycle*1000 + portSpeed - 1) / portSpeed
+}
+
+// Get the hop with the longest queuing delay, where the latency is most likely to be won back
+func getSlowestHop(latency analysis.ListenerLatency) (string, string) {
+	var slowest analysis.HopLatency
+	for _, hop := range latency.Hops {
+		if hop.Queuing >= slowest.Queuing {
+			slowest = hop
+		}
+	}
+	return slowest.Node, slowest.EgressPort
+}
+
+// The response of a rejected stream, failed for all its listeners
+func getRejectedResponse(req *configuration.Request, rejection *Rejection) *configuration.Response {
+	statusGroup := &configuration.StatusGroup{
+		StrId: req.GetTalker().GetStrId(),
+		StatusInfo: &configuration.StatusInfo{
+			TalkerStatus:   analysis.TalkerStatusFailed,
+			ListenerStatus: analysis.ListenerStatusFailed,
+			FailureCode:    rejection.FailureCode,
+		},
+	}
+	if rejection.Node != "" {
+		statusGroup.FailedInterfaces = append(statusGroup.FailedInterfaces,
+			&configuration.InterfaceId{InterfaceName: getPortKey(rejection.Node, rejection.Port)})
+	}
+	return &configuration.Response{StatusGroup: statusGroup}
+}
+
+func getPortKey(node string, port string) string {
+	return node + "." + port
+}
+
+// Get the ports of the usages (node.port), each port once
+func getUsageKeys(usages []portUsage) (keys []string) {
+	seen := map[string]bool{}
+	for _, usage := range usages {
+		if !seen[usage.key] {
+			seen[usage.key] = true
+			keys = append(keys, usage.key)
+		}
+	}
+	return keys
+}
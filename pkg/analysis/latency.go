@@ -0,0 +1,323 @@
+package analysis
+
+/*
+Worst-case latency analysis of the scheduled streams.
+For every listener of a stream the latency is added up along its path, from the talker to the listener:
+
+	talker: transmission + propagation
+	bridge: processing + queuing + transmission + propagation (of the link to the next node)
+
+The queuing delay at an egress port is the worst case of the traffic class of the stream in the gate control list of the port:
+the frame arrives just after the window of its traffic class closes, and the frames of all streams of the traffic class
+at the port are sent before it, over as many windows as they need. A port without gate control list sends the traffic class
+without gating, and the frame can be blocked by one frame of another traffic class that is already being sent.
+The guard bands are not counted, they are part of the windows of the other traffic classes.
+
+The best case is the same path without queuing, the jitter is the difference between the worst and the best case.
+
+Ref:
+	IEEE 802.1Qcc-2018 46.2.5.1.1 (AccumulatedLatency)
+	IEEE 802.1Qcc-2018 Table 46-1 (failure codes)
+	IEEE 802.1Q-2018 8.6.8.4 (enhancements for scheduled traffic)
+*/
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	pe "tsn-service/pkg/PE"
+	framepreemption "tsn-service/pkg/RAE/FramePreemption"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+)
+
+// Failure code of a stream whose latency exceeds its MaxLatency, Ref: IEEE 802.1Qcc-2018 Table 46-1
+const FailureMaxLatencyExceeded = 21
+
+// Values of TalkerStatus and ListenerStatus in the response
+const (
+	StatusReady                 = 1
+	TalkerStatusFailed          = 2
+	ListenerStatusPartialFailed = 2
+	ListenerStatusFailed        = 3
+)
+
+// A stream request and the path to each of its listeners
+type Stream struct {
+	Request *configuration.Request
+	Paths   map[uint32]pe.Path // by listener index
+
+	// The disjoint paths to the listeners that request seamless redundancy (FRER), by listener index (see pe.GetStreamDisjointPaths)
+	RedundantPaths map[uint32][]pe.Path
+}
+
+// Get the paths the frames of a stream take to a listener, all disjoint paths when the listener requests seamless redundancy
+func (stream Stream) GetListenerPaths(listener uint32) []pe.Path {
+	if paths, ok := stream.RedundantPaths[listener]; ok {
+		return paths
+	}
+	if path, ok := stream.Paths[listener]; ok {
+		return []pe.Path{path}
+	}
+	return nil
+}
+
+// The worst-case latency of one node on the path (ns)
+type HopLatency struct {
+	Node         string
+	EgressPort   string
+	Processing   uint64
+	Queuing      uint64
+	Transmission uint64
+	Propagation  uint64 // of the link to the next node
+}
+
+// The latency from the talker to one listener (ns)
+type ListenerLatency struct {
+	Listener   uint32 // index of the listener in the request
+	Hops       []HopLatency
+	WorstCase  uint64
+	BestCase   uint64
+	Jitter     uint64
+	MaxLatency uint32 // the requirement of the listener, 0 if it has none
+}
+
+// The latency to every listener of a stream
+type StreamLatency struct {
+	StrId     *configuration.StreamId
+	Listeners []ListenerLatency
+}
+
+// True if the worst-case latency is within the MaxLatency of the listener
+func (latency ListenerLatency) MeetsRequirement() bool {
+	return latency.MaxLatency == 0 || latency.WorstCase <= uint64(latency.MaxLatency)
+}
+
+// The max latency (ns) of a listener, the requirement of the talker is used when the listener has none
+func GetMaxLatency(talker *configuration.TalkerGroup, listener *configuration.ListenerGroup) uint32 {
+	if maxLatency := listener.GetUserToNetReq().GetMaxLatency(); maxLatency != 0 {
+		return maxLatency
+	}
+	return talker.GetUserToNetReq().GetMaxLatency()
+}
+
+/*
+Compute the worst-case latency and jitter of every stream to each of its listeners
+
+input:
+
+	topo: the topology, with the processing delay of the bridges, the propagation delay of the links and the port speeds
+	streams: the stream requests and their paths
+	gclConfig: the schedule of each port, ports that are not in it send without gating (may be nil)
+
+output:
+
+	latencies: the latency of each stream, in the order of streams
+*/
+func AnalyzeStreams(topo *topology.Topology, streams []Stream, gclConfig *schedule.GclConfiguration) (
+	latencies []StreamLatency, err error) {
+
+	a := newAnalyzer(topo, gclConfig)
+
+	// All streams that are sent through a port in the same traffic class delay each other
+	for _, stream := range streams {
+		if err = a.addStream(stream); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, stream := range streams {
+		talker := stream.Request.GetTalker()
+		streamLatency := StreamLatency{StrId: talker.GetStrId()}
+		for _, listener := range stream.Request.ListenerList {
+			paths := stream.GetListenerPaths(listener.Index)
+			if len(paths) == 0 {
+				return nil, errors.New("no path to listener " + fmt.Sprint(listener.Index) + " of stream " + talker.GetStrId().GetUniqueId())
+			}
+
+			// With seamless redundancy any of the paths can fail, so the slowest path bounds the latency
+			var listenerLatency ListenerLatency
+			for i, path := range paths {
+				pathLatency, err := a.getListenerLatency(talker, path)
+				if err != nil {
+					return nil, fmt.Errorf("failed analyzing the latency of stream %s to listener %d: %w",
+						talker.GetStrId().GetUniqueId(), listener.Index, err)
+				}
+				if i == 0 || pathLatency.WorstCase > listenerLatency.WorstCase {
+					listenerLatency = pathLatency
+				}
+			}
+			listenerLatency.Listener = listener.Index
+			listenerLatency.MaxLatency = GetMaxLatency(talker, listener)
+
+			streamLatency.Listeners = append(streamLatency.Listeners, listenerLatency)
+		}
+		latencies = append(latencies, streamLatency)
+	}
+
+	return latencies, nil
+}
+
+/*
+Fill in the accumulated latency of every listener in the response, and mark the streams that exceed the MaxLatency
+of a listener as failed (partial-failed if only some of the listeners are exceeded)
+Streams that are not in the response yet are added as ready
+
+input:
+
+	resp: the response to the configuration request
+	latencies: the result of AnalyzeStreams
+*/
+func UpdateResponse(resp *configuration.ConfigResponse, latencies []StreamLatency) {
+	for _, streamLatency := range latencies {
+		statusGroup := getStatusGroup(resp, streamLatency.StrId)
+
+		var failed int
+		for _, listenerLatency := range streamLatency.Listeners {
+			status := getTalkerListenerStatus(statusGroup, listenerLatency.Listener)
+			status.AccumulatedLatency = &configuration.AccumulatedLatency{
+				AccumulatedLatency: uint32(min(listenerLatency.WorstCase, math.MaxUint32)),
+			}
+			if !listenerLatency.MeetsRequirement() {
+				failed++
+			}
+		}
+
+		if failed == 0 {
+			continue
+		}
+		statusGroup.StatusInfo.FailureCode = FailureMaxLatencyExceeded
+		if failed == len(streamLatency.Listeners) {
+			statusGroup.StatusInfo.TalkerStatus = TalkerStatusFailed
+			statusGroup.StatusInfo.ListenerStatus = ListenerStatusFailed
+		} else {
+			statusGroup.StatusInfo.ListenerStatus = ListenerStatusPartialFailed
+		}
+	}
+}
+
+// The latency from the talker to one listener
+func (a *analyzer) getListenerLatency(talker *configuration.TalkerGroup, path pe.Path) (latency ListenerLatency, err error) {
+	if len(path) == 0 {
+		return latency, errors.New("the path has no bridges")
+	}
+
+	talkerHop, err := a.getTalkerHop(talker, path[0])
+	if err != nil {
+		return latency, err
+	}
+	latency.Hops = append(latency.Hops, talkerHop)
+
+	for _, hop := range path {
+		bridgeHop, err := a.getBridgeHop(talker, hop)
+		if err != nil {
+			return latency, err
+		}
+		latency.Hops = append(latency.Hops, bridgeHop)
+	}
+
+	for _, hop := range latency.Hops {
+		latency.BestCase += hop.Processing + hop.Transmission + hop.Propagation
+		latency.WorstCase += hop.Processing + hop.Queuing + hop.Transmission + hop.Propagation
+	}
+	latency.Jitter = latency.WorstCase - latency.BestCase
+
+	return latency, nil
+}
+
+// The latency from the talker to the first bridge, the talker decides when it sends so there is no queuing
+func (a *analyzer) getTalkerHop(talker *configuration.TalkerGroup, first pe.Hop) (HopLatency, error) {
+	link, err := a.getLink(first.Node, first.IngressPort)
+	if err != nil {
+		return HopLatency{}, err
+	}
+	talkerNode, talkerPort := getOtherEnd(link, first.Node)
+
+	latency := HopLatency{Node: talkerNode, EgressPort: talkerPort, Propagation: uint64(max(link.PropagationDelayNs, 0))}
+
+	// The port speed of the talker is not always known, then only the propagation is counted
+	if port := a.getPort(talkerNode, talkerPort); port != nil && port.GetCapabilities().GetPortSpeed() > 0 {
+		latency.Transmission = getTransmissionTime(getFrameBits(talker.TrafficSpecification), uint64(port.GetCapabilities().GetPortSpeed()))
+	}
+
+	return latency, nil
+}
+
+// The latency of a bridge, from the frame is received until it is received by the next node
+func (a *analyzer) getBridgeHop(talker *configuration.TalkerGroup, hop pe.Hop) (HopLatency, error) {
+	port := a.getPort(hop.Node, hop.EgressPort)
+	if port == nil {
+		return HopLatency{}, errors.New("port " + hop.Node + "." + hop.EgressPort + " is not in the topology")
+	}
+	portSpeed := uint64(port.GetCapabilities().GetPortSpeed())
+	if portSpeed == 0 {
+		return HopLatency{}, errors.New("port " + hop.Node + "." + hop.EgressPort + " has no port speed")
+	}
+
+	link, err := a.getLink(hop.Node, hop.EgressPort)
+	if err != nil {
+		return HopLatency{}, err
+	}
+
+	trafficClass, err := a.getTrafficClass(hop.Node, hop.EgressPort, GetStreamPriority(talker))
+	if err != nil {
+		return HopLatency{}, err
+	}
+
+	transmission := getTransmissionTime(getFrameBits(talker.TrafficSpecification), portSpeed)
+	queuing, err := a.getQueuingDelay(hop.Node, port, trafficClass, transmission)
+	if err != nil {
+		return HopLatency{}, err
+	}
+
+	return HopLatency{
+		Node:         hop.Node,
+		EgressPort:   hop.EgressPort,
+		Processing:   a.getProcessingDelay(hop.Node),
+		Queuing:      queuing,
+		Transmission: transmission,
+		Propagation:  uint64(max(link.PropagationDelayNs, 0)),
+	}, nil
+}
+
+/*
+The worst-case time a frame waits in the queue of its traffic class at an egress port
+
+input:
+
+	node: the bridge
+	port: the egress port
+	trafficClass: the traffic class of the frame at the port
+	transmission: the transmission time of the frame itself, which is not counted as waiting
+*/
+func (a *analyzer) getQueuingDelay(node string, port *topology.Port, trafficClass string, transmission uint64) (uint64, error) {
+	portSpeed := uint64(port.GetCapabilities().GetPortSpeed())
+	key := getPortKey(node, port.Name)
+
+	// The frames of all streams of the traffic class at the port
+	classTime := getTransmissionTime(a.classBits[key+"/"+trafficClass], portSpeed)
+	backlog := classTime - min(transmission, classTime)
+
+	sched := a.schedules[key]
+	if sched == nil {
+		// An express frame only waits for the part of a preemptable frame that can not be preempted
+		preemption := port.GetCapabilities().GetSupportsFramePreemption() &&
+			framepreemption.GetPreemptionStatus(trafficClass) == framepreemption.Express
+		blocking, err := framepreemption.GetGuardBand(uint(portSpeed),
+			uint(max(port.GetCapabilities().GetMaximumTransmissionUnit(), 0)), preemption)
+		if err != nil {
+			return 0, err
+		}
+		return blocking + backlog, nil
+	}
+
+	cycle, window := GetWindow(sched, trafficClass)
+	if window == 0 {
+		return 0, errors.New("traffic class " + trafficClass + " has no window at port " + key)
+	}
+
+	// Wait for the window, and for the next windows while the frames before it do not fit
+	windows := (classTime + window - 1) / window
+	return (cycle - window) + backlog + (max(windows, 1)-1)*(cycle-window), nil
+}
@@ -0,0 +1,146 @@
+package analysis
+
+import (
+	"testing"
+	pe "tsn-service/pkg/PE"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+)
+
+/*
+A talker and a listener on one bridge, all ports at 100 Mbps with an MTU of 1500 octets:
+
+	t.p0 -(100 ns)- sw0.p1  sw0.p2 -(100 ns)- l.p0
+
+The bridge takes 2000 ns to process a frame
+*/
+func getTestTopology() *topology.Topology {
+	capabilities := &topology.InterfaceCapabilities{PortSpeed: 100, MaximumTransmissionUnit: 1500}
+	return &topology.Topology{
+		Nodes: []*topology.Node{
+			{Name: "t", Type: topology.NodeRole_END_STATION, Ports: []*topology.Port{{Name: "p0", MacAddress: "00:00:00:00:00:01", Capabilities: capabilities}}},
+			{Name: "sw0", Type: topology.NodeRole_BRIDGE, ManagementInfo: &topology.ManagementInfo{IpAddress: "10.0.0.1"},
+				Properties: &topology.NodeProperties{Bridge: &topology.BridgeProperties{ProcessingDelayNs: 2000}},
+				Ports:      []*topology.Port{{Name: "p1", Capabilities: capabilities}, {Name: "p2", Capabilities: capabilities}}},
+			{Name: "l", Type: topology.NodeRole_END_STATION, Ports: []*topology.Port{{Name: "p0", MacAddress: "00:00:00:00:00:02", Capabilities: capabilities}}},
+		},
+		Links: []*topology.Link{
+			{Id: "l0", SourceNode: "t", SourcePort: "p0", TargetNode: "sw0", TargetPort: "p1", PropagationDelayNs: 100},
+			{Id: "l1", SourceNode: "sw0", SourcePort: "p2", TargetNode: "l", TargetPort: "p0", PropagationDelayNs: 100},
+		},
+	}
+}
+
+// A stream from t to l through sw0 of one 1000 octet frame every ms with priority 7, 81600 ns on the wire
+func getTestStream(id string, maxLatency uint32) Stream {
+	return Stream{
+		Request: &configuration.Request{
+			Talker: &configuration.TalkerGroup{
+				StrId:                  &configuration.StreamId{MacAddress: "00:00:00:00:00:01", UniqueId: id},
+				DataFrameSpecification: []*configuration.DataFrameSpecification{{VlanTag: &configuration.IeeeVlanTag{PriorityCodePoint: 7, VlanId: 10}}},
+				TrafficSpecification: &configuration.TrafficSpecification{
+					Interval:             &configuration.Interval{Numerator: 1, Denominator: 1000},
+					MaxFramesPerInterval: 1,
+					MaxFrameSize:         1000,
+				},
+			},
+			ListenerList: []*configuration.ListenerGroup{{Index: 0, UserToNetReq: &configuration.UserToNetworkRequirements{MaxLatency: maxLatency}}},
+		},
+		Paths: map[uint32]pe.Path{0: {{Node: "sw0", DeviceIp: "10.0.0.1", IngressPort: "p1", EgressPort: "p2"}}},
+	}
+}
+
+// A schedule at sw0.p2 with a cycle of 1 ms
+func getTestGclConfiguration(isochronous int32) *schedule.GclConfiguration {
+	return &schedule.GclConfiguration{Configs: []*schedule.ConfigMap{{
+		NodePort: "sw0.p2",
+		Sched: &schedule.Schedule{GatingCycle: 1000000, TrafficClasses: []*schedule.TrafficClass{
+			{Name: "isochronous", AssignedPortion: isochronous},
+			{Name: "best-effort", AssignedPortion: 100 - isochronous},
+		}},
+	}}}
+}
+
+/*
+Without queuing the frame takes, the same for every case:
+
+	talker: 81600 transmission + 100 propagation
+	sw0: 2000 processing + 81600 transmission + 100 propagation
+
+which is 165400 ns
+*/
+func TestAnalyzeStreams(t *testing.T) {
+	tests := []struct {
+		name      string
+		streams   int
+		gclConfig *schedule.GclConfiguration
+		queuing   uint64 // at sw0.p2
+	}{
+		// A maximum-size frame of another priority is being sent: 1542 octets * 8 at 100 Mbps
+		{name: "no schedule", streams: 1, gclConfig: nil, queuing: 123360},
+		// The window of 200000 ns closed just before the frame arrives
+		{name: "schedule", streams: 1, gclConfig: getTestGclConfiguration(20), queuing: 800000},
+		// And the frame of the other stream is sent first
+		{name: "two streams", streams: 2, gclConfig: getTestGclConfiguration(20), queuing: 881600},
+		// The two frames take 163200 ns, which does not fit in a window of 100000 ns, the frame is sent in the next window
+		{name: "two windows", streams: 2, gclConfig: getTestGclConfiguration(10), queuing: 1881600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var streams []Stream
+			for _, id := range []string{"a", "b"}[:tt.streams] {
+				streams = append(streams, getTestStream(id, 0))
+			}
+
+			latencies, err := AnalyzeStreams(getTestTopology(), streams, tt.gclConfig)
+			if err != nil {
+				t.Fatalf("AnalyzeStreams() error: %v", err)
+			}
+			latency := latencies[0].Listeners[0]
+			if latency.Hops[1].Queuing != tt.queuing {
+				t.Errorf("AnalyzeStreams() queuing at sw0.p2 = %d ns, want %d", latency.Hops[1].Queuing, tt.queuing)
+			}
+			if latency.BestCase != 165400 || latency.WorstCase != 165400+tt.queuing || latency.Jitter != tt.queuing {
+				t.Errorf("AnalyzeStreams() = worst %d, best %d, jitter %d ns, want %d, 165400 and %d",
+					latency.WorstCase, latency.BestCase, latency.Jitter, 165400+tt.queuing, tt.queuing)
+			}
+		})
+	}
+}
+
+// The worst case of 288760 ns without schedule against the max latency of the listener
+func TestUpdateResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxLatency  uint32
+		failureCode uint32
+		status      int32
+	}{
+		{name: "no max latency", maxLatency: 0, failureCode: 0, status: StatusReady},
+		{name: "within", maxLatency: 288760, failureCode: 0, status: StatusReady},
+		{name: "exceeded", maxLatency: 288759, failureCode: FailureMaxLatencyExceeded, status: ListenerStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latencies, err := AnalyzeStreams(getTestTopology(), []Stream{getTestStream("a", tt.maxLatency)}, nil)
+			if err != nil {
+				t.Fatalf("AnalyzeStreams() error: %v", err)
+			}
+
+			resp := &configuration.ConfigResponse{}
+			UpdateResponse(resp, latencies)
+			statusGroup := resp.Responses[0].GetStatusGroup()
+			if got := statusGroup.GetStatusTalkerListener()[0].GetAccumulatedLatency().GetAccumulatedLatency(); got != 288760 {
+				t.Errorf("UpdateResponse() accumulated latency = %d ns, want 288760", got)
+			}
+			statusInfo := statusGroup.GetStatusInfo()
+			if statusInfo.GetFailureCode() != tt.failureCode || statusInfo.GetListenerStatus() != tt.status {
+				t.Errorf("UpdateResponse() = failure code %d and listener status %d, want %d and %d",
+					statusInfo.GetFailureCode(), statusInfo.GetListenerStatus(), tt.failureCode, tt.status)
+			}
+		})
+	}
+}
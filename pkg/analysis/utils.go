@@ -0,0 +1,218 @@
+package analysis
+
+/*
+Help functions for latency.go
+*/
+
+import (
+	"errors"
+	"fmt"
+	pe "tsn-service/pkg/PE"
+	qos "tsn-service/pkg/QoS"
+	cbs "tsn-service/pkg/RAE/CBS"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+)
+
+// The topology and schedules the latency is computed from, and the traffic of each traffic class at each port
+type analyzer struct {
+	nodes      map[string]*topology.Node
+	links      map[string]*topology.Link     // by node.port at either end
+	schedules  map[string]*schedule.Schedule // by node.port
+	priorities map[string]map[int]string     // the traffic class of each priority in a schedule, by node.port
+	classBits  map[string]uint64             // bits per interval, by node.port/traffic class
+}
+
+func newAnalyzer(topo *topology.Topology, gclConfig *schedule.GclConfiguration) *analyzer {
+	a := &analyzer{
+		nodes:      map[string]*topology.Node{},
+		links:      map[string]*topology.Link{},
+		schedules:  map[string]*schedule.Schedule{},
+		priorities: map[string]map[int]string{},
+		classBits:  map[string]uint64{},
+	}
+	for _, node := range topo.GetNodes() {
+		a.nodes[node.Name] = node
+	}
+	for _, link := range topo.GetLinks() {
+		a.links[getPortKey(link.SourceNode, link.SourcePort)] = link
+		a.links[getPortKey(link.TargetNode, link.TargetPort)] = link
+	}
+	for _, configMap := range gclConfig.GetConfigs() {
+		a.schedules[configMap.NodePort] = configMap.Sched
+	}
+	return a
+}
+
+// Add the frames a stream sends per interval to the traffic class of every egress port on its paths, the disjoint paths included
+func (a *analyzer) addStream(stream Stream) error {
+	talker := stream.Request.GetTalker()
+	if talker == nil || talker.TrafficSpecification == nil {
+		return errors.New("the request has no talker with a traffic specification")
+	}
+	bits := uint64(talker.TrafficSpecification.MaxFramesPerInterval) * getFrameBits(talker.TrafficSpecification)
+
+	// A port the paths to several listeners share is only counted once
+	counted := map[string]bool{}
+	var paths []pe.Path
+	for _, path := range stream.Paths {
+		paths = append(paths, path)
+	}
+	for _, redundantPaths := range stream.RedundantPaths {
+		paths = append(paths, redundantPaths...)
+	}
+	for _, path := range paths {
+		for _, hop := range path {
+			key := getPortKey(hop.Node, hop.EgressPort)
+			if counted[key] {
+				continue
+			}
+			counted[key] = true
+
+			trafficClass, err := a.getTrafficClass(hop.Node, hop.EgressPort, GetStreamPriority(talker))
+			if err != nil {
+				return err
+			}
+			a.classBits[key+"/"+trafficClass] += bits
+		}
+	}
+	return nil
+}
+
+// Get the traffic class of the schedule a priority is sent in at a port, ports without schedule have one class per priority
+func (a *analyzer) getTrafficClass(node string, port string, priority int) (string, error) {
+	key := getPortKey(node, port)
+	sched := a.schedules[key]
+	if sched == nil {
+		return "priority" + fmt.Sprint(priority), nil
+	}
+
+	if _, ok := a.priorities[key]; !ok {
+		mapping, err := qos.GetPriorityMapping(sched)
+		if err != nil {
+			return "", fmt.Errorf("failed mapping the priorities of port %s: %w", key, err)
+		}
+		a.priorities[key] = map[int]string{}
+		for name, priorities := range mapping {
+			for _, p := range priorities {
+				a.priorities[key][p] = name
+			}
+		}
+	}
+
+	trafficClass, ok := a.priorities[key][priority]
+	if !ok {
+		return "", errors.New("priority " + fmt.Sprint(priority) + " is not in a traffic class of the schedule of port " + key)
+	}
+	return trafficClass, nil
+}
+
+// Get the link connected to a port
+func (a *analyzer) getLink(node string, port string) (*topology.Link, error) {
+	link, ok := a.links[getPortKey(node, port)]
+	if !ok {
+		return nil, errors.New("no link is connected to port " + getPortKey(node, port))
+	}
+	return link, nil
+}
+
+// Get a port of a node in the topology, nil if it is not in the topology
+func (a *analyzer) getPort(node string, port string) *topology.Port {
+	for _, p := range a.nodes[node].GetPorts() {
+		if p.Name == port {
+			return p
+		}
+	}
+	return nil
+}
+
+// Get the processing delay of a bridge (ns), 0 if it is not known
+func (a *analyzer) getProcessingDelay(node string) uint64 {
+	properties := a.nodes[node].GetProperties()
+	if delay := properties.GetBridge().GetProcessingDelayNs(); delay > 0 {
+		return uint64(delay)
+	}
+	if delay := properties.GetBridgedEndStation().GetProcessingDelayNs(); delay > 0 {
+		return uint64(delay)
+	}
+	return 0
+}
+
+// Get the node and port at the other end of a link
+func getOtherEnd(link *topology.Link, node string) (string, string) {
+	if link.SourceNode == node {
+		return link.TargetNode, link.TargetPort
+	}
+	return link.SourceNode, link.SourcePort
+}
+
+// Get the cycle and the window of a traffic class in a schedule (ns)
+func GetWindow(sched *schedule.Schedule, trafficClass string) (cycle uint64, window uint64) {
+	cycle = sched.GatingCycle
+	intervals := sched.GetIntervals()
+	for index, class := range sched.TrafficClasses {
+		if class.Name == trafficClass {
+			window += intervals[index]
+		}
+	}
+	return cycle, window
+}
+
+// Get the priority of the frames of a stream, frames without vlan tag have priority 0
+func GetStreamPriority(talker *configuration.TalkerGroup) int {
+	for _, dataFrameSpec := range talker.GetDataFrameSpecification() {
+		if vlanTag := dataFrameSpec.GetVlanTag(); vlanTag != nil {
+			return int(vlanTag.PriorityCodePoint)
+		}
+	}
+	return 0
+}
+
+// Get the bits one frame of a stream takes on the wire
+func getFrameBits(trafficSpec *configuration.TrafficSpecification) uint64 {
+	return (uint64(trafficSpec.GetMaxFrameSize()) + cbs.PerFrameOverhead) * 8
+}
+
+// Get the time (ns) it takes to send a number of bits at a port speed (Mbps), rounded up
+func getTransmissionTime(bits uint64, portSpeed uint64) uint64 {
+	return (bits*1000 + portSpeed - 1) / portSpeed
+}
+
+func getPortKey(node string, port string) string {
+	return node + "." + port
+}
+
+// Get the status of a stream in the response, a new status is added as ready if the stream is not in it
+func getStatusGroup(resp *configuration.ConfigResponse, strId *configuration.StreamId) *configuration.StatusGroup {
+	for _, response := range resp.Responses {
+		statusGroup := response.GetStatusGroup()
+		if statusGroup.GetStrId().GetUniqueId() == strId.GetUniqueId() &&
+			statusGroup.GetStrId().GetMacAddress() == strId.GetMacAddress() {
+			if statusGroup.StatusInfo == nil {
+				statusGroup.StatusInfo = &configuration.StatusInfo{TalkerStatus: StatusReady, ListenerStatus: StatusReady}
+			}
+			return statusGroup
+		}
+	}
+
+	statusGroup := &configuration.StatusGroup{
+		StrId:      strId,
+		StatusInfo: &configuration.StatusInfo{TalkerStatus: StatusReady, ListenerStatus: StatusReady},
+	}
+	resp.Responses = append(resp.Responses, &configuration.Response{StatusGroup: statusGroup})
+	return statusGroup
+}
+
+// Get the status of a listener of a stream, a new status is added if the listener is not in it
+func getTalkerListenerStatus(statusGroup *configuration.StatusGroup, listener uint32) *configuration.TalkerListenerStatus {
+	for _, status := range statusGroup.StatusTalkerListener {
+		if status.Index == listener {
+			return status
+		}
+	}
+
+	status := &configuration.TalkerListenerStatus{Index: listener}
+	statusGroup.StatusTalkerListener = append(statusGroup.StatusTalkerListener, status)
+	return status
+}
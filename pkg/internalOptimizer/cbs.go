@@ -0,0 +1,170 @@
+package internalOptimizer
+
+/*
+Set the credit-based shaper (CBS) of the bridge ports for the admitted streams whose talker selects it
+(TransmissionSelection, see cbs.TransmissionSelectionCbs). The idle slope of a queue at a port is the bandwidth of the
+streams sent through it, a port that supports the shaper and has none of these streams has no shaper.
+The admission control keeps the reservations of all streams below the share of the port speed the shaper allows
+(admission.DefaultLimits), so the shapers of the admitted streams always fit.
+*/
+
+import (
+	"errors"
+	"fmt"
+	pe "tsn-service/pkg/PE"
+	qos "tsn-service/pkg/QoS"
+	cbs "tsn-service/pkg/RAE/CBS"
+	pcp "tsn-service/pkg/RAE/PCP"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/analysis"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+/*
+Compute and set the shapers of the bridge ports that support the credit-based shaper
+
+input:
+
+	reqs: the admitted stream requests
+	topo: the topology the paths of the streams are computed in
+	gclConfig: the schedule of each port, the queue of a stream is taken from the priority plan of the port (may be nil)
+	roots: the configuration of each device, by device ip
+	ports: the ports to set (node.port), nil for all ports
+
+output:
+
+	updates: the shapers of the queues the streams are sent in
+	deletes: the shapers that were set before and have no streams any more
+*/
+func ComposeCbsConfiguration(reqs []*configuration.Request, topo *topology.Topology, gclConfig *schedule.GclConfiguration,
+	roots map[string]*st.SchemaTree, ports []string) (updates []*pb.Update, deletes []*pb.Path, err error) {
+
+	reservations, err := getCbsReservations(reqs, topo, gclConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selected := map[string]bool{}
+	for _, port := range ports {
+		selected[port] = true
+	}
+	for _, node := range topo.GetNodes() {
+		deviceIp := node.GetManagementInfo().GetIpAddress()
+		if node.Type == topology.NodeRole_END_STATION || deviceIp == "" {
+			continue
+		}
+		for _, port := range node.GetPorts() {
+			key := fmt.Sprintf("%s.%s", node.Name, port.Name)
+			if !isCbsPort(port) || (ports != nil && !selected[key]) {
+				continue
+			}
+			root, ok := roots[deviceIp]
+			if !ok {
+				return nil, nil, fmt.Errorf("no configuration for device %s (%s)", deviceIp, node.Name)
+			}
+
+			_, portUpdates, portDeletes, err := cbs.ComposePortCBS(root, deviceIp, port, reservations[key], cbs.DefaultLimits)
+			if err != nil {
+				return nil, nil, err
+			}
+			updates = append(updates, portUpdates...)
+			deletes = append(deletes, portDeletes...)
+		}
+	}
+
+	return updates, deletes, nil
+}
+
+// Get the reservations of the streams that select the credit-based shaper at every egress port on their paths,
+// the disjoint paths of FRER included, by node.port. A port the paths to several listeners share is only counted once
+func getCbsReservations(reqs []*configuration.Request, topo *topology.Topology, gclConfig *schedule.GclConfiguration) (
+	map[string][]cbs.Reservation, error) {
+
+	reservations := map[string][]cbs.Reservation{}
+	for _, req := range reqs {
+		talker := req.GetTalker()
+		if talker.GetTrafficSpecification().GetTransmissionSelection() != cbs.TransmissionSelectionCbs {
+			continue
+		}
+		bandwidth, err := cbs.GetStreamBandwidth(talker.TrafficSpecification)
+		if err != nil {
+			return nil, fmt.Errorf("failed getting the bandwidth of stream %s: %w", talker.GetStrId().GetUniqueId(), err)
+		}
+
+		paths, err := pe.GetStreamPaths(topo, req)
+		if err != nil {
+			return nil, err
+		}
+		redundantPaths, err := pe.GetStreamDisjointPaths(topo, req)
+		if err != nil {
+			return nil, err
+		}
+		allPaths := getPathList(req, paths)
+		for _, listener := range req.ListenerList {
+			allPaths = append(allPaths, redundantPaths[listener.Index]...)
+		}
+
+		counted := map[string]bool{}
+		for _, path := range allPaths {
+			for _, hop := range path {
+				key := fmt.Sprintf("%s.%s", hop.Node, hop.EgressPort)
+				_, port := getNodePort(topo, key)
+				if counted[key] || !isCbsPort(port) {
+					continue
+				}
+				counted[key] = true
+
+				queue, err := getPriorityQueue(port, getPortSchedule(gclConfig, key), analysis.GetStreamPriority(talker))
+				if err != nil {
+					return nil, fmt.Errorf("failed getting the queue of stream %s at port %s: %w", talker.GetStrId().GetUniqueId(), key, err)
+				}
+				reservations[key] = append(reservations[key], cbs.Reservation{
+					TrafficClass: queue,
+					Bandwidth:    bandwidth,
+					MaxFrameSize: uint(talker.TrafficSpecification.MaxFrameSize),
+				})
+			}
+		}
+	}
+	return reservations, nil
+}
+
+// True if the shapers of the port can be set: it supports the shaper, and its speed and number of queues are known
+func isCbsPort(port *topology.Port) bool {
+	return port.GetCapabilities().GetSupportsCbs() && port.GetCapabilities().GetPortSpeed() > 0 && port.GetNumberOfQueues() > 0
+}
+
+// Get the queue a priority is sent in at a port, from the priority plan of the schedule of the port,
+// or from the default traffic class table when the port has no schedule
+func getPriorityQueue(port *topology.Port, sched *schedule.Schedule, priority int) (uint, error) {
+	if priority < 0 || priority > 7 {
+		return 0, errors.New("Invalid priority. Value: " + fmt.Sprint(priority) + ". Range is [0-7]")
+	}
+	if sched == nil {
+		trafficClasses, err := pcp.GetDefaultTrafficClasses(int(port.NumberOfQueues))
+		if err != nil {
+			return 0, err
+		}
+		return uint(trafficClasses[priority]), nil
+	}
+
+	plan, err := qos.GetPortPlan(sched, int(port.NumberOfQueues))
+	if err != nil {
+		return 0, err
+	}
+	return uint(plan.TrafficClasses[priority]), nil
+}
+
+// Get the schedule of a port (node.port), nil if the port is not in the configuration
+func getPortSchedule(gclConfig *schedule.GclConfiguration, nodePort string) *schedule.Schedule {
+	for _, configMap := range gclConfig.GetConfigs() {
+		if configMap.NodePort == nodePort {
+			return configMap.Sched
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package internalOptimizer
+
+import (
+	"fmt"
+	"testing"
+	cbs "tsn-service/pkg/RAE/CBS"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+)
+
+/*
+A line of two bridges between a talker and a listener, all ports at 100 Mbps:
+
+	t.p0 - sw0.p1  sw0.p2 - sw1.p1  sw1.p2 - l.p0
+
+sw0.p2 and sw1.p1 support the credit-based shaper, sw0.p2 has 8 queues and sw1.p1 has 2
+*/
+func getTestLineTopology() *topology.Topology {
+	getPort := func(name string, mac string, queues int32, supportsCbs bool) *topology.Port {
+		return &topology.Port{Name: name, MacAddress: mac, NumberOfQueues: queues,
+			Capabilities: &topology.InterfaceCapabilities{PortSpeed: 100, MaximumTransmissionUnit: 1500, SupportsCbs: supportsCbs}}
+	}
+	getLink := func(id string, source string, sourcePort string, target string, targetPort string) *topology.Link {
+		return &topology.Link{Id: id, SourceNode: source, SourcePort: sourcePort, TargetNode: target, TargetPort: targetPort}
+	}
+
+	return &topology.Topology{
+		Nodes: []*topology.Node{
+			{Name: "t", Type: topology.NodeRole_END_STATION, Ports: []*topology.Port{getPort("p0", "00:00:00:00:00:01", 8, false)}},
+			{Name: "sw0", Type: topology.NodeRole_BRIDGE, ManagementInfo: &topology.ManagementInfo{IpAddress: "10.0.0.1"},
+				Ports: []*topology.Port{getPort("p1", "", 8, false), getPort("p2", "", 8, true)}},
+			{Name: "sw1", Type: topology.NodeRole_BRIDGE, ManagementInfo: &topology.ManagementInfo{IpAddress: "10.0.0.2"},
+				Ports: []*topology.Port{getPort("p1", "", 2, true), getPort("p2", "", 8, false)}},
+			{Name: "l", Type: topology.NodeRole_END_STATION, Ports: []*topology.Port{getPort("p0", "00:00:00:00:00:02", 8, false)}},
+		},
+		Links: []*topology.Link{
+			getLink("l0", "t", "p0", "sw0", "p1"),
+			getLink("l1", "sw0", "p2", "sw1", "p1"),
+			getLink("l2", "sw1", "p2", "l", "p0"),
+		},
+	}
+}
+
+// A stream from t to l of one 1000 octet frame every ms (8160000 bits/sec on the wire)
+func getTestLineRequest(id string, priority uint32, transmissionSelection uint32) *configuration.Request {
+	getInterfaces := func(mac string) []*configuration.Interface {
+		return []*configuration.Interface{{InterfaceId: &configuration.InterfaceId{MacAddress: mac}}}
+	}
+	return &configuration.Request{
+		Talker: &configuration.TalkerGroup{
+			StrId:                  &configuration.StreamId{MacAddress: "00:00:00:00:00:01", UniqueId: id},
+			EndStationInterfaces:   getInterfaces("00:00:00:00:00:01"),
+			DataFrameSpecification: []*configuration.DataFrameSpecification{{VlanTag: &configuration.IeeeVlanTag{PriorityCodePoint: priority, VlanId: 10}}},
+			TrafficSpecification: &configuration.TrafficSpecification{
+				Interval:              &configuration.Interval{Numerator: 1, Denominator: 1000},
+				MaxFramesPerInterval:  1,
+				MaxFrameSize:          1000,
+				TransmissionSelection: transmissionSelection,
+			},
+		},
+		ListenerList: []*configuration.ListenerGroup{{Index: 0, EndStationInterfaces: getInterfaces("00:00:00:00:00:02")}},
+	}
+}
+
+func TestComposeCbsConfiguration(t *testing.T) {
+	topo := getTestLineTopology()
+	reqs := []*configuration.Request{
+		getTestLineRequest("a", 3, cbs.TransmissionSelectionCbs),
+		getTestLineRequest("b", 2, cbs.TransmissionSelectionCbs),
+		getTestLineRequest("c", 3, cbs.TransmissionSelectionCbs),
+		getTestLineRequest("d", 5, 0), // strict priority, not shaped
+	}
+	roots := map[string]*st.SchemaTree{
+		"10.0.0.1": {Name: "data", Kind: st.KindContainer},
+		"10.0.0.2": {Name: "data", Kind: st.KindContainer},
+	}
+
+	// A shaper that was set before at sw1.p1, which none of the streams leave the bridge through
+	cbs.SetCreditBasedShaper(roots["10.0.0.2"], "p1", "10.0.0.2", cbs.Shaper{TrafficClass: 1, IdleSlope: 1000000})
+
+	updates, deletes, err := ComposeCbsConfiguration(reqs, topo, nil, roots, nil)
+	if err != nil {
+		t.Fatalf("ComposeCbsConfiguration() error: %v", err)
+	}
+
+	idleSlopes := map[string]uint64{}
+	for _, update := range updates {
+		elems := update.GetPath().GetElem()
+		if elems[len(elems)-1].GetName() == "idle-slope" {
+			key := update.GetPath().GetTarget() + " queue " + elems[len(elems)-2].GetKey()["traffic-class"]
+			idleSlopes[key] = update.GetVal().GetUintVal()
+		}
+	}
+	want := map[string]uint64{"10.0.0.1 queue 3": 16320000, "10.0.0.1 queue 2": 8160000}
+	if fmt.Sprint(idleSlopes) != fmt.Sprint(want) {
+		t.Errorf("ComposeCbsConfiguration() idle slopes = %v, want %v", idleSlopes, want)
+	}
+	if len(deletes) != 1 || deletes[0].GetTarget() != "10.0.0.2" {
+		t.Errorf("ComposeCbsConfiguration() deletes = %v, want the shaper at sw1.p1", deletes)
+	}
+
+	// Only the given ports are set
+	updates, deletes, err = ComposeCbsConfiguration(reqs[:1], topo, nil, roots, []string{"sw1.p1"})
+	if err != nil {
+		t.Fatalf("ComposeCbsConfiguration() of sw1.p1 error: %v", err)
+	}
+	if len(updates) != 0 || len(deletes) != 0 {
+		t.Errorf("ComposeCbsConfiguration() of sw1.p1 = %d updates and %d deletes, want none, its shaper is deleted", len(updates), len(deletes))
+	}
+}
+
+// The priority plan of the schedule of a port decides the queue, the default traffic class table is used without schedule
+func TestGetPriorityQueue(t *testing.T) {
+	// With 2 queues the default table puts priority 5 in queue 1 with isochronous, the plan of the schedule moves best-effort to queue 0
+	port := &topology.Port{Name: "p1", NumberOfQueues: 2}
+	sched := getTestSchedule(1000000, portion{"isochronous", 50}, portion{"best-effort", 50})
+
+	tests := []struct {
+		name     string
+		sched    *schedule.Schedule
+		priority int
+		want     uint
+	}{
+		{name: "default table", sched: nil, priority: 5, want: 1},
+		{name: "plan of the schedule", sched: sched, priority: 5, want: 0},
+		{name: "isochronous", sched: sched, priority: 7, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue, err := getPriorityQueue(port, tt.sched, tt.priority)
+			if err != nil {
+				t.Fatalf("getPriorityQueue() error: %v", err)
+			}
+			if queue != tt.want {
+				t.Errorf("getPriorityQueue(%d) = %d, want %d", tt.priority, queue, tt.want)
+			}
+		})
+	}
+
+	for _, priority := range []int{-1, 8} {
+		if _, err := getPriorityQueue(port, nil, priority); err == nil {
+			t.Errorf("getPriorityQueue(%d) gave no error", priority)
+		}
+	}
+}
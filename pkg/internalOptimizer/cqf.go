@@ -0,0 +1,314 @@
+package internalOptimizer
+
+/*
+Cyclic Queuing and Forwarding (CQF), an alternative to the shared GCL of the default schedule.
+Time is divided into cycles of equal length and two queues take turns at every port: while one queue receives
+the frames of the current cycle, the other one sends what was received in the cycle before. A frame is therefore
+sent one cycle after it was received by every bridge, which bounds the latency to (hops+1) cycles.
+
+	ingress (PSFP stream gate):  cycle 0 -> queue A,  cycle 1 -> queue B
+	egress  (TAS gates):         cycle 0 -> A closed, cycle 1 -> B closed
+
+Ref:
+	IEEE 802.1Q-2018 Annex T (cyclic queuing and forwarding)
+	IEEE 802.1Qch-2017
+*/
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	pe "tsn-service/pkg/PE"
+	cbs "tsn-service/pkg/RAE/CBS"
+	psfp "tsn-service/pkg/RAE/PSFP"
+	flowmeterinst "tsn-service/pkg/RAE/PSFP/flowMeterInst"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/analysis"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/topology"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// The queues that take turns when nothing else is configured, the two highest of a port with 8 queues
+var DefaultCqfQueues = [2]uint{6, 7}
+
+// A stream request and the path to each of its listeners
+type cqfStream struct {
+	req   *configuration.Request
+	paths map[uint32]pe.Path // by listener index
+}
+
+/*
+Configure all streams of a configuration request with CQF
+
+input:
+
+	confReq: the stream requests
+	topo: the topology the paths of the streams are computed in
+	roots: the configuration of each device, by device ip
+	queues: the two queues that take turns at every port
+	tolerance: how much the flow meters allow above the traffic specification of the talkers
+
+output:
+
+	cycleTime: the cycle time in ns
+	resp: the status and accumulated latency of every listener
+	streamConfigs: what is set for each stream, in the order of the requests (see ReleaseStreamConfiguration)
+	updates: the PSFP and VLAN updates of the ingress ports and the gate updates of the egress ports
+*/
+func CalculateCqf(confReq *configuration.ConfigRequest, topo *topology.Topology, roots map[string]*st.SchemaTree,
+	queues [2]uint, tolerance flowmeterinst.Tolerance) (cycleTime uint64, resp *configuration.ConfigResponse,
+	streamConfigs []*StreamConfiguration, updates []*pb.Update, err error) {
+
+	if queues[0] == queues[1] || queues[0] > 7 || queues[1] > 7 {
+		return 0, nil, nil, nil, errors.New("Invalid CQF queues. Value: " + fmt.Sprint(queues) + ". Two different queues between 0 and 7 are needed")
+	}
+
+	streams, err := getCqfStreams(confReq, topo)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+
+	cycleTime, err = getCqfCycleTime(streams)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+
+	if err = checkCqfPorts(streams, topo, cycleTime, queues); err != nil {
+		return 0, nil, nil, nil, err
+	}
+
+	resp = &configuration.ConfigResponse{Version: confReq.Version}
+	egressPorts := map[string]pe.Hop{}
+	for _, stream := range streams {
+		// Every bridge port is only configured once, also when the paths to several listeners pass it
+		for _, listener := range stream.req.ListenerList {
+			for _, hop := range stream.paths[listener.Index] {
+				egressPorts[hop.DeviceIp+"."+hop.EgressPort] = hop
+			}
+		}
+
+		streamConfig := &StreamConfiguration{}
+		var psfpUpdates, vlanUpdates []*pb.Update
+		streamConfig.PSFP, psfpUpdates, err = psfp.ComposeCqfStreamPSFP(stream.req, getIngressHops(stream.req, stream.paths), roots, tolerance, uint(cycleTime), queues)
+		if err != nil {
+			return 0, nil, nil, nil, fmt.Errorf("failed setting the stream gates of stream %s: %w", stream.req.Talker.GetStrId().GetUniqueId(), err)
+		}
+		streamConfig.Vlans, vlanUpdates, err = composeStreamVlans(stream.req, getPathList(stream.req, stream.paths), roots)
+		if err != nil {
+			return 0, nil, nil, nil, err
+		}
+		streamConfigs = append(streamConfigs, streamConfig)
+		updates = append(updates, psfpUpdates...)
+		updates = append(updates, vlanUpdates...)
+
+		resp.Responses = append(resp.Responses, getCqfResponse(stream, cycleTime))
+	}
+
+	var egressKeys []string
+	for key := range egressPorts {
+		egressKeys = append(egressKeys, key)
+	}
+	sort.Strings(egressKeys)
+	for _, key := range egressKeys {
+		hop := egressPorts[key]
+		root, ok := roots[hop.DeviceIp]
+		if !ok {
+			return 0, nil, nil, nil, errors.New("no configuration for device " + hop.DeviceIp)
+		}
+		updates = append(updates, getCqfGateElems(root, hop.EgressPort, hop.DeviceIp, cycleTime, queues)...)
+	}
+
+	return cycleTime, resp, streamConfigs, updates, nil
+}
+
+// Find the path from the talker to every listener of the stream requests, a request for seamless redundancy is an error
+func getCqfStreams(confReq *configuration.ConfigRequest, topo *topology.Topology) ([]cqfStream, error) {
+	var streams []cqfStream
+	for _, req := range confReq.GetRequests() {
+		talker := req.GetTalker()
+		if talker == nil || talker.TrafficSpecification == nil {
+			return nil, errors.New("the request has no talker with a traffic specification")
+		}
+
+		// The cycles of CQF are only set on one path to every listener, FRER needs the disjoint paths as well
+		for _, listener := range req.ListenerList {
+			if pe.GetNumSeamlessTrees(talker, listener) > 1 {
+				return nil, errors.New("stream " + talker.GetStrId().GetUniqueId() + " requests seamless redundancy, which is not supported with CQF")
+			}
+		}
+
+		paths, err := pe.GetStreamPaths(topo, req)
+		if err != nil {
+			return nil, err
+		}
+		streams = append(streams, cqfStream{req: req, paths: paths})
+	}
+	return streams, nil
+}
+
+/*
+Pick the cycle time (ns) from the stream requests
+The cycle time must divide the interval of every stream, so a stream sends the same frames every time it is
+in the same cycle, and (hops+1) cycles must stay within the max latency of every listener.
+The longest cycle time that meets both is picked, since every cycle needs room for the frames of all streams.
+*/
+func getCqfCycleTime(streams []cqfStream) (uint64, error) {
+	var cycleTime uint64
+	maxCycleTime := uint64(math.MaxUint64)
+	for _, stream := range streams {
+		talker := stream.req.Talker
+		interval := talker.TrafficSpecification.GetInterval()
+		if interval == nil || interval.Numerator == 0 || interval.Denominator == 0 {
+			return 0, errors.New("the traffic specification of stream " + talker.GetStrId().GetUniqueId() +
+				" must have an interval larger than 0")
+		}
+		intervalNs := uint64(interval.Numerator) * 1000000000
+		if intervalNs%uint64(interval.Denominator) != 0 {
+			return 0, errors.New("Invalid interval of stream " + talker.GetStrId().GetUniqueId() + ". Value: " +
+				fmt.Sprint(interval.Numerator) + "/" + fmt.Sprint(interval.Denominator) + " s. The interval must be a whole number of ns")
+		}
+		cycleTime = gcd(cycleTime, intervalNs/uint64(interval.Denominator))
+
+		for _, listener := range stream.req.ListenerList {
+			maxLatency := analysis.GetMaxLatency(talker, listener)
+			if maxLatency == 0 {
+				continue
+			}
+			hops := uint64(len(stream.paths[listener.Index]))
+			maxCycleTime = min(maxCycleTime, uint64(maxLatency)/(hops+1))
+		}
+	}
+
+	if cycleTime == 0 {
+		return 0, errors.New("no streams to pick the cycle time from")
+	}
+
+	// The largest divisor of the common interval that meets the latency of all listeners
+	best := uint64(0)
+	for divisor := uint64(1); divisor*divisor <= cycleTime; divisor++ {
+		if cycleTime%divisor != 0 {
+			continue
+		}
+		for _, candidate := range []uint64{divisor, cycleTime / divisor} {
+			if candidate <= maxCycleTime && candidate > best {
+				best = candidate
+			}
+		}
+	}
+	if best == 0 {
+		return 0, errors.New("no cycle time meets the max latency of the listeners")
+	}
+
+	return best, nil
+}
+
+/*
+Check that every port on the paths can do CQF with the queues, and that one cycle has room for the frames
+all streams can send through the port in one interval
+*/
+func checkCqfPorts(streams []cqfStream, topo *topology.Topology, cycleTime uint64, queues [2]uint) error {
+	bitsPerCycle := map[string]uint64{}
+	ports := map[string]*topology.Port{}
+	for _, stream := range streams {
+		trafficSpec := stream.req.Talker.TrafficSpecification
+		bits := uint64(trafficSpec.MaxFramesPerInterval) * (uint64(trafficSpec.MaxFrameSize) + cbs.PerFrameOverhead) * 8
+
+		counted := map[string]bool{}
+		for _, path := range stream.paths {
+			for _, hop := range path {
+				key := hop.Node + "." + hop.EgressPort
+				if counted[key] {
+					continue
+				}
+				counted[key] = true
+
+				if _, ok := ports[key]; !ok {
+					port, err := getTopologyPort(topo, hop.Node, hop.EgressPort)
+					if err != nil {
+						return err
+					}
+					if !port.GetCapabilities().GetSupportsCqf() {
+						return errors.New("port " + key + " does not support CQF")
+					}
+					if queues[0] >= uint(port.NumberOfQueues) || queues[1] >= uint(port.NumberOfQueues) {
+						return errors.New("Invalid CQF queues. Value: " + fmt.Sprint(queues) + ". Port " + key +
+							" has " + fmt.Sprint(port.NumberOfQueues) + " queues")
+					}
+					ports[key] = port
+				}
+				bitsPerCycle[key] += bits
+			}
+		}
+	}
+
+	for key, bits := range bitsPerCycle {
+		portSpeed := uint64(ports[key].GetCapabilities().GetPortSpeed())
+		if portSpeed == 0 {
+			return errors.New("port " + key + " has no port speed")
+		}
+		// bits / Mbps is µs, so bits * 1000 / Mbps is ns
+		transmissionTime := bits * 1000 / portSpeed
+		if transmissionTime > cycleTime {
+			return errors.New("The frames of one cycle do not fit in the cycle at port " + key + ". Transmission time: " +
+				fmt.Sprint(transmissionTime) + " ns, cycle time: " + fmt.Sprint(cycleTime) + " ns")
+		}
+	}
+
+	return nil
+}
+
+// Create the gate updates of an egress port, the gate of the queue that receives in a cycle is closed in that cycle
+func getCqfGateElems(root *st.SchemaTree, port string, deviceIp string, cycleTime uint64, queues [2]uint) []*pb.Update {
+	var updates []*pb.Update
+	updates = append(updates, getStatusChangeElems(root, port, deviceIp, len(queues))...)
+	for index, queue := range queues {
+		updates = append(updates, getGclEntryElems(root, index, 255&^(1<<queue), cycleTime, port, deviceIp)...)
+	}
+	updates = append(updates, getCycleTimeElems(root, int64(cycleTime)*int64(len(queues)), 1000000000, port, deviceIp)...)
+	updates = append(updates, getFinalElems(root, port, deviceIp)...)
+	return updates
+}
+
+// Create the response of a stream, the latency to a listener is one cycle more than the bridges on its path
+func getCqfResponse(stream cqfStream, cycleTime uint64) *configuration.Response {
+	statusGroup := &configuration.StatusGroup{
+		StrId:                stream.req.Talker.StrId,
+		StatusInfo:           &configuration.StatusInfo{TalkerStatus: analysis.StatusReady, ListenerStatus: analysis.StatusReady},
+		EndStationInterfaces: stream.req.Talker.EndStationInterfaces,
+	}
+
+	for _, listener := range stream.req.ListenerList {
+		hops := uint64(len(stream.paths[listener.Index]))
+		statusGroup.StatusTalkerListener = append(statusGroup.StatusTalkerListener, &configuration.TalkerListenerStatus{
+			Index:              listener.Index,
+			AccumulatedLatency: &configuration.AccumulatedLatency{AccumulatedLatency: uint32((hops + 1) * cycleTime)},
+		})
+	}
+
+	return &configuration.Response{StatusGroup: statusGroup}
+}
+
+// Find a port of a node in the topology
+func getTopologyPort(topo *topology.Topology, nodeName string, portName string) (*topology.Port, error) {
+	for _, node := range topo.GetNodes() {
+		if node.Name != nodeName {
+			continue
+		}
+		for _, port := range node.Ports {
+			if port.Name == portName {
+				return port, nil
+			}
+		}
+	}
+	return nil, errors.New("port " + nodeName + "." + portName + " is not in the topology")
+}
+
+func gcd(a uint64, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,73 @@
+package internalOptimizer
+
+import (
+	"testing"
+	pe "tsn-service/pkg/PE"
+	"tsn-service/pkg/structures/configuration"
+)
+
+// A stream with one listener whose path passes the given number of bridges, the max latencies are in ns (0 for none)
+func getTestCqfStream(numerator uint32, denominator uint32, hops int, talkerLatency uint32, listenerLatency uint32) cqfStream {
+	return cqfStream{
+		req: &configuration.Request{
+			Talker: &configuration.TalkerGroup{
+				StrId: &configuration.StreamId{MacAddress: "00:00:00:00:00:01", UniqueId: "a"},
+				TrafficSpecification: &configuration.TrafficSpecification{
+					Interval:             &configuration.Interval{Numerator: numerator, Denominator: denominator},
+					MaxFramesPerInterval: 1,
+					MaxFrameSize:         1000,
+				},
+				UserToNetReq: &configuration.UserToNetworkRequirements{MaxLatency: talkerLatency},
+			},
+			ListenerList: []*configuration.ListenerGroup{{Index: 0, UserToNetReq: &configuration.UserToNetworkRequirements{MaxLatency: listenerLatency}}},
+		},
+		paths: map[uint32]pe.Path{0: make(pe.Path, hops)},
+	}
+}
+
+func TestGetCqfCycleTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		streams []cqfStream
+		want    uint64
+	}{
+		{name: "one stream", streams: []cqfStream{getTestCqfStream(1, 1000, 1, 0, 0)}, want: 1000000},
+		{name: "common interval", streams: []cqfStream{getTestCqfStream(1, 1000, 1, 0, 0), getTestCqfStream(3, 2000, 1, 0, 0)}, want: 500000},
+		// At most 1000000 / (2+1) = 333333 ns, the largest divisor of 1 ms below it is 250000 ns
+		{name: "max latency", streams: []cqfStream{getTestCqfStream(1, 1000, 2, 0, 1000000)}, want: 250000},
+		{name: "max latency of the talker", streams: []cqfStream{getTestCqfStream(1, 1000, 1, 1000000, 0)}, want: 500000},
+		{name: "max latency of the listener first", streams: []cqfStream{getTestCqfStream(1, 1000, 1, 1000000, 600000)}, want: 250000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycleTime, err := getCqfCycleTime(tt.streams)
+			if err != nil {
+				t.Fatalf("getCqfCycleTime() error: %v", err)
+			}
+			if cycleTime != tt.want {
+				t.Errorf("getCqfCycleTime() = %d ns, want %d", cycleTime, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCqfCycleTimeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		streams []cqfStream
+	}{
+		{name: "no interval", streams: []cqfStream{getTestCqfStream(0, 1000, 1, 0, 0)}},
+		{name: "interval not in whole ns", streams: []cqfStream{getTestCqfStream(1, 3, 1, 0, 0)}},
+		{name: "max latency below one ns per cycle", streams: []cqfStream{getTestCqfStream(1, 1000, 1, 0, 1)}},
+		{name: "no streams", streams: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cycleTime, err := getCqfCycleTime(tt.streams); err == nil {
+				t.Errorf("getCqfCycleTime() = %d ns, want an error", cycleTime)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package internalOptimizer
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	framepreemption "tsn-service/pkg/RAE/FramePreemption"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	store "tsn-service/pkg/storewrapper"
 	"tsn-service/pkg/structures/schedule"
 	"tsn-service/pkg/structures/topology"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/ghodss/yaml"
 	"github.com/gogo/protobuf/jsonpb"
+	pb "github.com/openconfig/gnmi/proto/gnmi"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -20,6 +24,22 @@ import (
 
 var defaultSchedID = "default_schedule"
 
+// How the streams of a schedule are sent, the mode of the schedule (an empty mode is ModeGcl)
+const (
+	ModeGcl = "gcl" // the traffic classes share the gate control list of every port
+	ModeCqf = "cqf" // cyclic queuing and forwarding, see CalculateCqf
+)
+
+// Is the configuration sent with CQF, the schedules of all its ports are in mode ModeCqf
+func IsCqfConfiguration(gclConfig *schedule.GclConfiguration) bool {
+	for _, configMap := range gclConfig.GetConfigs() {
+		if configMap.GetSched().GetMode() != ModeCqf {
+			return false
+		}
+	}
+	return len(gclConfig.GetConfigs()) > 0
+}
+
 // Calculates configuration set request using optimizer, if that failes build configuration set request from default schedule
 func CalculateConf(topology *topology.Topology, oldConfig *schedule.GclConfiguration) (*schedule.GclConfiguration, error) {
 
@@ -29,6 +49,10 @@ func CalculateConf(topology *topology.Topology, oldConfig *schedule.GclConfigura
 		//log.Errorf("Failed getting default schedule: %v", err)
 		return nil, err
 	}
+	if err = ValidateSchedule(sched); err != nil {
+		//log.Errorf("Default schedule is invalid: %v", err)
+		return nil, err
+	}
 
 	// Create configuration set request based on default schedule and topology
 	configSetReq, err := createConfigurationFromSchedule(sched, topology)
@@ -45,6 +69,89 @@ func CalculateConf(topology *topology.Topology, oldConfig *schedule.GclConfigura
 	return configSetReq, nil
 }
 
+// Get the part of a configuration for the given ports (node.port), as when only their streams changed
+func GetPortConfiguration(gclConfig *schedule.GclConfiguration, ports []string) *schedule.GclConfiguration {
+	affected := map[string]bool{}
+	for _, port := range ports {
+		affected[port] = true
+	}
+
+	portConfig := &schedule.GclConfiguration{}
+	for _, configMap := range gclConfig.GetConfigs() {
+		if affected[configMap.NodePort] {
+			portConfig.Configs = append(portConfig.Configs, configMap)
+		}
+	}
+	return portConfig
+}
+
+/*
+Compose the updates of the ports of the bridges from their schedule: the traffic class table, the gate control list
+with the gate states of the traffic classes (see qos.ComposePortQoS) and a guard band before every express window,
+and the frame preemption status table of the ports that can preempt frames (see CalculateFramePreemption)
+
+input:
+
+	gclConfig: the schedule of each port (see CalculateConf)
+	topo: the topology the ports are in
+	roots: the configuration of each device, by device ip
+
+output:
+
+	updates: the updates of all devices
+*/
+func ComposeGclConfiguration(gclConfig *schedule.GclConfiguration, topo *topology.Topology, roots map[string]*st.SchemaTree) ([]*pb.Update, error) {
+	var updates []*pb.Update
+	for _, configMap := range gclConfig.GetConfigs() {
+		node, port := getNodePort(topo, configMap.NodePort)
+		if port == nil {
+			return nil, errors.New("port " + configMap.NodePort + " is not in the topology")
+		}
+
+		// End stations are not configured, and the gates of a port whose number of queues is not known can not be planned
+		deviceIp := node.GetManagementInfo().GetIpAddress()
+		if node.Type == topology.NodeRole_END_STATION || deviceIp == "" || port.NumberOfQueues == 0 {
+			continue
+		}
+		root, ok := roots[deviceIp]
+		if !ok {
+			return nil, errors.New("no configuration for device " + deviceIp + " (" + node.Name + ")")
+		}
+
+		portUpdates, err := composePortGcl(root, deviceIp, port, configMap.Sched)
+		if err != nil {
+			//log.Errorf("Failed composing the gate control list of port %v: %v", configMap.NodePort, err)
+			return nil, fmt.Errorf("failed composing the gate control list of port %s: %w", configMap.NodePort, err)
+		}
+		updates = append(updates, portUpdates...)
+
+		// Ports that preempt frames get the preemption status of their priorities, their guard bands are shorter
+		preemptionUpdates, err := CalculateFramePreemption(root, deviceIp, port, configMap.Sched)
+		if err != nil {
+			return nil, fmt.Errorf("failed composing the frame preemption of port %s: %w", configMap.NodePort, err)
+		}
+		updates = append(updates, preemptionUpdates...)
+	}
+
+	return updates, nil
+}
+
+// Builds the frame preemption status table of a port from the traffic classes of the schedule
+// Ports that can not preempt frames send everything as express frames, so nothing is configured for them
+func CalculateFramePreemption(root *st.SchemaTree, deviceIp string, port *topology.Port, sched *schedule.Schedule) ([]*pb.Update, error) {
+	if !port.GetCapabilities().GetSupportsFramePreemption() {
+		return nil, nil
+	}
+
+	statuses, err := getPriorityPreemptionStatuses(sched)
+	if err != nil {
+		//log.Errorf("Failed getting frame preemption status of port %v: %v", port.Name, err)
+		return nil, err
+	}
+
+	return framepreemption.SetFramePreemptionStatusTable(root, port.Name, deviceIp, statuses)
+}
+
 // Reads default schedule config file and stores configuration for schedule in k/v store
 func CreateDefaultSchedule() error {
 	// Read default schedule from file
@@ -69,6 +176,13 @@ func CreateDefaultSchedule() error {
 		//log.Errorf("Failed unmarshaling json to protobuf: %v", err)
 		return err
 	}
+
+	// Do not store a schedule that can not be turned into gate control lists
+	if err = ValidateSchedule(defaultSched); err != nil {
+		fmt.Printf("Default schedule is invalid: %v\n", err)
+		//log.Errorf("Default schedule is invalid: %v", err)
+		return err
+	}
 	fmt.Println("Successfully created default schedule with ID: ", defaultSchedID)
 
 	// Serialize schedule
@@ -0,0 +1,213 @@
+package internalOptimizer
+
+/*
+Configure the bridges on the paths of an admitted stream:
+
+	PSFP: the stream identification, filter, gate and flow meter at every ingress port (see psfp.ComposeStreamPSFP)
+	FRER: a listener that requests seamless redundancy gets the stream over disjoint paths (see frer.ComposeStreamFRER)
+	VLAN: the ingress and egress ports of every bridge are tagged members of the VLAN of the stream, on the disjoint paths too
+
+What is set for a stream is kept in a StreamConfiguration, so exactly that can be removed again
+*/
+
+import (
+	"errors"
+	"fmt"
+	pe "tsn-service/pkg/PE"
+	frer "tsn-service/pkg/RAE/FRER"
+	psfp "tsn-service/pkg/RAE/PSFP"
+	flowmeterinst "tsn-service/pkg/RAE/PSFP/flowMeterInst"
+	vlan "tsn-service/pkg/RAE/VLAN"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/topology"
+
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// The filtering database the VLAN memberships of the streams are set in
+const vlanDatabaseId uint32 = 1
+
+// The membership of a port in the VLAN of a stream, a port in the port map of a Static VLAN Registration Entry
+type VlanMembership struct {
+	DeviceIp  string
+	Bridge    string
+	Component string
+	Port      string
+	Vid       uint32
+}
+
+// What is set at the bridges for one stream
+type StreamConfiguration struct {
+	PSFP  []psfp.StreamPSFP
+	FRER  []frer.StreamFRER
+	Vlans []VlanMembership
+}
+
+/*
+Configure the bridges on the paths of a stream request
+
+input:
+
+	req: the stream request, it must be admitted
+	topo: the topology the paths of the stream are computed in
+	roots: the configuration of each device, by device ip
+	tolerance: how much the flow meters allow above the traffic specification of the talker
+
+output:
+
+	streamConfig: what is set for the stream
+	updates: the updates of all devices on the paths
+*/
+func ComposeStreamConfiguration(req *configuration.Request, topo *topology.Topology, roots map[string]*st.SchemaTree,
+	tolerance flowmeterinst.Tolerance) (streamConfig *StreamConfiguration, updates []*pb.Update, err error) {
+
+	paths, err := pe.GetStreamPaths(topo, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	streamConfig = &StreamConfiguration{}
+	streamConfig.PSFP, updates, err = psfp.ComposeStreamPSFP(req, getIngressHops(req, paths), roots, tolerance)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed setting the PSFP of stream %s: %w", req.GetTalker().GetStrId().GetUniqueId(), err)
+	}
+
+	var frerUpdates []*pb.Update
+	streamConfig.FRER, frerUpdates, err = frer.ComposeStreamFRER(req, topo, roots, frer.DefaultRecoveryParameters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed setting the FRER of stream %s: %w", req.GetTalker().GetStrId().GetUniqueId(), err)
+	}
+	updates = append(updates, frerUpdates...)
+
+	allPaths := getPathList(req, paths)
+	for _, entry := range streamConfig.FRER {
+		allPaths = append(allPaths, entry.Paths...)
+	}
+	vlans, vlanUpdates, err := composeStreamVlans(req, allPaths, roots)
+	if err != nil {
+		return nil, nil, err
+	}
+	streamConfig.Vlans = vlans
+
+	return streamConfig, append(updates, vlanUpdates...), nil
+}
+
+// Get the paths to the listeners of a stream, in the order of the listeners
+func getPathList(req *configuration.Request, paths map[uint32]pe.Path) (pathList []pe.Path) {
+	for _, listener := range req.ListenerList {
+		if path, ok := paths[listener.Index]; ok {
+			pathList = append(pathList, path)
+		}
+	}
+	return pathList
+}
+
+// Make the ingress and egress port of every bridge on the paths of a stream a tagged member of the VLAN of the stream
+func composeStreamVlans(req *configuration.Request, paths []pe.Path, roots map[string]*st.SchemaTree) (
+	vlans []VlanMembership, updates []*pb.Update, err error) {
+
+	vlanTag, err := psfp.GetStreamVlanTag(req.GetTalker())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, membership := range getVlanMemberships(paths, vlanTag.VlanId) {
+		root, ok := roots[membership.DeviceIp]
+		if !ok {
+			return nil, nil, errors.New("no configuration for device " + membership.DeviceIp)
+		}
+		membership.Bridge, membership.Component, err = vlan.GetBridgeComponent(root)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed setting VLAN %d at device %s: %w", membership.Vid, membership.DeviceIp, err)
+		}
+
+		_, vlanUpdates, err := vlan.SetStaticVlanRegistrationEntry(root, "tagged", "fixed-new-ignored", fmt.Sprint(membership.Vid),
+			vlanDatabaseId, membership.Component, membership.Bridge, membership.Port, membership.DeviceIp)
+		if err != nil {
+			return nil, nil, err
+		}
+		vlans = append(vlans, membership)
+		updates = append(updates, vlanUpdates...)
+	}
+
+	return vlans, updates, nil
+}
+
+// Get the hops of the paths to all listeners of a stream, a bridge port the paths share is only in it once
+func getIngressHops(req *configuration.Request, paths map[uint32]pe.Path) (ingressHops pe.Path) {
+	ingressPorts := map[string]bool{}
+	for _, listener := range req.ListenerList {
+		for _, hop := range paths[listener.Index] {
+			if !ingressPorts[hop.DeviceIp+"."+hop.IngressPort] {
+				ingressPorts[hop.DeviceIp+"."+hop.IngressPort] = true
+				ingressHops = append(ingressHops, hop)
+			}
+		}
+	}
+	return ingressHops
+}
+
+// Get the ports on the paths of a stream that must be members of its VLAN, the ingress and egress port of every bridge
+func getVlanMemberships(paths []pe.Path, vid uint32) (memberships []VlanMembership) {
+	members := map[string]bool{}
+	for _, path := range paths {
+		for _, hop := range path {
+			for _, port := range []string{hop.IngressPort, hop.EgressPort} {
+				if !members[hop.DeviceIp+"."+port] {
+					members[hop.DeviceIp+"."+port] = true
+					memberships = append(memberships, VlanMembership{DeviceIp: hop.DeviceIp, Port: port, Vid: vid})
+				}
+			}
+		}
+	}
+	return memberships
+}
+
+/*
+Remove what is set for a stream at the bridges on its paths
+The PSFP and FRER entries of the stream are always removed, a port stays a member of the VLAN if another stream still uses it there
+
+input:
+
+	streamConfig: what is set for the stream (see ComposeStreamConfiguration)
+	others: what is set for the other streams that stay
+	roots: the configuration of each device, by device ip
+
+output:
+
+	updates: the FRER entries the stream shared with other streams, with the stream handles of the other streams
+	deletes: the paths to delete on all devices on the paths
+*/
+func ReleaseStreamConfiguration(streamConfig *StreamConfiguration, others []*StreamConfiguration, roots map[string]*st.SchemaTree) (
+	updates []*pb.Update, deletes []*pb.Path, err error) {
+
+	deletes, err = psfp.ReleaseStreamPSFP(streamConfig.PSFP, roots)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updates, frerDeletes, err := frer.ReleaseStreamFRER(streamConfig.FRER, roots)
+	if err != nil {
+		return nil, nil, err
+	}
+	deletes = append(deletes, frerDeletes...)
+
+	inUse := map[VlanMembership]bool{}
+	for _, other := range others {
+		for _, membership := range other.Vlans {
+			inUse[membership] = true
+		}
+	}
+	for _, membership := range streamConfig.Vlans {
+		if inUse[membership] {
+			continue
+		}
+		root, ok := roots[membership.DeviceIp]
+		if !ok {
+			return nil, nil, errors.New("no configuration for device " + membership.DeviceIp)
+		}
+		deletes = append(deletes, vlan.DeleteStaticVlanRegistrationEntry(root, fmt.Sprint(membership.Vid), vlanDatabaseId,
+			membership.Component, membership.Bridge, membership.Port, membership.DeviceIp))
+	}
+	return updates, deletes, nil
+}
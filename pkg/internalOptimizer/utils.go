@@ -3,14 +3,17 @@ package internalOptimizer
 import (
 	"fmt"
 	"strings"
+	qos "tsn-service/pkg/QoS"
+	framepreemption "tsn-service/pkg/RAE/FramePreemption"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	path "tsn-service/pkg/RAE/dataStructures/composit"
+	"tsn-service/pkg/RAE/dataStructures/pbMethods"
 	"tsn-service/pkg/structures/schedule"
 	"tsn-service/pkg/structures/topology"
 
 	pb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
-// TODO: Make generic paths for all the updates, they are currently built statically for our switches
-
 // Creates configuration set request from given schedule and network topology
 func createConfigurationFromSchedule(sched *schedule.Schedule, topo *topology.Topology) (*schedule.GclConfiguration, error) {
 
@@ -30,36 +33,47 @@ func createConfigurationFromSchedule(sched *schedule.Schedule, topo *topology.To
 		}
 	}
 
-	// For every object in the topology
-	//for _, node := range topology.Nodes {
-
-	// Add GCL and gating cycle for all port interfaces on device
-	//for _, port := range devicePortMap[string(node.Name)] {
-	//configMap:= schedule.ConfigMap{node.Id+"."+port.Id, sched}
-	//gclConfig.Update(configMap)
-
-	/*
-		TODO: this is to use in the config service when we talk to gnmi switch
-		// Create initial gate status updates
-		statusChange := getStatusChangeElems(port, string(node.Name), len(sched.TrafficClasses))
+	return gclConfig, nil
+}
 
-		// Create GCL entries for a specific port
-		gcl := getGclElems(sched, port, string(node.Name))
+// Compose the updates of the schedule of one port: the traffic class table, the gate control list with a guard band
+// before every express window, the gating cycle and the config change that applies them
+func composePortGcl(root *st.SchemaTree, deviceIp string, port *topology.Port, sched *schedule.Schedule) ([]*pb.Update, error) {
+	// Plan the priorities and queues of the port, and set its traffic class table
+	plan, updates, err := qos.ComposePortQoS(root, deviceIp, port, sched)
+	if err != nil {
+		return nil, err
+	}
 
-		// Create gating cycle entry for a specific port
-		adminCycle := getAdminCycleTimeElems(sched.GatingCycle, port, string(node.Name))
+	// The port speed is not always known, then no guard band can be computed
+	var guardBand uint64
+	if port.GetCapabilities().GetPortSpeed() > 0 {
+		if guardBand, err = getPortGuardBand(port); err != nil {
+			return nil, err
+		}
+	}
+	entries, errs := getGclEntries(sched, plan.GetGateStates(), guardBand)
+	if len(errs) > 0 {
+		return nil, errs
+	}
 
-		// Create extra time information and set config change to true
-		timeInfoAndConfigChange := getFinalElems(port, string(node.Name))
+	updates = append(updates, getStatusChangeElems(root, port.Name, deviceIp, len(entries))...)
+	updates = append(updates, getGclElems(root, entries, port.Name, deviceIp)...)
+	updates = append(updates, getAdminCycleTimeElems(root, sched.GatingCycle, port.Name, deviceIp)...)
+	updates = append(updates, getFinalElems(root, port.Name, deviceIp)...)
+	return updates, nil
+}
 
-		// Add all updates to set request
-		req.Update = append(req.Update, statusChange...)
-		req.Update = append(req.Update, gcl...)
-		req.Update = append(req.Update, adminCycle...)
-		req.Update = append(req.Update, timeInfoAndConfigChange...)
-	*/
-	//	}
-	return gclConfig, nil
+// Find a node and its port in the topology from node.port, the port is nil if it is not in the topology
+func getNodePort(topo *topology.Topology, nodePort string) (*topology.Node, *topology.Port) {
+	for _, node := range topo.GetNodes() {
+		for _, port := range node.GetPorts() {
+			if fmt.Sprintf("%s.%s", node.Name, port.Name) == nodePort {
+				return node, port
+			}
+		}
+	}
+	return nil, nil
 }
 
 // Finds every port on the devices
@@ -89,454 +103,210 @@ func findAllPortsOnDevices(topology *topology.Topology) (map[string][]string, er
 // Cycle time extension is statically 0 now, not sure what to do about it yet
 // Base time is statically 0 on both seconds and fractional seconds now, not sure what to do about it yet
 // Create updates for admin-cycle-time-extension, admin-base-time, and config-change
-func getFinalElems(port string, deviceIp string) []*pb.Update {
-	// Build update for admin cycle time extension
-	cycleTimeExtUpd := &pb.Update{
-		Path: &pb.Path{
-			Elem: []*pb.PathElem{
-				{
-					Name: "interfaces",
-					Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-				},
-				{
-					Name: "interface",
-					Key:  map[string]string{"name": port},
-				},
-				{
-					Name: "gate-parameters",
-					Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-				},
-				{
-					Name: "admin-cycle-time-extension",
-					Key:  map[string]string{},
-				},
-			},
-			Target: deviceIp,
-		},
-		Val: &pb.TypedValue{
-			Value: &pb.TypedValue_UintVal{
-				UintVal: 0, // This should maybe be calculated???
-			},
-		},
-	}
+func getFinalElems(root *st.SchemaTree, port string, deviceIp string) []*pb.Update {
+	cycleTimeExtTree, cycleTimeExtPb := getGateParameterPath(root, port, "admin-cycle-time-extension")
+	cycleTimeExtTree.SetUint64(0) // This should maybe be calculated???
 
-	// Build update for admin base time seconds
-	baseTimeSecondsUpd := &pb.Update{
-		Path: &pb.Path{
-			Elem: []*pb.PathElem{
-				{
-					Name: "interfaces",
-					Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-				},
-				{
-					Name: "interface",
-					Key:  map[string]string{"name": port},
-				},
-				{
-					Name: "gate-parameters",
-					Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-				},
-				{
-					Name: "admin-base-time",
-					Key:  map[string]string{},
-				},
-				{
-					Name: "seconds",
-					Key:  map[string]string{},
-				},
-			},
-			Target: deviceIp,
-		},
-		Val: &pb.TypedValue{
-			Value: &pb.TypedValue_StringVal{
-				StringVal: "0", // This should maybe be calculated???
-			},
-		},
-	}
+	baseTimeSecondsTree, baseTimeSecondsPb := getGateParameterPath(root, port, "admin-base-time", "seconds")
+	baseTimeSecondsTree.SetString("0") // This should maybe be calculated???
 
-	// Build update for admin base time fractional seconds
-	baseTimeFractionalSecondsUpd := &pb.Update{
-		Path: &pb.Path{
-			Elem: []*pb.PathElem{
-				{
-					Name: "interfaces",
-					Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-				},
-				{
-					Name: "interface",
-					Key:  map[string]string{"name": port},
-				},
-				{
-					Name: "gate-parameters",
-					Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-				},
-				{
-					Name: "admin-base-time",
-					Key:  map[string]string{},
-				},
-				{
-					Name: "fractional-seconds",
-					Key:  map[string]string{},
-				},
-			},
-			Target: deviceIp,
-		},
-		Val: &pb.TypedValue{
-			Value: &pb.TypedValue_StringVal{
-				StringVal: "0", // This should maybe be calculated???
-			},
-		},
-	}
+	baseTimeFractionalSecondsTree, baseTimeFractionalSecondsPb := getGateParameterPath(root, port, "admin-base-time", "fractional-seconds")
+	baseTimeFractionalSecondsTree.SetString("0") // This should maybe be calculated???
 
-	// Build update for config change
-	confChangeUpd := &pb.Update{
-		Path: &pb.Path{
-			Elem: []*pb.PathElem{
-				{
-					Name: "interfaces",
-					Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-				},
-				{
-					Name: "interface",
-					Key:  map[string]string{"name": port},
-				},
-				{
-					Name: "gate-parameters",
-					Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-				},
-				{
-					Name: "config-change",
-					Key:  map[string]string{},
-				},
-			},
-			Target: deviceIp,
-		},
-		Val: &pb.TypedValue{
-			Value: &pb.TypedValue_BoolVal{
-				BoolVal: true,
-			},
-		},
-	}
+	confChangeTree, confChangePb := getGateParameterPath(root, port, "config-change")
+	confChangeTree.SetBool(true)
 
-	return []*pb.Update{cycleTimeExtUpd, baseTimeSecondsUpd, baseTimeFractionalSecondsUpd, confChangeUpd}
+	return []*pb.Update{
+		pbMethods.GetUpdate(deviceIp, cycleTimeExtPb, pbMethods.GetPbUintTypeVal(0)),
+		pbMethods.GetUpdate(deviceIp, baseTimeSecondsPb, pbMethods.GetPbStringTypeVal("0")),
+		pbMethods.GetUpdate(deviceIp, baseTimeFractionalSecondsPb, pbMethods.GetPbStringTypeVal("0")),
+		pbMethods.GetUpdate(deviceIp, confChangePb, pbMethods.GetPbBoolTypeVal(true)),
+	}
 }
 
 // Create updates for gate-enabled, admin-gate-states, and admin-control-list-length
-func getStatusChangeElems(port string, deviceIp string, numOfTrafficClassEntries int) []*pb.Update {
-	// Build update for gate enabled
-	gateEnabledUpd := &pb.Update{
-		Path: &pb.Path{
-			Elem: []*pb.PathElem{
-				{
-					Name: "interfaces",
-					Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-				},
-				{
-					Name: "interface",
-					Key:  map[string]string{"name": port},
-				},
-				{
-					Name: "gate-parameters",
-					Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-				},
-				{
-					Name: "gate-enabled",
-					Key:  map[string]string{},
-				},
-			},
-			Target: deviceIp,
-		},
-		Val: &pb.TypedValue{
-			Value: &pb.TypedValue_BoolVal{
-				BoolVal: true,
-			},
-		},
-	}
+func getStatusChangeElems(root *st.SchemaTree, port string, deviceIp string, numOfTrafficClassEntries int) []*pb.Update {
+	gateEnabledTree, gateEnabledPb := getGateParameterPath(root, port, "gate-enabled")
+	gateEnabledTree.SetBool(true)
+
+	// Statically set all gates to be open for their initial state
+	gateStatesTree, gateStatesPb := getGateParameterPath(root, port, "admin-gate-states")
+	gateStatesTree.SetUint64(255)
 
-	// Build update for admin gate states
-	gateStatesUpd := &pb.Update{
-		Path: &pb.Path{
-			Elem: []*pb.PathElem{
-				{
-					Name: "interfaces",
-					Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-				},
-				{
-					Name: "interface",
-					Key:  map[string]string{"name": port},
-				},
-				{
-					Name: "gate-parameters",
-					Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-				},
-				{
-					Name: "admin-gate-states",
-					Key:  map[string]string{},
-				},
-			},
-			Target: deviceIp,
-		},
-		Val: &pb.TypedValue{
-			Value: &pb.TypedValue_UintVal{
-				UintVal: 255, // Statically set all gates to be open for their initial state
-			},
-		},
+	controlListLenTree, controlListLenPb := getGateParameterPath(root, port, "admin-control-list-length")
+	controlListLenTree.SetUint64(uint64(numOfTrafficClassEntries))
+
+	return []*pb.Update{
+		pbMethods.GetUpdate(deviceIp, gateEnabledPb, pbMethods.GetPbBoolTypeVal(true)),
+		pbMethods.GetUpdate(deviceIp, gateStatesPb, pbMethods.GetPbUintTypeVal(255)),
+		pbMethods.GetUpdate(deviceIp, controlListLenPb, pbMethods.GetPbUintTypeVal(uint(numOfTrafficClassEntries))),
 	}
+}
+
+/*
+Get both the tree and the pb path to a parameter of the gate parameters of a port, below the elements names (e.g. admin-base-time, seconds)
+The interfaces and the gate parameters are named by their module, so they are in its namespace whatever the adapter registered
+
+key parameters:
 
-	// Build update for admin control list length
-	controlListLenUpd := &pb.Update{
-		Path: &pb.Path{
-			Elem: []*pb.PathElem{
-				{
-					Name: "interfaces",
-					Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-				},
-				{
-					Name: "interface",
-					Key:  map[string]string{"name": port},
-				},
-				{
-					Name: "gate-parameters",
-					Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-				},
-				{
-					Name: "admin-control-list-length",
-					Key:  map[string]string{},
-				},
-			},
-			Target: deviceIp,
-		},
-		Val: &pb.TypedValue{
-			Value: &pb.TypedValue_UintVal{
-				UintVal: uint64(numOfTrafficClassEntries),
-			},
-		},
+	port
+*/
+func getGateParameterPath(root *st.SchemaTree, port string, names ...string) (*st.SchemaTree, []*pb.PathElem) {
+	pathLvl1Tree, pathLvl1Pb := path.GetParam0Keys(root, nil, "ietf-interfaces:interfaces")
+	pathLvl2Tree, pathLvl2Pb := path.GetParamKeys(pathLvl1Tree, pathLvl1Pb, "", "interface", map[string]string{"name": port})
+	pathTree, pathPb := path.GetParam0Keys(pathLvl2Tree, pathLvl2Pb, "ieee802-dot1q-sched:gate-parameters")
+	for _, name := range names {
+		pathTree, pathPb = path.GetParam0Keys(pathTree, pathPb, name)
 	}
+	return pathTree, pathPb
+}
 
-	return []*pb.Update{gateEnabledUpd, gateStatesUpd, controlListLenUpd}
+// One entry of the gate control list
+type gclEntry struct {
+	trafficClass string // empty for a guard band
+	gateStates   uint64
+	interval     uint64 // ns
 }
 
-// Create updates for operation-name, gate-states-value, and time-interval-value, for every traffic class in schedule
-func getGclElems(sched *schedule.Schedule, port string, deviceIp string) []*pb.Update {
+// Create updates for operation-name, gate-states-value, and time-interval-value, for every entry of the gate control list
+func getGclElems(root *st.SchemaTree, entries []gclEntry, port string, deviceIp string) []*pb.Update {
 	var updates []*pb.Update
-	// For every traffic class, create an entry in the admin-control-list
+	for index, entry := range entries {
+		updates = append(updates, getGclEntryElems(root, index, entry.gateStates, entry.interval, port, deviceIp)...)
+	}
+
+	return updates
+}
+
+// Create updates for operation-name, gate-states-value, and time-interval-value of one entry in the admin-control-list
+func getGclEntryElems(root *st.SchemaTree, index int, gateStates uint64, interval uint64, port string, deviceIp string) []*pb.Update {
+	entryTree, entryPb := getGateParameterPath(root, port)
+	entryTree, entryPb = path.GetParamKeys(entryTree, entryPb, "", "admin-control-list", map[string]string{"index": fmt.Sprint(index)})
+
+	operationTree, operationPb := path.GetParam0Keys(entryTree, entryPb, "operation-name")
+	operationTree.SetString("set-gate-states")
+
+	sgsParamsTree, sgsParamsPb := path.GetParam0Keys(entryTree, entryPb, "sgs-params")
+	gateStatesTree, gateStatesPb := path.GetParam0Keys(sgsParamsTree, sgsParamsPb, "gate-states-value")
+	gateStatesTree.SetUint64(gateStates)
+	timeIntervalTree, timeIntervalPb := path.GetParam0Keys(sgsParamsTree, sgsParamsPb, "time-interval-value")
+	timeIntervalTree.SetUint64(interval)
+
+	return []*pb.Update{
+		pbMethods.GetUpdate(deviceIp, operationPb, pbMethods.GetPbStringTypeVal("set-gate-states")),
+		pbMethods.GetUpdate(deviceIp, gateStatesPb, pbMethods.GetPbUintTypeVal(uint(gateStates))),
+		pbMethods.GetUpdate(deviceIp, timeIntervalPb, pbMethods.GetPbUintTypeVal(uint(interval))),
+	}
+}
+
+/*
+Get the entries of the gate control list of a port, one window per traffic class in the order of the schedule
+A guard band entry with all gates closed is put before every express window, so a frame started in the window before
+it can not run into it. The guard band is taken from the end of the window before (nothing is needed if that window is
+express as well), and the window of the last traffic class gives the guard band of the first.
+
+input:
+
+	sched: the schedule
+	gateStates: the gate states of every traffic class, by name (see qos.ComposePortQoS)
+	guardBand: the guard band of the port in ns (see getPortGuardBand)
+
+output:
+
+	entries: the entries of the gate control list
+	errs: a ScheduleError for every window that the guard band after it leaves no time, nil if there is none
+*/
+func getGclEntries(sched *schedule.Schedule, gateStates map[string]uint64, guardBand uint64) ([]gclEntry, ScheduleErrors) {
+	var errs ScheduleErrors
+	intervals := sched.GetIntervals()
+	guardBands := make([]uint64, len(intervals))
 	for index, trafficClass := range sched.TrafficClasses {
-		// Build update for type of operation
-		operationUpd := &pb.Update{
-			Path: &pb.Path{
-				Elem: []*pb.PathElem{
-					{
-						Name: "interfaces",
-						Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-					},
-					{
-						Name: "interface",
-						Key:  map[string]string{"name": port},
-					},
-					{
-						Name: "gate-parameters",
-						Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-					},
-					{
-						Name: "admin-control-list",
-						Key:  map[string]string{"index": fmt.Sprint(index)},
-					},
-					{
-						Name: "operation-name",
-						Key:  map[string]string{},
-					},
-				},
-				Target: deviceIp,
-			},
-			Val: &pb.TypedValue{
-				Value: &pb.TypedValue_StringVal{
-					StringVal: "set-gate-states",
-				},
-			},
+		previous := (index + len(intervals) - 1) % len(intervals)
+		if framepreemption.GetPreemptionStatus(trafficClass.Name) != framepreemption.Express ||
+			framepreemption.GetPreemptionStatus(sched.TrafficClasses[previous].Name) == framepreemption.Express {
+			continue
 		}
 
-		// Build update for gate states
-		gateStateUpd := &pb.Update{
-			Path: &pb.Path{
-				Elem: []*pb.PathElem{
-					{
-						Name: "interfaces",
-						Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-					},
-					{
-						Name: "interface",
-						Key:  map[string]string{"name": port},
-					},
-					{
-						Name: "gate-parameters",
-						Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-					},
-					{
-						Name: "admin-control-list",
-						Key:  map[string]string{"index": fmt.Sprint(index)},
-					},
-					{
-						Name: "sgs-params",
-						Key:  map[string]string{},
-					},
-					{
-						Name: "gate-states-value",
-						Key:  map[string]string{},
-					},
-				},
-				Target: deviceIp,
-			},
-			Val: &pb.TypedValue{
-				Value: &pb.TypedValue_UintVal{
-					UintVal: getGateStatesValue(trafficClass.Name),
-				},
-			},
+		if intervals[previous] <= guardBand {
+			errs = append(errs, &ScheduleError{TrafficClass: sched.TrafficClasses[previous].Name,
+				Reason: "the window is " + fmt.Sprint(intervals[previous]) + " ns, the guard band of " + fmt.Sprint(guardBand) +
+					" ns before the express window of traffic class " + trafficClass.Name + " leaves no time for it"})
+			continue
 		}
+		guardBands[index] = guardBand
+		intervals[previous] -= guardBand
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 
-		// Build update for time interval
-		timeIntervalUpd := &pb.Update{
-			Path: &pb.Path{
-				Elem: []*pb.PathElem{
-					{
-						Name: "interfaces",
-						Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-					},
-					{
-						Name: "interface",
-						Key:  map[string]string{"name": port},
-					},
-					{
-						Name: "gate-parameters",
-						Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-					},
-					{
-						Name: "admin-control-list",
-						Key:  map[string]string{"index": fmt.Sprint(index)},
-					},
-					{
-						Name: "sgs-params",
-						Key:  map[string]string{},
-					},
-					{
-						Name: "time-interval-value",
-						Key:  map[string]string{},
-					},
-				},
-				Target: deviceIp,
-			},
-			Val: &pb.TypedValue{
-				Value: &pb.TypedValue_UintVal{
-					UintVal: getInterval(trafficClass.AssignedPortion, sched.GatingCycle),
-				},
-			},
+	var entries []gclEntry
+	for index, trafficClass := range sched.TrafficClasses {
+		if index > 0 && guardBands[index] > 0 {
+			entries = append(entries, gclEntry{interval: guardBands[index]})
 		}
-
-		updates = append(updates, operationUpd)
-		updates = append(updates, gateStateUpd)
-		updates = append(updates, timeIntervalUpd)
+		entries = append(entries, gclEntry{trafficClass: trafficClass.Name, gateStates: gateStates[trafficClass.Name], interval: intervals[index]})
+	}
+	if len(guardBands) > 0 && guardBands[0] > 0 {
+		entries = append(entries, gclEntry{interval: guardBands[0]})
 	}
+	return entries, nil
+}
 
-	return updates
+// Get the guard band in nanoseconds the port needs before express windows, from its MTU and port speed
+// The guard band is shorter if the port can preempt frames
+func getPortGuardBand(port *topology.Port) (uint64, error) {
+	return framepreemption.GetGuardBand(uint(port.GetCapabilities().GetPortSpeed()),
+		uint(max(port.GetCapabilities().GetMaximumTransmissionUnit(), 0)), port.GetCapabilities().GetSupportsFramePreemption())
 }
 
-// Get gate state values if traffic class matches a predefined traffic class (best effort will open two gates)
-func getGateStatesValue(trafficClassName string) uint64 {
-	switch trafficClassName {
-	case "isochronous":
-		return 128
-	case "cyclic-sync":
-		return 64
-	case "cyclic-async":
-		return 32
-	case "alarms-events":
-		return 16
-	case "config-diag":
-		return 8
-	case "network-control":
-		return 4
-	case "best-effort":
-		return 3
-	default:
-		//log.Errorf("Traffic class was not found: %v", errors.New("traffic class did not match any of the predefined traffic classes, all gates will be closed"))
+// Get the frame preemption status of every priority from the traffic classes of the schedule
+// The priorities of an express traffic class are express
+func getPriorityPreemptionStatuses(sched *schedule.Schedule) ([]string, error) {
+	priorities, err := qos.GetPriorityMapping(sched)
+	if err != nil {
+		return nil, err
 	}
 
-	return 0
+	statuses := make([]string, 8)
+	for priority := range statuses {
+		statuses[priority] = framepreemption.Preemptable
+	}
+	for name, classPriorities := range priorities {
+		if framepreemption.GetPreemptionStatus(name) != framepreemption.Express {
+			continue
+		}
+		for _, priority := range classPriorities {
+			statuses[priority] = framepreemption.Express
+		}
+	}
+	return statuses, nil
 }
 
-// Get interval in nanoseconds from scheduled percentage of gatingcycle
-func getInterval(assignedPercentage int32, gatingCycle float32) uint64 {
-	return uint64(gatingCycle * 1000000 * (float32(assignedPercentage) / 100))
+// Create updates for admin-cycle-time (numerator and denominator) from the gating cycle in ns
+// The fraction of a second is reduced, so a gating cycle of 1 ms is 1/1000
+func getAdminCycleTimeElems(root *st.SchemaTree, gatingCycle uint64, port string, deviceIp string) []*pb.Update {
+	divisor := getGreatestCommonDivisor(gatingCycle, 1000000000)
+	return getCycleTimeElems(root, int64(gatingCycle/divisor), int64(1000000000/divisor), port, deviceIp)
 }
 
-// Create updates for admin-cycle-time (numerator and denominator)
-func getAdminCycleTimeElems(gatingCycle float32, port string, deviceIp string) []*pb.Update {
-	// Create update element for numerator
-	numeratorUpd := &pb.Update{
-		Path: &pb.Path{
-			Elem: []*pb.PathElem{
-				{
-					Name: "interfaces",
-					Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-				},
-				{
-					Name: "interface",
-					Key:  map[string]string{"name": port},
-				},
-				{
-					Name: "gate-parameters",
-					Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-				},
-				{
-					Name: "admin-cycle-time",
-					Key:  map[string]string{},
-				},
-				{
-					Name: "numerator",
-					Key:  map[string]string{},
-				},
-			},
-			Target: deviceIp,
-		},
-		Val: &pb.TypedValue{
-			Value: &pb.TypedValue_IntVal{
-				IntVal: int64(gatingCycle),
-			},
-		},
+func getGreatestCommonDivisor(a uint64, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
 	}
+	return a
+}
 
-	// Create update element for denominator
-	denominatorUpd := &pb.Update{
-		Path: &pb.Path{
-			Elem: []*pb.PathElem{
-				{
-					Name: "interfaces",
-					Key:  map[string]string{"namespace": "urn:ietf:params:xml:ns:yang:ietf-interfaces"},
-				},
-				{
-					Name: "interface",
-					Key:  map[string]string{"name": port},
-				},
-				{
-					Name: "gate-parameters",
-					Key:  map[string]string{"namespace": "urn:ieee:std:802.1Q:yang:ieee802-dot1q-sched"},
-				},
-				{
-					Name: "admin-cycle-time",
-					Key:  map[string]string{},
-				},
-				{
-					Name: "denominator",
-					Key:  map[string]string{},
-				},
-			},
-			Target: deviceIp,
-		},
-		Val: &pb.TypedValue{
-			Value: &pb.TypedValue_IntVal{
-				IntVal: 1000, // 1000 ensures the gating cycle is in milliseconds
-			},
-		},
-	}
+// Create updates for admin-cycle-time, the cycle time is numerator/denominator seconds
+func getCycleTimeElems(root *st.SchemaTree, numerator int64, denominator int64, port string, deviceIp string) []*pb.Update {
+	numeratorTree, numeratorPb := getGateParameterPath(root, port, "admin-cycle-time", "numerator")
+	numeratorTree.SetInt64(numerator)
+
+	denominatorTree, denominatorPb := getGateParameterPath(root, port, "admin-cycle-time", "denominator")
+	denominatorTree.SetInt64(denominator)
 
-	return []*pb.Update{numeratorUpd, denominatorUpd}
+	return []*pb.Update{
+		pbMethods.GetUpdate(deviceIp, numeratorPb, pbMethods.GetPbIntTypeVal(int(numerator))),
+		pbMethods.GetUpdate(deviceIp, denominatorPb, pbMethods.GetPbIntTypeVal(int(denominator))),
+	}
 }
@@ -0,0 +1,156 @@
+package internalOptimizer
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/structures/schedule"
+)
+
+// A schedule with the traffic classes in this order, each with its assigned portion
+func getTestSchedule(gatingCycle uint64, portions ...portion) *schedule.Schedule {
+	sched := &schedule.Schedule{GatingCycle: gatingCycle}
+	for _, p := range portions {
+		sched.TrafficClasses = append(sched.TrafficClasses, &schedule.TrafficClass{Name: p.name, AssignedPortion: p.percent})
+	}
+	return sched
+}
+
+type portion struct {
+	name    string
+	percent int32
+}
+
+func TestGetGclEntries(t *testing.T) {
+	tests := []struct {
+		name      string
+		sched     *schedule.Schedule
+		guardBand uint64
+		want      []gclEntry
+	}{
+		{
+			name:      "guard band before the express window",
+			sched:     getTestSchedule(100000, portion{"best-effort", 50}, portion{"isochronous", 50}),
+			guardBand: 1000,
+			want: []gclEntry{
+				{trafficClass: "best-effort", interval: 49000},
+				{interval: 1000},
+				{trafficClass: "isochronous", interval: 50000},
+			},
+		},
+		{
+			// The last window gives the guard band of the first, it ends the cycle
+			name:      "express window first",
+			sched:     getTestSchedule(100000, portion{"isochronous", 50}, portion{"best-effort", 50}),
+			guardBand: 1000,
+			want: []gclEntry{
+				{trafficClass: "isochronous", interval: 50000},
+				{trafficClass: "best-effort", interval: 49000},
+				{interval: 1000},
+			},
+		},
+		{
+			name:      "consecutive express windows",
+			sched:     getTestSchedule(100000, portion{"best-effort", 40}, portion{"isochronous", 30}, portion{"cyclic-sync", 30}),
+			guardBand: 1000,
+			want: []gclEntry{
+				{trafficClass: "best-effort", interval: 39000},
+				{interval: 1000},
+				{trafficClass: "isochronous", interval: 30000},
+				{trafficClass: "cyclic-sync", interval: 30000},
+			},
+		},
+		{
+			name:      "only express windows",
+			sched:     getTestSchedule(100000, portion{"isochronous", 50}, portion{"cyclic-sync", 50}),
+			guardBand: 1000,
+			want: []gclEntry{
+				{trafficClass: "isochronous", interval: 50000},
+				{trafficClass: "cyclic-sync", interval: 50000},
+			},
+		},
+		{
+			// The port speed is not known
+			name:      "no guard band",
+			sched:     getTestSchedule(100000, portion{"best-effort", 50}, portion{"isochronous", 50}),
+			guardBand: 0,
+			want: []gclEntry{
+				{trafficClass: "best-effort", interval: 50000},
+				{trafficClass: "isochronous", interval: 50000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, errs := getGclEntries(tt.sched, nil, tt.guardBand)
+			if len(errs) > 0 {
+				t.Fatalf("getGclEntries() error: %v", errs)
+			}
+			if !slices.Equal(entries, tt.want) {
+				t.Errorf("getGclEntries() = %+v, want %+v", entries, tt.want)
+			}
+		})
+	}
+}
+
+// A guard band that takes the whole window before it is an error, not a window of 0 ns
+func TestGetGclEntriesNoTimeLeft(t *testing.T) {
+	tests := []struct {
+		name  string
+		sched *schedule.Schedule
+		want  []string // the traffic classes with an error
+	}{
+		{name: "window as long as the guard band", sched: getTestSchedule(100000, portion{"best-effort", 1}, portion{"isochronous", 99}), want: []string{"best-effort"}},
+		{name: "window shorter than the guard band", sched: getTestSchedule(50000, portion{"isochronous", 99}, portion{"best-effort", 1}), want: []string{"best-effort"}},
+		{name: "two windows", sched: getTestSchedule(100000, portion{"best-effort", 1}, portion{"isochronous", 49}, portion{"video", 1}, portion{"cyclic-sync", 49}),
+			want: []string{"best-effort", "video"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, errs := getGclEntries(tt.sched, nil, 1000)
+			var classes []string
+			for _, err := range errs {
+				classes = append(classes, err.TrafficClass)
+			}
+			if !slices.Equal(classes, tt.want) {
+				t.Errorf("getGclEntries() errors = %v, want errors for %v", errs, tt.want)
+			}
+			if entries != nil {
+				t.Errorf("getGclEntries() = %+v with the errors, want no entries", entries)
+			}
+		})
+	}
+}
+
+// The gating cycle is set as a reduced fraction of a second
+func TestGetAdminCycleTimeElems(t *testing.T) {
+	tests := []struct {
+		gatingCycle uint64 // ns
+		numerator   int64
+		denominator int64
+	}{
+		{gatingCycle: 1000000, numerator: 1, denominator: 1000},
+		{gatingCycle: 250000, numerator: 1, denominator: 4000},
+		{gatingCycle: 1500000, numerator: 3, denominator: 2000},
+		{gatingCycle: 333333, numerator: 333333, denominator: 1000000000},
+		{gatingCycle: 2000000000, numerator: 2, denominator: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.gatingCycle), func(t *testing.T) {
+			root := &st.SchemaTree{Name: "data", Kind: st.KindContainer}
+			values := map[string]int64{}
+			for _, update := range getAdminCycleTimeElems(root, tt.gatingCycle, "sw0p1", "10.0.0.1") {
+				elems := update.GetPath().GetElem()
+				values[elems[len(elems)-1].GetName()] = update.GetVal().GetIntVal()
+			}
+			if values["numerator"] != tt.numerator || values["denominator"] != tt.denominator {
+				t.Errorf("admin-cycle-time of %d ns = %d/%d, want %d/%d", tt.gatingCycle,
+					values["numerator"], values["denominator"], tt.numerator, tt.denominator)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package internalOptimizer
+
+/*
+Validate the schedules before they are stored, a schedule that passes can be turned into a gate control list for the port:
+
+	schedule: a mode (gcl or cqf), a gating cycle (ns) of at least MinGatingCycle that fits a time interval of the gate control list, traffic classes with unique names
+	          whose assigned portions sum to 100%, and a priority (PCP) for every traffic class (see qos.GetPriorityMapping)
+	port:     the port is in the topology, every window fits a maximum-size frame at the port speed and the guard band after it,
+	          and the gates of different traffic classes are not opened together (they do not share a queue)
+*/
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	qos "tsn-service/pkg/QoS"
+	framepreemption "tsn-service/pkg/RAE/FramePreemption"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+)
+
+// The shortest gating cycle (ns), 10 us. The gating cycle used to be given in ms, such a value is far below it
+const MinGatingCycle = 10000
+
+// A problem found in a schedule
+type ScheduleError struct {
+	NodePort     string // empty if the problem is in the schedule itself, not at a port
+	TrafficClass string // empty if the problem is not caused by one traffic class
+	Reason       string
+}
+
+func (e *ScheduleError) Error() string {
+	var prefix []string
+	if e.NodePort != "" {
+		prefix = append(prefix, "port "+e.NodePort)
+	}
+	if e.TrafficClass != "" {
+		prefix = append(prefix, "traffic class "+e.TrafficClass)
+	}
+	if len(prefix) == 0 {
+		return e.Reason
+	}
+	return strings.Join(prefix, ", ") + ": " + e.Reason
+}
+
+// All problems found in a schedule or configuration
+type ScheduleErrors []*ScheduleError
+
+func (errs ScheduleErrors) Error() string {
+	var reasons []string
+	for _, err := range errs {
+		reasons = append(reasons, err.Error())
+	}
+	return "invalid schedule: " + strings.Join(reasons, "; ")
+}
+
+/*
+Validate a schedule without a port, as when it is loaded
+
+output:
+
+	err: ScheduleErrors with every problem that is found, nil if there is none
+*/
+func ValidateSchedule(sched *schedule.Schedule) error {
+	if errs := validateSchedule(sched, ""); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+/*
+Validate the schedule of every port in a configuration, before it is stored
+
+input:
+
+	gclConfig: the schedule of each port
+	topo: the topology the ports are in, with their speed and number of queues
+
+output:
+
+	err: ScheduleErrors with every problem that is found, by port, nil if there is none
+*/
+func ValidateGclConfiguration(gclConfig *schedule.GclConfiguration, topo *topology.Topology) error {
+	ports := map[string]*topology.Port{}
+	for _, node := range topo.GetNodes() {
+		for _, port := range node.GetPorts() {
+			ports[fmt.Sprintf("%s.%s", node.Name, port.Name)] = port
+		}
+	}
+
+	var errs ScheduleErrors
+	for _, configMap := range gclConfig.GetConfigs() {
+		port, ok := ports[configMap.NodePort]
+		if !ok {
+			errs = append(errs, &ScheduleError{NodePort: configMap.NodePort, Reason: "the port is not in the topology"})
+			continue
+		}
+
+		schedErrs := validateSchedule(configMap.Sched, configMap.NodePort)
+		if len(schedErrs) > 0 {
+			errs = append(errs, schedErrs...)
+			continue
+		}
+		errs = append(errs, validatePortSchedule(configMap.Sched, port, configMap.NodePort)...)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Check the schedule itself, nodePort is only used in the errors
+func validateSchedule(sched *schedule.Schedule, nodePort string) (errs ScheduleErrors) {
+	if sched == nil {
+		return ScheduleErrors{{NodePort: nodePort, Reason: "there is no schedule"}}
+	}
+	if sched.GatingCycle < MinGatingCycle || sched.GatingCycle > math.MaxUint32 {
+		errs = append(errs, &ScheduleError{NodePort: nodePort,
+			Reason: "Invalid gating cycle. Value: " + fmt.Sprint(sched.GatingCycle) + " ns. Range is [" + fmt.Sprint(MinGatingCycle) + "-" +
+				fmt.Sprint(uint64(math.MaxUint32)) + "]. The gating cycle is in ns, not in ms as before"})
+	}
+	if sched.Mode != "" && sched.Mode != ModeGcl && sched.Mode != ModeCqf {
+		errs = append(errs, &ScheduleError{NodePort: nodePort,
+			Reason: "Invalid mode. Value: " + sched.Mode + ". The mode should be one of the following values: \"" + ModeGcl + "\", \"" + ModeCqf + "\""})
+	}
+	if len(sched.TrafficClasses) == 0 {
+		return append(errs, &ScheduleError{NodePort: nodePort, Reason: "the schedule has no traffic classes"})
+	}
+
+	var total int32
+	names := map[string]bool{}
+	for _, trafficClass := range sched.TrafficClasses {
+		if trafficClass.Name == "" {
+			errs = append(errs, &ScheduleError{NodePort: nodePort, Reason: "a traffic class has no name"})
+		} else if names[trafficClass.Name] {
+			errs = append(errs, &ScheduleError{NodePort: nodePort, TrafficClass: trafficClass.Name,
+				Reason: "the traffic class is more than once in the schedule"})
+		}
+		names[trafficClass.Name] = true
+
+		if trafficClass.AssignedPortion <= 0 || trafficClass.AssignedPortion > 100 {
+			errs = append(errs, &ScheduleError{NodePort: nodePort, TrafficClass: trafficClass.Name,
+				Reason: "Invalid assigned portion. Value: " + fmt.Sprint(trafficClass.AssignedPortion) + "%. Range is [1-100]"})
+		}
+		total += trafficClass.AssignedPortion
+	}
+	if total != 100 {
+		errs = append(errs, &ScheduleError{NodePort: nodePort,
+			Reason: "the assigned portions sum to " + fmt.Sprint(total) + "%, must be 100%"})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// Every traffic class needs a priority, otherwise its gates are never opened
+	if _, err := qos.GetPriorityMapping(sched); err != nil {
+		errs = append(errs, &ScheduleError{NodePort: nodePort, Reason: err.Error()})
+	}
+	return errs
+}
+
+// Check that the schedule can be used at a port
+func validatePortSchedule(sched *schedule.Schedule, port *topology.Port, nodePort string) (errs ScheduleErrors) {
+	// The port speed is not always known (e.g. at end stations), then the windows can not be checked
+	if portSpeed := uint(port.GetCapabilities().GetPortSpeed()); portSpeed > 0 {
+		guardBand, err := getPortGuardBand(port)
+		if err != nil {
+			return ScheduleErrors{{NodePort: nodePort, Reason: err.Error()}}
+		}
+		maxFrameTime, err := framepreemption.GetGuardBand(portSpeed,
+			uint(max(port.GetCapabilities().GetMaximumTransmissionUnit(), 0)), false)
+		if err != nil {
+			return ScheduleErrors{{NodePort: nodePort, Reason: err.Error()}}
+		}
+
+		entries, guardBandErrs := getGclEntries(sched, nil, guardBand)
+		for _, err := range guardBandErrs {
+			err.NodePort = nodePort
+		}
+		errs = append(errs, guardBandErrs...)
+		for _, entry := range entries {
+			if entry.trafficClass != "" && entry.interval < maxFrameTime {
+				errs = append(errs, &ScheduleError{NodePort: nodePort, TrafficClass: entry.trafficClass,
+					Reason: "the window is " + fmt.Sprint(entry.interval) + " ns, shorter than a maximum-size frame (" +
+						fmt.Sprint(maxFrameTime) + " ns at " + fmt.Sprint(portSpeed) + " Mbps)"})
+			}
+		}
+	}
+
+	// The number of queues is not always known, then the gates can not be checked
+	if port.NumberOfQueues == 0 {
+		return errs
+	}
+	plan, err := qos.GetPortPlan(sched, int(port.NumberOfQueues))
+	if err != nil {
+		return append(errs, &ScheduleError{NodePort: nodePort, Reason: err.Error()})
+	}
+	gateStates := plan.GetGateStates()
+	for i, trafficClass := range sched.TrafficClasses {
+		for _, other := range sched.TrafficClasses[:i] {
+			if gateStates[trafficClass.Name]&gateStates[other.Name] != 0 {
+				errs = append(errs, &ScheduleError{NodePort: nodePort, TrafficClass: trafficClass.Name,
+					Reason: "the gates overlap with traffic class " + other.Name + ", they share a queue (the port has " +
+						fmt.Sprint(port.NumberOfQueues) + " queues)"})
+			}
+		}
+	}
+	return errs
+}
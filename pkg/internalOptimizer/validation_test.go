@@ -0,0 +1,140 @@
+package internalOptimizer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+)
+
+// Get the port and traffic class of every problem in an error of ValidateSchedule or ValidateGclConfiguration, as port/traffic class
+func getTestScheduleErrors(t *testing.T, err error) (problems []string) {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+	var errs ScheduleErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error %v is not a ScheduleErrors", err)
+	}
+	for _, schedErr := range errs {
+		problems = append(problems, schedErr.NodePort+"/"+schedErr.TrafficClass)
+	}
+	return problems
+}
+
+func TestValidateSchedule(t *testing.T) {
+	// One traffic class more than there are priorities
+	tooManyClasses := getTestSchedule(1000000)
+	for index := range 9 {
+		tooManyClasses.TrafficClasses = append(tooManyClasses.TrafficClasses,
+			&schedule.TrafficClass{Name: fmt.Sprint("class", index), AssignedPortion: int32(12 - 8*(index/8))})
+	}
+
+	tests := []struct {
+		name   string
+		sched  *schedule.Schedule
+		want   []string // port/traffic class of every problem
+		reason string   // in the error
+	}{
+		{name: "valid", sched: getTestSchedule(1000000, portion{"isochronous", 50}, portion{"best-effort", 50})},
+		{name: "valid cqf", sched: &schedule.Schedule{GatingCycle: 1000000, Mode: ModeCqf,
+			TrafficClasses: []*schedule.TrafficClass{{Name: "best-effort", AssignedPortion: 100}}}},
+		{name: "no schedule", sched: nil, want: []string{"/"}, reason: "there is no schedule"},
+		{name: "no gating cycle", sched: getTestSchedule(0, portion{"best-effort", 100}), want: []string{"/"}, reason: "Invalid gating cycle"},
+		{name: "gating cycle in ms", sched: getTestSchedule(1, portion{"best-effort", 100}), want: []string{"/"}, reason: "not in ms"},
+		{name: "gating cycle too long", sched: getTestSchedule(math.MaxUint32+1, portion{"best-effort", 100}), want: []string{"/"}, reason: "Invalid gating cycle"},
+		{name: "unknown mode", sched: &schedule.Schedule{GatingCycle: 1000000, Mode: "cbs",
+			TrafficClasses: []*schedule.TrafficClass{{Name: "best-effort", AssignedPortion: 100}}}, want: []string{"/"}, reason: "Invalid mode"},
+		{name: "no traffic classes", sched: getTestSchedule(1000000), want: []string{"/"}, reason: "no traffic classes"},
+		{name: "traffic class without name", sched: getTestSchedule(1000000, portion{"", 50}, portion{"best-effort", 50}), want: []string{"/"}, reason: "no name"},
+		{name: "traffic class twice", sched: getTestSchedule(1000000, portion{"best-effort", 50}, portion{"best-effort", 50}),
+			want: []string{"/best-effort"}, reason: "more than once"},
+		{name: "portion of 0", sched: getTestSchedule(1000000, portion{"isochronous", 0}, portion{"best-effort", 100}),
+			want: []string{"/isochronous"}, reason: "Invalid assigned portion"},
+		{name: "portions do not sum to 100", sched: getTestSchedule(1000000, portion{"isochronous", 40}, portion{"best-effort", 50}),
+			want: []string{"/"}, reason: "sum to 90%"},
+		{name: "every problem", sched: getTestSchedule(0, portion{"isochronous", 101}, portion{"isochronous", 0}),
+			want: []string{"/", "/isochronous", "/isochronous", "/isochronous", "/"}},
+		{name: "no priority left", sched: tooManyClasses, want: []string{"/"}, reason: "no priority is left for traffic class class8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSchedule(tt.sched)
+			if problems := getTestScheduleErrors(t, err); !slices.Equal(problems, tt.want) {
+				t.Errorf("ValidateSchedule() = %v, want problems at %v", err, tt.want)
+			}
+			if tt.reason != "" && (err == nil || !strings.Contains(err.Error(), tt.reason)) {
+				t.Errorf("ValidateSchedule() = %v, want %q in the error", err, tt.reason)
+			}
+		})
+	}
+}
+
+/*
+The ports of a bridge, a maximum-size frame of 1542 octets on the wire takes 123360 ns at 100 Mbps:
+
+	p1: 100 Mbps, 8 queues
+	p2: speed not known, 1 queue
+	p3: 100 Mbps, number of queues not known
+*/
+func getTestTopology() *topology.Topology {
+	capabilities := &topology.InterfaceCapabilities{PortSpeed: 100, MaximumTransmissionUnit: 1500}
+	return &topology.Topology{Nodes: []*topology.Node{{
+		Name: "sw0",
+		Ports: []*topology.Port{
+			{Name: "p1", NumberOfQueues: 8, Capabilities: capabilities},
+			{Name: "p2", NumberOfQueues: 1},
+			{Name: "p3", Capabilities: capabilities},
+		},
+	}}}
+}
+
+func TestValidateGclConfiguration(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodePort string
+		sched    *schedule.Schedule
+		want     []string // port/traffic class of every problem
+	}{
+		{name: "valid", nodePort: "sw0.p1", sched: getTestSchedule(1000000, portion{"isochronous", 50}, portion{"best-effort", 50})},
+		{name: "port not in the topology", nodePort: "sw0.p9", sched: getTestSchedule(1000000, portion{"best-effort", 100}),
+			want: []string{"sw0.p9/"}},
+		{name: "invalid schedule", nodePort: "sw0.p1", sched: getTestSchedule(1000000, portion{"isochronous", 40}, portion{"best-effort", 50}),
+			want: []string{"sw0.p1/"}},
+		{name: "shared queue", nodePort: "sw0.p2", sched: getTestSchedule(1000000, portion{"isochronous", 50}, portion{"best-effort", 50}),
+			want: []string{"sw0.p2/best-effort"}},
+		// 123360 ns of guard band before the express window, best-effort has 100000 ns
+		{name: "guard band takes the window", nodePort: "sw0.p3", sched: getTestSchedule(1000000, portion{"best-effort", 10}, portion{"isochronous", 90}),
+			want: []string{"sw0.p3/best-effort"}},
+		{name: "windows shorter than a frame", nodePort: "sw0.p3",
+			sched: getTestSchedule(1000000, portion{"best-effort", 80}, portion{"cyclic-sync", 10}, portion{"isochronous", 10}),
+			want:  []string{"sw0.p3/cyclic-sync", "sw0.p3/isochronous"}},
+	}
+
+	topo := getTestTopology()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gclConfig := &schedule.GclConfiguration{Configs: []*schedule.ConfigMap{{NodePort: tt.nodePort, Sched: tt.sched}}}
+			err := ValidateGclConfiguration(gclConfig, topo)
+			if problems := getTestScheduleErrors(t, err); !slices.Equal(problems, tt.want) {
+				t.Errorf("ValidateGclConfiguration() = %v, want problems at %v", err, tt.want)
+			}
+		})
+	}
+
+	// The problems of all ports are found together
+	gclConfig := &schedule.GclConfiguration{}
+	for _, tt := range tests {
+		gclConfig.Configs = append(gclConfig.Configs, &schedule.ConfigMap{NodePort: tt.nodePort, Sched: tt.sched})
+	}
+	if problems := getTestScheduleErrors(t, ValidateGclConfiguration(gclConfig, topo)); len(problems) != 6 {
+		t.Errorf("ValidateGclConfiguration() of every port = %v, want the 6 problems of the ports", problems)
+	}
+}
@@ -3,77 +3,276 @@ package notificationHandler
 import (
 	"fmt"
 
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/admission"
 	"tsn-service/pkg/internalOptimizer"
 	store "tsn-service/pkg/storewrapper"
 	"tsn-service/pkg/structures/configuration"
+	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
 
 	//	"git.cs.kau.se/hamzchah/opencnc_kafka-exporter/logger/pkg/logger"
 
 	"github.com/google/uuid"
+	pb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 //var log = logger.GetLogger()
 
 // Calculates configuration and stores it as a set request in k/v store, returns ID of configuration set request
-func CalculateConfiguration(ids []string) (string, error) {
-	// Not yet used when calculating configuration
+// and the response to the requests, with the status of every stream. Only the admitted streams are configured
+// The streams are admitted into a copy of the admitted streams, which is only kept once everything is stored
+func CalculateConfiguration(ids []string) (string, *configuration.ConfigResponse, error) {
+	stateLock.Lock()
+	defer stateLock.Unlock()
+
 	var allRequestData []*configuration.Request
 
 	// Get request from k/v store
 	for _, requestId := range ids {
 		reqData, err := store.GetRequestData(requestId)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
 		fmt.Printf("Got request from store with id: %s\n", requestId)
 		allRequestData = append(allRequestData, reqData)
 	}
 
-	// TODO: Use requests when creating configuration
-
 	// Get topology
 	topology, err := store.GetTopology()
 	if err != nil {
 		//log.Errorf("Failed getting topology: %v", err)
 		fmt.Printf("Failed getting topology: %v\n", err)
-		return "", err
+		return "", nil, err
 	}
 
 	//log.Info("Successfully requested topology from k/v store!")
 	fmt.Println("Successfully requested topology from k/v store!")
 
-	// NOT TESTED???
-	// Get current configuration of the network
-	//oldConfig, err := store.GetConfiguration("old")
-	/*
-		if err != nil {
-			//log.Errorf("Failed getting configuration: %v", err)
-			fmt.Printf("Failed getting configuration: %v\n", err)
-			return "", err
-		}*/
 	// Calculate configuration set request
 	newConfig, err := internalOptimizer.CalculateConf(topology, nil)
 	if err != nil {
 		//log.Errorf("Failed calculating configuration: %v", err)
 		fmt.Printf("Failed calculating configuration: %v\n", err)
-		return "", err
+		return "", nil, err
 	}
 
 	//log.Info("Successfully calculated new configuration!")
 	fmt.Println("Successfully calculated new configuration!")
 
+	// Check the schedule of every port before it is stored
+	if err := internalOptimizer.ValidateGclConfiguration(newConfig, topology); err != nil {
+		fmt.Printf("Failed validating configuration: %v\n", err)
+		return "", nil, err
+	}
+
+	// Admit the new requests against the streams that are already admitted, with the new configuration
+	roots := getDeviceConfigs(topology)
+	working, releaseUpdates, deletes, err := getWorkingState(topology, newConfig, roots)
+	if err != nil {
+		fmt.Printf("Failed creating admission controller: %v\n", err)
+		return "", nil, err
+	}
+	admissionResp, err := working.controller.AdmitRequests(allRequestData)
+	if err != nil {
+		fmt.Printf("Failed admitting requests: %v\n", err)
+		return "", nil, err
+	}
+
+	var updates []*pb.Update
+	if internalOptimizer.IsCqfConfiguration(newConfig) {
+		// With CQF the admitted streams share one cycle time, all of them are set again with the new streams
+		cqfResp, cqfUpdates, cqfDeletes, err := working.composeCqf(topology, roots)
+		if err != nil {
+			return "", nil, err
+		}
+		admissionResp = getCqfResponse(admissionResp, cqfResp)
+		updates, deletes = append(releaseUpdates, cqfUpdates...), append(deletes, cqfDeletes...)
+	} else {
+		// Set the schedule of every port at the bridges
+		gclUpdates, err := internalOptimizer.ComposeGclConfiguration(newConfig, topology, roots)
+		if err != nil {
+			fmt.Printf("Failed composing the gate control lists: %v\n", err)
+			return "", nil, err
+		}
+		updates = append(releaseUpdates, gclUpdates...)
+
+		// Set the PSFP and VLANs of the admitted streams at the bridges on their paths
+		for _, req := range getAdmittedRequests(allRequestData, admissionResp) {
+			streamUpdates, err := working.composeStream(req, topology, roots)
+			if err != nil {
+				return "", nil, err
+			}
+			updates = append(updates, streamUpdates...)
+		}
+
+		// Shape the queues of the admitted streams that select the credit-based shaper
+		cbsUpdates, cbsDeletes, err := working.composeCbs(topology, newConfig, nil, roots)
+		if err != nil {
+			return "", nil, err
+		}
+		updates, deletes = append(updates, cbsUpdates...), append(deletes, cbsDeletes...)
+	}
+
 	// Generate an ID for configuration set request
 	confId := fmt.Sprint(uuid.New())
 
-	// Store configuration set request in k/v store
+	// Store the updates of every device, then the configuration set request and the response in k/v store
+	if err := storeSetRequests(confId, updates, deletes, roots); err != nil {
+		return "", nil, err
+	}
 	if err := store.StoreConfiguration(newConfig, confId); err != nil {
 		//log.Errorf("Failed storing new configuration: %v", err)
 		fmt.Printf("Failed storing configuration: %v\n", err)
 
-		return "", err
+		return "", nil, err
+	}
+	if err := store.StoreLatestConfigurationId(confId); err != nil {
+		fmt.Printf("Failed storing id of configuration: %v\n", err)
+		return "", nil, err
+	}
+	if err := store.StoreConfigResponse(admissionResp, confId); err != nil {
+		fmt.Printf("Failed storing response: %v\n", err)
+		return "", nil, err
+	}
+	if err := commitState(working); err != nil {
+		return "", nil, err
 	}
 
 	//log.Info("Successfully stored new configuration!")
 	fmt.Println("Successfully stored new configuration!")
+	return confId, admissionResp, nil
+}
+
+// Withdraws an admitted stream, removes what is set for it at the bridges and stores the configuration of the ports it used,
+// returns ID of configuration set request. The stream stays admitted if the configuration can not be stored
+func WithdrawStream(strId *configuration.StreamId) (string, error) {
+	stateLock.Lock()
+	defer stateLock.Unlock()
+
+	topology, config, roots, working, updates, deletes, err := getStreamState()
+	if err != nil {
+		return "", err
+	}
+
+	_, ports, err := working.controller.Remove(strId)
+	if err != nil {
+		fmt.Printf("Failed withdrawing stream: %v\n", err)
+		return "", err
+	}
+	fmt.Printf("Withdrew stream %s, recalculating ports: %v\n", admission.GetStreamKey(strId), ports)
+
+	var confId string
+	if internalOptimizer.IsCqfConfiguration(config) {
+		confId, err = working.storeCqfConfiguration(topology, config, ports, roots, updates, deletes)
+	} else {
+		streamUpdates, streamDeletes, err := working.releaseStream(strId, roots)
+		if err != nil {
+			return "", err
+		}
+		cbsUpdates, cbsDeletes, err := working.composeCbs(topology, config, ports, roots)
+		if err != nil {
+			return "", err
+		}
+		updates, deletes = append(updates, streamUpdates...), append(deletes, streamDeletes...)
+		confId, err = storePortConfiguration(topology, config, ports, roots, append(updates, cbsUpdates...), append(deletes, cbsDeletes...))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := commitState(working); err != nil {
+		return "", err
+	}
 	return confId, nil
 }
+
+// Changes the traffic specification of an admitted stream, sets it again at the bridges and stores the configuration
+// of the ports it uses, returns ID of configuration set request
+// The stream keeps its old traffic specification if the new one can not be admitted or the configuration can not be stored
+func ModifyStream(strId *configuration.StreamId, trafficSpec *configuration.TrafficSpecification) (string, error) {
+	stateLock.Lock()
+	defer stateLock.Unlock()
+
+	topology, config, roots, working, updates, deletes, err := getStreamState()
+	if err != nil {
+		return "", err
+	}
+
+	latency, ports, err := working.controller.Modify(strId, trafficSpec)
+	if err != nil {
+		fmt.Printf("Failed modifying stream: %v\n", err)
+		return "", err
+	}
+	for _, listener := range latency.Listeners {
+		fmt.Printf("Stream %s to listener %d, worst-case latency: %d ns\n", admission.GetStreamKey(strId), listener.Listener, listener.WorstCase)
+	}
+
+	var confId string
+	if internalOptimizer.IsCqfConfiguration(config) {
+		confId, err = working.storeCqfConfiguration(topology, config, ports, roots, updates, deletes)
+	} else {
+		confId, err = modifyStreamConfiguration(working, strId, topology, config, ports, roots, updates, deletes)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := commitState(working); err != nil {
+		return "", err
+	}
+	return confId, nil
+}
+
+// Set a modified stream again at the bridges, the PSFP of the stream is set again with the new traffic specification
+func modifyStreamConfiguration(working *streamState, strId *configuration.StreamId, topology *topology.Topology,
+	config *schedule.GclConfiguration, ports []string, roots map[string]*st.SchemaTree, updates []*pb.Update, deletes []*pb.Path) (string, error) {
+
+	streamUpdates, streamDeletes, err := working.releaseStream(strId, roots)
+	if err != nil {
+		return "", err
+	}
+	updates = append(updates, streamUpdates...)
+	for _, req := range working.controller.GetAdmittedRequests() {
+		if admission.GetStreamKey(req.GetTalker().GetStrId()) == admission.GetStreamKey(strId) {
+			composeUpdates, err := working.composeStream(req, topology, roots)
+			if err != nil {
+				return "", err
+			}
+			updates = append(updates, composeUpdates...)
+		}
+	}
+
+	// The bandwidth of the stream changed, so did the shapers of the ports it uses
+	cbsUpdates, cbsDeletes, err := working.composeCbs(topology, config, ports, roots)
+	if err != nil {
+		return "", err
+	}
+	updates, deletes = append(updates, cbsUpdates...), append(deletes, streamDeletes...)
+
+	return storePortConfiguration(topology, config, ports, roots, updates, append(deletes, cbsDeletes...))
+}
+
+// Get the topology, the current configuration and the configuration of the devices, and a copy of the state to work on with them
+// The caller holds stateLock
+func getStreamState() (*topology.Topology, *schedule.GclConfiguration, map[string]*st.SchemaTree, *streamState, []*pb.Update, []*pb.Path, error) {
+	topology, err := store.GetTopology()
+	if err != nil {
+		fmt.Printf("Failed getting topology: %v\n", err)
+		return nil, nil, nil, nil, nil, nil, err
+	}
+
+	config, err := getConfiguration(topology)
+	if err != nil {
+		fmt.Printf("Failed getting configuration: %v\n", err)
+		return nil, nil, nil, nil, nil, nil, err
+	}
+
+	roots := getDeviceConfigs(topology)
+	working, updates, deletes, err := getWorkingState(topology, config, roots)
+	if err != nil {
+		fmt.Printf("Failed creating admission controller: %v\n", err)
+		return nil, nil, nil, nil, nil, nil, err
+	}
+	return topology, config, roots, working, updates, deletes, nil
+}
@@ -1,14 +1,391 @@
 package notificationHandler
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	flowmeterinst "tsn-service/pkg/RAE/PSFP/flowMeterInst"
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
+	"tsn-service/pkg/RAE/dataStructures/namespaces"
+	pbMethods "tsn-service/pkg/RAE/dataStructures/pbMethods"
+	"tsn-service/pkg/admission"
+	"tsn-service/pkg/internalOptimizer"
+	store "tsn-service/pkg/storewrapper"
+	"tsn-service/pkg/structures/configuration"
 	"tsn-service/pkg/structures/schedule"
+	"tsn-service/pkg/structures/topology"
+
+	"github.com/google/uuid"
+	pb "github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/protobuf/proto"
 )
 
 //	"git.cs.kau.se/hamzchah/opencnc_kafka-exporter/logger/pkg/logger"
 
-// Get configuration from config-service
-func getConfiguration() (config *schedule.GclConfiguration, err error) {
+// Get the configuration the network has now, it is calculated from the topology if none is stored yet
+func getConfiguration(topo *topology.Topology) (config *schedule.GclConfiguration, err error) {
+	config, err = store.GetLatestConfiguration()
+	if err == nil {
+		return config, nil
+	}
+
+	fmt.Printf("No configuration stored, calculating it: %v\n", err)
+	return internalOptimizer.CalculateConf(topo, nil)
+}
+
+// What is kept between requests: the admitted streams, and what is set at the bridges for each of them
+type streamState struct {
+	controller *admission.Controller
+	configs    map[string]*internalOptimizer.StreamConfiguration // by stream id (see admission.GetStreamKey)
+}
+
+var (
+	// The state of the last request that was stored, nil before the first request
+	currentState *streamState
+
+	// Held by a request from reading the state until it is stored, so the requests change the state one at a time
+	stateLock sync.Mutex
+)
+
+/*
+Get a copy of the state to work on with the current topology and configuration, the admission controller is created the first time
+The admitted streams that do not fit the topology any more are withdrawn, what is set for them is given back as deletes
+(and updates of the entries they shared with other streams).
+The copy replaces the state with commitState when the configuration is stored, until then the state does not change.
+The caller holds stateLock.
+*/
+func getWorkingState(topo *topology.Topology, config *schedule.GclConfiguration, roots map[string]*st.SchemaTree) (
+	*streamState, []*pb.Update, []*pb.Path, error) {
+
+	if currentState == nil {
+		return loadState(topo, config, roots)
+	}
+
+	working := &streamState{controller: currentState.controller.Clone(), configs: maps.Clone(currentState.configs)}
+	dropped, err := working.controller.SetTopology(topo, config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var updates []*pb.Update
+	var deletes []*pb.Path
+	for _, req := range dropped {
+		strId := req.GetTalker().GetStrId()
+		fmt.Printf("Stream %s does not fit the topology any more, it is withdrawn\n", admission.GetStreamKey(strId))
+		streamUpdates, streamDeletes, err := working.releaseStream(strId, roots)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		updates = append(updates, streamUpdates...)
+		deletes = append(deletes, streamDeletes...)
+	}
+	return working, updates, deletes, nil
+}
+
+// The state as it is stored, the admitted requests in the order they were admitted (protobuf) and what is set for each stream
+type storedStreamState struct {
+	Requests [][]byte
+	Configs  map[string]*internalOptimizer.StreamConfiguration
+}
+
+/*
+Store the working state once the configuration of its streams is stored, and keep it as the state
+The state does not change if it can not be stored. The caller holds stateLock.
+*/
+func commitState(working *streamState) error {
+	stored := storedStreamState{Configs: working.configs}
+	for _, req := range working.controller.GetAdmittedRequests() {
+		rawReq, err := proto.Marshal(req)
+		if err != nil {
+			return err
+		}
+		stored.Requests = append(stored.Requests, rawReq)
+	}
+
+	rawState, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	if err := store.StoreStreamState(rawState); err != nil {
+		fmt.Printf("Failed storing state of admitted streams: %v\n", err)
+		return err
+	}
+
+	currentState = working
+	return nil
+}
+
+/*
+Get the state stored by the last request, e.g. before the service restarted, with a new admission controller
+The stored streams are admitted again in order, a stream that no longer fits is withdrawn and what is set for it is given back
+as deletes (and updates of the entries it shared with other streams).
+Without a stored state no stream is admitted.
+*/
+func loadState(topo *topology.Topology, config *schedule.GclConfiguration, roots map[string]*st.SchemaTree) (
+	*streamState, []*pb.Update, []*pb.Path, error) {
+
+	controller, err := admission.NewController(topo, config, admission.DefaultLimits)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	working := &streamState{controller: controller, configs: map[string]*internalOptimizer.StreamConfiguration{}}
+
+	rawState, err := store.GetStreamState()
+	if err != nil {
+		fmt.Printf("No state of admitted streams stored, starting without streams: %v\n", err)
+		return working, nil, nil, nil
+	}
+	var stored storedStreamState
+	if err := json.Unmarshal(rawState, &stored); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed reading state of admitted streams: %w", err)
+	}
+	if stored.Configs != nil {
+		working.configs = stored.Configs
+	}
+
+	var updates []*pb.Update
+	var deletes []*pb.Path
+	for _, rawReq := range stored.Requests {
+		req := &configuration.Request{}
+		if err := proto.Unmarshal(rawReq, req); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed reading state of admitted streams: %w", err)
+		}
+
+		var rejection *admission.Rejection
+		_, err := controller.Admit(req)
+		switch {
+		case err == nil:
+			continue
+		case !errors.As(err, &rejection):
+			return nil, nil, nil, err
+		}
+
+		strId := req.GetTalker().GetStrId()
+		fmt.Printf("Stream %s does not fit the topology any more, it is withdrawn\n", admission.GetStreamKey(strId))
+		streamUpdates, streamDeletes, err := working.releaseStream(strId, roots)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		updates = append(updates, streamUpdates...)
+		deletes = append(deletes, streamDeletes...)
+	}
+	return working, updates, deletes, nil
+}
+
+// Configure an admitted stream at the bridges on its paths, and keep what is set for it
+func (s *streamState) composeStream(req *configuration.Request, topo *topology.Topology, roots map[string]*st.SchemaTree) ([]*pb.Update, error) {
+	streamConfig, updates, err := internalOptimizer.ComposeStreamConfiguration(req, topo, roots, flowmeterinst.DefaultTolerance)
+	if err != nil {
+		fmt.Printf("Failed configuring stream %s: %v\n", admission.GetStreamKey(req.GetTalker().GetStrId()), err)
+		return nil, err
+	}
+
+	s.configs[admission.GetStreamKey(req.GetTalker().GetStrId())] = streamConfig
+	return updates, nil
+}
+
+// Remove what is set for a stream at the bridges, the VLAN memberships and FRER entries other streams use stay
+// A stream without a kept configuration has nothing to remove
+func (s *streamState) releaseStream(strId *configuration.StreamId, roots map[string]*st.SchemaTree) ([]*pb.Update, []*pb.Path, error) {
+	streamConfig, ok := s.configs[admission.GetStreamKey(strId)]
+	if !ok {
+		return nil, nil, nil
+	}
+	delete(s.configs, admission.GetStreamKey(strId))
+
+	var others []*internalOptimizer.StreamConfiguration
+	for _, other := range s.configs {
+		others = append(others, other)
+	}
+
+	updates, deletes, err := internalOptimizer.ReleaseStreamConfiguration(streamConfig, others, roots)
+	if err != nil {
+		fmt.Printf("Failed releasing stream %s: %v\n", admission.GetStreamKey(strId), err)
+		return nil, nil, err
+	}
+	return updates, deletes, nil
+}
+
+// Set the credit-based shapers of the given ports (node.port, nil for all ports) for the admitted streams that select it
+func (s *streamState) composeCbs(topo *topology.Topology, config *schedule.GclConfiguration, ports []string,
+	roots map[string]*st.SchemaTree) ([]*pb.Update, []*pb.Path, error) {
+
+	updates, deletes, err := internalOptimizer.ComposeCbsConfiguration(s.controller.GetAdmittedRequests(), topo, config, roots, ports)
+	if err != nil {
+		fmt.Printf("Failed composing the credit-based shapers: %v\n", err)
+		return nil, nil, err
+	}
+	return updates, deletes, nil
+}
+
+// Get the requests that are admitted in the response, the rejected streams are not configured
+func getAdmittedRequests(reqs []*configuration.Request, resp *configuration.ConfigResponse) (admitted []*configuration.Request) {
+	rejected := map[string]bool{}
+	for _, streamResp := range resp.GetResponses() {
+		statusGroup := streamResp.GetStatusGroup()
+		if statusGroup.GetStatusInfo().GetFailureCode() != 0 {
+			fmt.Printf("Stream %s was not admitted, failure code: %d\n",
+				admission.GetStreamKey(statusGroup.GetStrId()), statusGroup.GetStatusInfo().GetFailureCode())
+			rejected[admission.GetStreamKey(statusGroup.GetStrId())] = true
+		}
+	}
+
+	for _, req := range reqs {
+		if !rejected[admission.GetStreamKey(req.GetTalker().GetStrId())] {
+			admitted = append(admitted, req)
+		}
+	}
+	return admitted
+}
+
+// Store the schedule of the given ports (node.port) from the current configuration, together with the updates and deletes
+// of the streams that changed at them, the other ports are not changed
+func storePortConfiguration(topo *topology.Topology, config *schedule.GclConfiguration, ports []string,
+	roots map[string]*st.SchemaTree, updates []*pb.Update, deletes []*pb.Path) (string, error) {
+
+	portConfig := internalOptimizer.GetPortConfiguration(config, ports)
+
+	// Check the schedule of every port before it is stored
+	if err := internalOptimizer.ValidateGclConfiguration(portConfig, topo); err != nil {
+		fmt.Printf("Failed validating configuration: %v\n", err)
+		return "", err
+	}
+
+	// With CQF the gates of the ports are set with the streams (see composeCqf), not from the schedule
+	var gclUpdates []*pb.Update
+	if !internalOptimizer.IsCqfConfiguration(portConfig) {
+		var err error
+		if gclUpdates, err = internalOptimizer.ComposeGclConfiguration(portConfig, topo, roots); err != nil {
+			fmt.Printf("Failed composing the gate control lists: %v\n", err)
+			return "", err
+		}
+	}
+
+	confId := fmt.Sprint(uuid.New())
+	if err := storeSetRequests(confId, append(gclUpdates, updates...), deletes, roots); err != nil {
+		return "", err
+	}
+	if err := store.StoreConfiguration(portConfig, confId); err != nil {
+		fmt.Printf("Failed storing configuration: %v\n", err)
+		return "", err
+	}
+
+	fmt.Println("Successfully stored new configuration!")
+	return confId, nil
+}
+
+// Set all admitted streams again with CQF, what was set for them before is deleted
+// The cycle time is picked from all admitted streams, so it can change with every stream that is admitted or withdrawn
+func (s *streamState) composeCqf(topo *topology.Topology, roots map[string]*st.SchemaTree) (
+	*configuration.ConfigResponse, []*pb.Update, []*pb.Path, error) {
+
+	reqs := s.controller.GetAdmittedRequests()
+	var releaseUpdates []*pb.Update
+	var deletes []*pb.Path
+	for _, req := range reqs {
+		streamUpdates, streamDeletes, err := s.releaseStream(req.GetTalker().GetStrId(), roots)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		releaseUpdates = append(releaseUpdates, streamUpdates...)
+		deletes = append(deletes, streamDeletes...)
+	}
+	if len(reqs) == 0 {
+		return &configuration.ConfigResponse{}, releaseUpdates, deletes, nil
+	}
+
+	cycleTime, resp, configs, updates, err := internalOptimizer.CalculateCqf(&configuration.ConfigRequest{Requests: reqs}, topo, roots,
+		internalOptimizer.DefaultCqfQueues, flowmeterinst.DefaultTolerance)
+	if err != nil {
+		fmt.Printf("Failed configuring the streams with CQF: %v\n", err)
+		return nil, nil, nil, err
+	}
+	fmt.Printf("Configured %d streams with CQF, cycle time: %d ns\n", len(reqs), cycleTime)
+
+	for i, req := range reqs {
+		s.configs[admission.GetStreamKey(req.GetTalker().GetStrId())] = configs[i]
+	}
+	return resp, append(releaseUpdates, updates...), deletes, nil
+}
+
+// Replace the responses of the admitted streams by their CQF responses, with the latency of the CQF cycles
+func getCqfResponse(resp *configuration.ConfigResponse, cqfResp *configuration.ConfigResponse) *configuration.ConfigResponse {
+	cqfResponses := map[string]*configuration.Response{}
+	for _, streamResp := range cqfResp.GetResponses() {
+		cqfResponses[admission.GetStreamKey(streamResp.GetStatusGroup().GetStrId())] = streamResp
+	}
+
+	for i, streamResp := range resp.GetResponses() {
+		if cqfResponse, ok := cqfResponses[admission.GetStreamKey(streamResp.GetStatusGroup().GetStrId())]; ok {
+			resp.Responses[i] = cqfResponse
+		}
+	}
+	return resp
+}
+
+// Set all admitted streams again with CQF and store their updates, after a stream is withdrawn or modified
+// The configuration that is stored holds the schedule of the ports the stream used, as with storePortConfiguration
+func (s *streamState) storeCqfConfiguration(topo *topology.Topology, config *schedule.GclConfiguration, ports []string,
+	roots map[string]*st.SchemaTree, updates []*pb.Update, deletes []*pb.Path) (string, error) {
+
+	_, cqfUpdates, cqfDeletes, err := s.composeCqf(topo, roots)
+	if err != nil {
+		return "", err
+	}
+
+	return storePortConfiguration(topo, config, ports, roots, append(updates, cqfUpdates...), append(deletes, cqfDeletes...))
+}
+
+// Get the configuration of every device in the topology that is configured, by device ip
+// A device without a stored configuration starts from an empty tree, as when it is configured the first time
+func getDeviceConfigs(topo *topology.Topology) map[string]*st.SchemaTree {
+	roots := map[string]*st.SchemaTree{}
+	for _, node := range topo.GetNodes() {
+		deviceIp := node.GetManagementInfo().GetIpAddress()
+		if node.Type == topology.NodeRole_END_STATION || deviceIp == "" {
+			continue
+		}
+
+		registerDeviceCapabilities(deviceIp)
+
+		root, err := store.GetDeviceConfig(deviceIp)
+		if err != nil {
+			fmt.Printf("No configuration of device %s, starting from an empty one: %v\n", deviceIp, err)
+			root = &st.SchemaTree{}
+		}
+		roots[deviceIp] = root
+	}
+	return roots
+}
+
+// Register the YANG modules of a device from the capabilities the adapter stored when it connected to it,
+// so the modules the setters name have the namespace and revision of the device. The known modules are used without them
+func registerDeviceCapabilities(deviceIp string) {
+	capabilities, err := store.GetDeviceCapabilities(deviceIp)
+	if err != nil {
+		fmt.Printf("No capabilities of device %s, using the known modules: %v\n", deviceIp, err)
+		return
+	}
+	if err := namespaces.RegisterCapabilities(capabilities); err != nil {
+		fmt.Printf("Failed registering capabilities of device %s: %v\n", deviceIp, err)
+	}
+}
+
+// Store the updates and deletes as one SetRequest per device, and the configuration the devices get with them
+func storeSetRequests(confId string, updates []*pb.Update, deletes []*pb.Path, roots map[string]*st.SchemaTree) error {
+	setRequests := pbMethods.GetSetRequests(updates, deletes)
+	if err := store.StoreSetRequests(setRequests, confId); err != nil {
+		fmt.Printf("Failed storing set requests: %v\n", err)
+		return err
+	}
 
-	// TODO: Return correct value(s)
-	return nil, err
+	for deviceIp := range setRequests {
+		if err := store.StoreDeviceConfig(deviceIp, roots[deviceIp]); err != nil {
+			fmt.Printf("Failed storing configuration of device %s: %v\n", deviceIp, err)
+			return err
+		}
+	}
+	return nil
 }
@@ -1,16 +1,16 @@
 package storewrapper
 
 import (
+	st "tsn-service/pkg/RAE/dataStructures/SchemaTreeMethods"
 	adapterResp "tsn-service/pkg/structures/adapterResponse"
 
+	pb "github.com/openconfig/gnmi/proto/gnmi"
 	"google.golang.org/protobuf/proto"
 )
 
 // Converts *SchemaTree to adapterResponse and stores in k/v store
-func StoreDeviceConfig(ipAddr string, tree *SchemaTree) error {
-	var adapterResponse = &adapterResp.AdapterResponse{}
-
-	treeConverter(tree, adapterResponse)
+func StoreDeviceConfig(ipAddr string, tree *st.SchemaTree) error {
+	adapterResponse := st.ToAdapterResponse(tree)
 
 	rawAdapterResponse, err := proto.Marshal(adapterResponse)
 	if err != nil {
@@ -23,106 +23,47 @@ func StoreDeviceConfig(ipAddr string, tree *SchemaTree) error {
 	return nil
 }
 
-// Converts *SchemaTree to adapterResponse
-func treeConverter(tree *SchemaTree, adapterResponse *adapterResp.AdapterResponse) *adapterResp.AdapterResponse {
-	start := &adapterResp.SchemaEntry{
-		Name:      tree.Name,
-		Tag:       "start",
-		Namespace: tree.Namespace,
-		Value:     tree.Value,
-	}
-
-	adapterResponse.Entries = append(adapterResponse.Entries, start)
-
-	// //log.Infof("START %s --- %s --- %v", start.Name, start.Namespace, start.Value)
-
-	for _, child := range tree.Children {
-		treeConverter(child, adapterResponse)
-	}
-
-	end := &adapterResp.SchemaEntry{
-		Name: tree.Name,
-		Tag:  "end",
-	}
-
-	adapterResponse.Entries = append(adapterResponse.Entries, end)
-
-	// //log.Infof("END %s --- %s --- %v", end.Name, end.Namespace, end.Value)
-
-	return adapterResponse
-}
-
 // Takes in an IP address of a switch, gets the data from k/v store, and converts it to *SchemaTree
-func GetDeviceConfig(ipAddr string) (*SchemaTree, error) {
+// The tree can be passed to the RAE setters as it is, and stored again with StoreDeviceConfig
+func GetDeviceConfig(ipAddr string) (*st.SchemaTree, error) {
 	// Create a URN where the config is stored
 	urn := "configurations." + ipAddr + ".config"
 
 	rawData, err := getFromStore(urn)
 	if err != nil {
 		//log.Errorf("Failed getting configuration: %v", err)
-		return &SchemaTree{}, err
+		return &st.SchemaTree{}, err
 	}
 
 	var adapterResponse = &adapterResp.AdapterResponse{}
-	var schemaTree = &SchemaTree{}
 
 	// Deserialize response that was built in adapter
 	if err := proto.Unmarshal(rawData, adapterResponse); err != nil {
 		//log.Errorf("Failed to unmarshal ProtoBytes: %v", err)
-		return &SchemaTree{}, err
+		return &st.SchemaTree{}, err
 	}
 
 	// Get tree structure of response
-	schemaTree = getTreeStructure(adapterResponse.Entries)
-
-	return schemaTree, nil
+	return st.FromAdapterResponse(adapterResponse), nil
 }
 
-// THE BELOW STRUCTURE SHOULD BE IMPLEMENTED THROUGH PROTOBUF
-// OR SHOULD BE REPLACED WITH A NATIVE GNMI IMPLEMENTATION
-// The following type are used for deconstructing data from the adapter
-type SchemaTree struct {
-	Name      string
-	Namespace string
-	Children  []*SchemaTree
-	Parent    *SchemaTree
-	Value     string
-}
-
-// Build a schemaTree from the entries provided
-func getTreeStructure(schemaEntries []*adapterResp.SchemaEntry) *SchemaTree {
-	var newTree *SchemaTree
-	tree := &SchemaTree{}
-	lastNode := ""
-	for _, entry := range schemaEntries {
-		if entry.Value == "" {
-			// In a directory
-			if entry.Tag == "end" {
-				if entry.Name != "data" {
-					if lastNode != "leaf" {
-						tree = tree.Parent
-					}
-					lastNode = ""
-				}
-			} else {
-				newTree = &SchemaTree{Parent: tree}
-
-				newTree.Name = entry.Name
-				newTree.Namespace = entry.Namespace
-				newTree.Parent.Children = append(newTree.Parent.Children, newTree)
+// Takes in an IP address of a switch and gets its gNMI CapabilityResponse from the k/v store, as the adapter stored it
+// when it connected to the switch, with the YANG modules the switch implements
+func GetDeviceCapabilities(ipAddr string) (*pb.CapabilityResponse, error) {
+	// Create a URN where the capabilities are stored
+	urn := "configurations." + ipAddr + ".capabilities"
 
-				tree = newTree
-			}
-		} else {
-			// In a leaf
-			newTree = &SchemaTree{Parent: tree}
-
-			newTree.Name = entry.Name
-			newTree.Value = entry.Value
-			newTree.Parent.Children = append(newTree.Parent.Children, newTree)
+	rawData, err := getFromStore(urn)
+	if err != nil {
+		//log.Errorf("Failed getting capabilities: %v", err)
+		return nil, err
+	}
 
-			lastNode = "leaf"
-		}
+	var capabilities = &pb.CapabilityResponse{}
+	if err := proto.Unmarshal(rawData, capabilities); err != nil {
+		//log.Errorf("Failed to unmarshal capabilities: %v", err)
+		return nil, err
 	}
-	return tree
+
+	return capabilities, nil
 }
@@ -2,6 +2,8 @@ package storewrapper
 
 import (
 	"fmt"
+	"sort"
+	"tsn-service/pkg/RAE/dataStructures/yangSchema"
 	"tsn-service/pkg/structures/configuration"
 	"tsn-service/pkg/structures/schedule"
 	"tsn-service/pkg/structures/topology"
@@ -98,6 +100,118 @@ func GetConfiguration(confId string) (*schedule.GclConfiguration, error) {
 	return &config, nil
 }
 
+/*
+Store the SetRequests of a configuration, one per device, where the config service picks them up
+Every SetRequest is validated against the YANG modules first, nothing is stored if one of them is not valid
+
+input:
+
+	setRequests: the SetRequest of each device, by device ip (see pbMethods.GetSetRequests)
+	confId: the id of the configuration they belong to
+*/
+func StoreSetRequests(setRequests map[string]*pb.SetRequest, confId string) error {
+	var deviceIps []string
+	for deviceIp := range setRequests {
+		deviceIps = append(deviceIps, deviceIp)
+	}
+	sort.Strings(deviceIps)
+
+	for _, deviceIp := range deviceIps {
+		if err := yangSchema.ValidateSetRequest(setRequests[deviceIp]); err != nil {
+			//log.Errorf("Invalid set request for device %s: %v", deviceIp, err)
+			return fmt.Errorf("invalid set request for device %s: %w", deviceIp, err)
+		}
+	}
+
+	for _, deviceIp := range deviceIps {
+		// Create a URN where the serialized request will be stored
+		urn := "configurations.set-requests." + confId + "." + deviceIp
+
+		rawSetRequest, err := proto.Marshal(setRequests[deviceIp])
+		if err != nil {
+			//log.Errorf("Failed marshaling set request: %v", err)
+			return err
+		}
+
+		if err = sendToStore(rawSetRequest, urn); err != nil {
+			//log.Errorf("Failed storing set request: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+Store the id of the configuration the network has now, the configuration itself is stored with StoreConfiguration
+*/
+func StoreLatestConfigurationId(confId string) error {
+	if err := sendToStore([]byte(confId), "configurations.latest-tsn-configuration"); err != nil {
+		//log.Errorf("Failed storing id of latest configuration: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+/*
+Get the configuration the network has now, the one of the id stored with StoreLatestConfigurationId
+*/
+func GetLatestConfiguration() (*schedule.GclConfiguration, error) {
+	confId, err := getFromStore("configurations.latest-tsn-configuration")
+	if err != nil {
+		// log.Errorf("Failed to retrieve id of latest configuration: %v", err)
+		return nil, err
+	}
+
+	return GetConfiguration(string(confId))
+}
+
+/*
+Store the response to the stream requests of a configuration, with the status of every stream (admitted or failed)
+*/
+func StoreConfigResponse(resp *configuration.ConfigResponse, confId string) error {
+	// Create a URN where the serialized response will be stored
+	urn := "configurations.tsn-response." + confId
+
+	rawResp, err := proto.Marshal(resp)
+	if err != nil {
+		//log.Errorf("Failed marshaling config response: %v", err)
+		return err
+	}
+
+	if err = sendToStore(rawResp, urn); err != nil {
+		//log.Errorf("Failed storing config response: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+/*
+Store the admitted streams and what is set at the bridges for each of them, so they are known again after a restart
+The state is one value, the notification handler encodes it
+*/
+func StoreStreamState(state []byte) error {
+	if err := sendToStore(state, "streams.admitted-state"); err != nil {
+		//log.Errorf("Failed storing state of admitted streams: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// Get the state of the admitted streams stored with StoreStreamState
+func GetStreamState() ([]byte, error) {
+	state, err := getFromStore("streams.admitted-state")
+	if err != nil {
+		// log.Errorf("Failed to retrieve state of admitted streams: %v", err)
+		return nil, err
+	}
+
+	return state, nil
+}
+
 func GetAllConfigurations() ([]*pb.SetRequest, error) {
 	const prefix = "configurations.tsn-configuration"
 
@@ -21,6 +21,65 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// What an element is, an element with an empty value is a container or an empty leaf
+type EntryKind int32
+
+const (
+	EntryKind_UNKNOWN         EntryKind = 0 // not sent: an element with a value is a leaf, one without a value is a container
+	EntryKind_CONTAINER       EntryKind = 1
+	EntryKind_LIST_ENTRY      EntryKind = 2
+	EntryKind_LEAF            EntryKind = 3
+	EntryKind_LEAF_LIST_ENTRY EntryKind = 4 // one value of a leaf-list, every value is a leaf with the same name
+	EntryKind_LIST_KEY        EntryKind = 5 // a key leaf of a list entry, the entry is identified by its key leaves
+)
+
+// Enum value maps for EntryKind.
+var (
+	EntryKind_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "CONTAINER",
+		2: "LIST_ENTRY",
+		3: "LEAF",
+		4: "LEAF_LIST_ENTRY",
+		5: "LIST_KEY",
+	}
+	EntryKind_value = map[string]int32{
+		"UNKNOWN":         0,
+		"CONTAINER":       1,
+		"LIST_ENTRY":      2,
+		"LEAF":            3,
+		"LEAF_LIST_ENTRY": 4,
+		"LIST_KEY":        5,
+	}
+)
+
+func (x EntryKind) Enum() *EntryKind {
+	p := new(EntryKind)
+	*p = x
+	return p
+}
+
+func (x EntryKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntryKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_structures_adapterResponse_adapterResponse_proto_enumTypes[0].Descriptor()
+}
+
+func (EntryKind) Type() protoreflect.EnumType {
+	return &file_pkg_structures_adapterResponse_adapterResponse_proto_enumTypes[0]
+}
+
+func (x EntryKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntryKind.Descriptor instead.
+func (EntryKind) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_structures_adapterResponse_adapterResponse_proto_rawDescGZIP(), []int{0}
+}
+
 type AdapterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Entries       []*SchemaEntry         `protobuf:"bytes,1,rep,name=Entries,proto3" json:"Entries,omitempty"`
@@ -79,6 +138,8 @@ type SchemaEntry struct {
 	Tag           string                 `protobuf:"bytes,2,opt,name=Tag,proto3" json:"Tag,omitempty"`
 	Namespace     string                 `protobuf:"bytes,3,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
 	Value         string                 `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	Kind          EntryKind              `protobuf:"varint,5,opt,name=Kind,proto3,enum=adapterResponse.EntryKind" json:"Kind,omitempty"` // only in "start" entries
+	OrderedByUser bool                   `protobuf:"varint,6,opt,name=OrderedByUser,proto3" json:"OrderedByUser,omitempty"`              // the entries of the list or leaf-list keep their order (ordered-by user)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -141,6 +202,20 @@ func (x *SchemaEntry) GetValue() string {
 	return ""
 }
 
+func (x *SchemaEntry) GetKind() EntryKind {
+	if x != nil {
+		return x.Kind
+	}
+	return EntryKind_UNKNOWN
+}
+
+func (x *SchemaEntry) GetOrderedByUser() bool {
+	if x != nil {
+		return x.OrderedByUser
+	}
+	return false
+}
+
 var File_pkg_structures_adapterResponse_adapterResponse_proto protoreflect.FileDescriptor
 
 var file_pkg_structures_adapterResponse_adapterResponse_proto_rawDesc = string([]byte{
@@ -155,15 +230,27 @@ var file_pkg_structures_adapterResponse_adapterResponse_proto_rawDesc = string([
 	0x68, 0x65, 0x6d, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x45, 0x6e, 0x74, 0x72, 0x69,
 	0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x22, 0x67, 0x0a, 0x0b, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x54, 0x61, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x5a, 0x10, 0x2f, 0x61, 0x64,
-	0x61, 0x70, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0xbd, 0x01, 0x0a, 0x0b, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x54, 0x61, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x4b, 0x69,
+	0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x61, 0x64, 0x61, 0x70, 0x74,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x65, 0x64, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0d, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x65, 0x64, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72,
+	0x2a, 0x64, 0x0a, 0x09, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0b, 0x0a,
+	0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f,
+	0x4e, 0x54, 0x41, 0x49, 0x4e, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x49, 0x53,
+	0x54, 0x5f, 0x45, 0x4e, 0x54, 0x52, 0x59, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x45, 0x41,
+	0x46, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x45, 0x41, 0x46, 0x5f, 0x4c, 0x49, 0x53, 0x54,
+	0x5f, 0x45, 0x4e, 0x54, 0x52, 0x59, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x49, 0x53, 0x54,
+	0x5f, 0x4b, 0x45, 0x59, 0x10, 0x05, 0x42, 0x12, 0x5a, 0x10, 0x2f, 0x61, 0x64, 0x61, 0x70, 0x74,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 })
 
 var (
@@ -178,18 +265,21 @@ func file_pkg_structures_adapterResponse_adapterResponse_proto_rawDescGZIP() []b
 	return file_pkg_structures_adapterResponse_adapterResponse_proto_rawDescData
 }
 
+var file_pkg_structures_adapterResponse_adapterResponse_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_pkg_structures_adapterResponse_adapterResponse_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_pkg_structures_adapterResponse_adapterResponse_proto_goTypes = []any{
-	(*AdapterResponse)(nil), // 0: adapterResponse.AdapterResponse
-	(*SchemaEntry)(nil),     // 1: adapterResponse.SchemaEntry
+	(EntryKind)(0),          // 0: adapterResponse.EntryKind
+	(*AdapterResponse)(nil), // 1: adapterResponse.AdapterResponse
+	(*SchemaEntry)(nil),     // 2: adapterResponse.SchemaEntry
 }
 var file_pkg_structures_adapterResponse_adapterResponse_proto_depIdxs = []int32{
-	1, // 0: adapterResponse.AdapterResponse.Entries:type_name -> adapterResponse.SchemaEntry
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 0: adapterResponse.AdapterResponse.Entries:type_name -> adapterResponse.SchemaEntry
+	0, // 1: adapterResponse.SchemaEntry.Kind:type_name -> adapterResponse.EntryKind
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_pkg_structures_adapterResponse_adapterResponse_proto_init() }
@@ -202,13 +292,14 @@ func file_pkg_structures_adapterResponse_adapterResponse_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_structures_adapterResponse_adapterResponse_proto_rawDesc), len(file_pkg_structures_adapterResponse_adapterResponse_proto_rawDesc)),
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_pkg_structures_adapterResponse_adapterResponse_proto_goTypes,
 		DependencyIndexes: file_pkg_structures_adapterResponse_adapterResponse_proto_depIdxs,
+		EnumInfos:         file_pkg_structures_adapterResponse_adapterResponse_proto_enumTypes,
 		MessageInfos:      file_pkg_structures_adapterResponse_adapterResponse_proto_msgTypes,
 	}.Build()
 	File_pkg_structures_adapterResponse_adapterResponse_proto = out.File
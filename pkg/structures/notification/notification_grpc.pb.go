@@ -1,17 +1,17 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.12.4
+// - protoc             v3.21.12
 // source: notification.proto
 
 package notification
 
 import (
 	context "context"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -24,13 +24,16 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type NotificationClient interface {
 	CalcConfig(ctx context.Context, in *IdList, opts ...grpc.CallOption) (*UUID, error)
-	// MSTP tables to configur
-	UpdateConfigMstpCistPortTable(ctx context.Context, in *InMstpCistPortTableRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	UpdateConfigMstpCistTable(ctx context.Context, in *InMstpCistTableRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	UpdateConfigMstpConfigTable(ctx context.Context, in *InMstpConfigTableRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	UpdateConfigMstpFidToMstiV2Table(ctx context.Context, in *InMstpFidToMstiV2TableRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	UpdateConfigMstpPortTable(ctx context.Context, in *InMstpPortTableRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	UpdateConfigMstpTable(ctx context.Context, in *InMstpTableRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Stream lifecycle, returns the id of the updated configuration
+	WithdrawStream(ctx context.Context, in *StreamId, opts ...grpc.CallOption) (*UUID, error)
+	ModifyStream(ctx context.Context, in *ModifyStreamRequest, opts ...grpc.CallOption) (*UUID, error)
+	// MSTP tables to configure
+	UpdateConfigMstpCistPortTable(ctx context.Context, in *InMstpCistPortTableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateConfigMstpCistTable(ctx context.Context, in *InMstpCistTableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateConfigMstpConfigTable(ctx context.Context, in *InMstpConfigTableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateConfigMstpFidToMstiV2Table(ctx context.Context, in *InMstpFidToMstiV2TableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateConfigMstpPortTable(ctx context.Context, in *InMstpPortTableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateConfigMstpTable(ctx context.Context, in *InMstpTableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type notificationClient struct {
@@ -50,8 +53,26 @@ func (c *notificationClient) CalcConfig(ctx context.Context, in *IdList, opts ..
 	return out, nil
 }
 
-func (c *notificationClient) UpdateConfigMstpCistPortTable(ctx context.Context, in *InMstpCistPortTableRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *notificationClient) WithdrawStream(ctx context.Context, in *StreamId, opts ...grpc.CallOption) (*UUID, error) {
+	out := new(UUID)
+	err := c.cc.Invoke(ctx, "/notification.Notification/WithdrawStream", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) ModifyStream(ctx context.Context, in *ModifyStreamRequest, opts ...grpc.CallOption) (*UUID, error) {
+	out := new(UUID)
+	err := c.cc.Invoke(ctx, "/notification.Notification/ModifyStream", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) UpdateConfigMstpCistPortTable(ctx context.Context, in *InMstpCistPortTableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/notification.Notification/UpdateConfigMstpCistPortTable", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -59,8 +80,8 @@ func (c *notificationClient) UpdateConfigMstpCistPortTable(ctx context.Context,
 	return out, nil
 }
 
-func (c *notificationClient) UpdateConfigMstpCistTable(ctx context.Context, in *InMstpCistTableRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *notificationClient) UpdateConfigMstpCistTable(ctx context.Context, in *InMstpCistTableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/notification.Notification/UpdateConfigMstpCistTable", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -68,8 +89,8 @@ func (c *notificationClient) UpdateConfigMstpCistTable(ctx context.Context, in *
 	return out, nil
 }
 
-func (c *notificationClient) UpdateConfigMstpConfigTable(ctx context.Context, in *InMstpConfigTableRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *notificationClient) UpdateConfigMstpConfigTable(ctx context.Context, in *InMstpConfigTableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/notification.Notification/UpdateConfigMstpConfigTable", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -77,8 +98,8 @@ func (c *notificationClient) UpdateConfigMstpConfigTable(ctx context.Context, in
 	return out, nil
 }
 
-func (c *notificationClient) UpdateConfigMstpFidToMstiV2Table(ctx context.Context, in *InMstpFidToMstiV2TableRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *notificationClient) UpdateConfigMstpFidToMstiV2Table(ctx context.Context, in *InMstpFidToMstiV2TableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/notification.Notification/UpdateConfigMstpFidToMstiV2Table", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -86,8 +107,8 @@ func (c *notificationClient) UpdateConfigMstpFidToMstiV2Table(ctx context.Contex
 	return out, nil
 }
 
-func (c *notificationClient) UpdateConfigMstpPortTable(ctx context.Context, in *InMstpPortTableRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *notificationClient) UpdateConfigMstpPortTable(ctx context.Context, in *InMstpPortTableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/notification.Notification/UpdateConfigMstpPortTable", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -95,8 +116,8 @@ func (c *notificationClient) UpdateConfigMstpPortTable(ctx context.Context, in *
 	return out, nil
 }
 
-func (c *notificationClient) UpdateConfigMstpTable(ctx context.Context, in *InMstpTableRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *notificationClient) UpdateConfigMstpTable(ctx context.Context, in *InMstpTableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/notification.Notification/UpdateConfigMstpTable", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -109,13 +130,16 @@ func (c *notificationClient) UpdateConfigMstpTable(ctx context.Context, in *InMs
 // for forward compatibility
 type NotificationServer interface {
 	CalcConfig(context.Context, *IdList) (*UUID, error)
-	// MSTP tables to configur
-	UpdateConfigMstpCistPortTable(context.Context, *InMstpCistPortTableRequest) (*empty.Empty, error)
-	UpdateConfigMstpCistTable(context.Context, *InMstpCistTableRequest) (*empty.Empty, error)
-	UpdateConfigMstpConfigTable(context.Context, *InMstpConfigTableRequest) (*empty.Empty, error)
-	UpdateConfigMstpFidToMstiV2Table(context.Context, *InMstpFidToMstiV2TableRequest) (*empty.Empty, error)
-	UpdateConfigMstpPortTable(context.Context, *InMstpPortTableRequest) (*empty.Empty, error)
-	UpdateConfigMstpTable(context.Context, *InMstpTableRequest) (*empty.Empty, error)
+	// Stream lifecycle, returns the id of the updated configuration
+	WithdrawStream(context.Context, *StreamId) (*UUID, error)
+	ModifyStream(context.Context, *ModifyStreamRequest) (*UUID, error)
+	// MSTP tables to configure
+	UpdateConfigMstpCistPortTable(context.Context, *InMstpCistPortTableRequest) (*emptypb.Empty, error)
+	UpdateConfigMstpCistTable(context.Context, *InMstpCistTableRequest) (*emptypb.Empty, error)
+	UpdateConfigMstpConfigTable(context.Context, *InMstpConfigTableRequest) (*emptypb.Empty, error)
+	UpdateConfigMstpFidToMstiV2Table(context.Context, *InMstpFidToMstiV2TableRequest) (*emptypb.Empty, error)
+	UpdateConfigMstpPortTable(context.Context, *InMstpPortTableRequest) (*emptypb.Empty, error)
+	UpdateConfigMstpTable(context.Context, *InMstpTableRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedNotificationServer()
 }
 
@@ -126,22 +150,28 @@ type UnimplementedNotificationServer struct {
 func (UnimplementedNotificationServer) CalcConfig(context.Context, *IdList) (*UUID, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CalcConfig not implemented")
 }
-func (UnimplementedNotificationServer) UpdateConfigMstpCistPortTable(context.Context, *InMstpCistPortTableRequest) (*empty.Empty, error) {
+func (UnimplementedNotificationServer) WithdrawStream(context.Context, *StreamId) (*UUID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawStream not implemented")
+}
+func (UnimplementedNotificationServer) ModifyStream(context.Context, *ModifyStreamRequest) (*UUID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModifyStream not implemented")
+}
+func (UnimplementedNotificationServer) UpdateConfigMstpCistPortTable(context.Context, *InMstpCistPortTableRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfigMstpCistPortTable not implemented")
 }
-func (UnimplementedNotificationServer) UpdateConfigMstpCistTable(context.Context, *InMstpCistTableRequest) (*empty.Empty, error) {
+func (UnimplementedNotificationServer) UpdateConfigMstpCistTable(context.Context, *InMstpCistTableRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfigMstpCistTable not implemented")
 }
-func (UnimplementedNotificationServer) UpdateConfigMstpConfigTable(context.Context, *InMstpConfigTableRequest) (*empty.Empty, error) {
+func (UnimplementedNotificationServer) UpdateConfigMstpConfigTable(context.Context, *InMstpConfigTableRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfigMstpConfigTable not implemented")
 }
-func (UnimplementedNotificationServer) UpdateConfigMstpFidToMstiV2Table(context.Context, *InMstpFidToMstiV2TableRequest) (*empty.Empty, error) {
+func (UnimplementedNotificationServer) UpdateConfigMstpFidToMstiV2Table(context.Context, *InMstpFidToMstiV2TableRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfigMstpFidToMstiV2Table not implemented")
 }
-func (UnimplementedNotificationServer) UpdateConfigMstpPortTable(context.Context, *InMstpPortTableRequest) (*empty.Empty, error) {
+func (UnimplementedNotificationServer) UpdateConfigMstpPortTable(context.Context, *InMstpPortTableRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfigMstpPortTable not implemented")
 }
-func (UnimplementedNotificationServer) UpdateConfigMstpTable(context.Context, *InMstpTableRequest) (*empty.Empty, error) {
+func (UnimplementedNotificationServer) UpdateConfigMstpTable(context.Context, *InMstpTableRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfigMstpTable not implemented")
 }
 func (UnimplementedNotificationServer) mustEmbedUnimplementedNotificationServer() {}
@@ -175,6 +205,42 @@ func _Notification_CalcConfig_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Notification_WithdrawStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StreamId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).WithdrawStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.Notification/WithdrawStream",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).WithdrawStream(ctx, req.(*StreamId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_ModifyStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyStreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).ModifyStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notification.Notification/ModifyStream",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).ModifyStream(ctx, req.(*ModifyStreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Notification_UpdateConfigMstpCistPortTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InMstpCistPortTableRequest)
 	if err := dec(in); err != nil {
@@ -294,6 +360,14 @@ var Notification_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CalcConfig",
 			Handler:    _Notification_CalcConfig_Handler,
 		},
+		{
+			MethodName: "WithdrawStream",
+			Handler:    _Notification_WithdrawStream_Handler,
+		},
+		{
+			MethodName: "ModifyStream",
+			Handler:    _Notification_ModifyStream_Handler,
+		},
 		{
 			MethodName: "UpdateConfigMstpCistPortTable",
 			Handler:    _Notification_UpdateConfigMstpCistPortTable_Handler,
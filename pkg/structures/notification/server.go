@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"tsn-service/pkg/RAE/mstp"
 	handler "tsn-service/pkg/notificationHandler"
+	"tsn-service/pkg/structures/configuration"
 
 	//"git.cs.kau.se/hamzchah/opencnc_kafka-exporter/logger/pkg/logger"
 
@@ -95,7 +96,7 @@ func (s *Server) CalcConfig(ctx context.Context, in *IdList) (*UUID, error) {
 	//log.Infof("Received notification to calculate configuration for: %s", ids)
 	fmt.Printf("Received notification to calculate configuration for: %s\n", ids)
 
-	configId, err := handler.CalculateConfiguration(idStringSlice)
+	configId, resp, err := handler.CalculateConfiguration(idStringSlice)
 	if err != nil {
 		//log.Errorf("Failed calculating configuration: %v", err)
 		fmt.Printf("Failed calculating configuration: %v\n", err)
@@ -103,9 +104,64 @@ func (s *Server) CalcConfig(ctx context.Context, in *IdList) (*UUID, error) {
 		return nil, err
 	}
 
+	// The response is stored with the configuration, the status of every stream is logged here
+	for _, streamResp := range resp.GetResponses() {
+		statusGroup := streamResp.GetStatusGroup()
+		fmt.Printf("Stream %s:%s, failure code: %d\n", statusGroup.GetStrId().GetMacAddress(),
+			statusGroup.GetStrId().GetUniqueId(), statusGroup.GetStatusInfo().GetFailureCode())
+	}
+
 	var transportConfId = &UUID{
 		Value: configId,
 	}
 
 	return transportConfId, nil
 }
+
+/* ------------------ <Stream lifecycle> -------------------------- */
+
+// Function provided by gRPC server (entrypoint for withdrawing a stream)
+func (s *Server) WithdrawStream(ctx context.Context, in *StreamId) (*UUID, error) {
+	//log.Infof("Received notification to withdraw stream: %v", in)
+	fmt.Printf("Received notification to withdraw stream: %v\n", in)
+
+	configId, err := handler.WithdrawStream(getStreamId(in))
+	if err != nil {
+		//log.Errorf("Failed withdrawing stream: %v", err)
+		return nil, err
+	}
+
+	return &UUID{Value: configId}, nil
+}
+
+// Function provided by gRPC server (entrypoint for changing the traffic specification of a stream)
+func (s *Server) ModifyStream(ctx context.Context, in *ModifyStreamRequest) (*UUID, error) {
+	//log.Infof("Received notification to modify stream: %v", in)
+	fmt.Printf("Received notification to modify stream: %v\n", in)
+
+	trafficSpec := &configuration.TrafficSpecification{
+		Interval: &configuration.Interval{
+			Numerator:   in.IntervalNumerator,
+			Denominator: in.IntervalDenominator,
+		},
+		MaxFramesPerInterval: in.MaxFramesPerInterval,
+		MaxFrameSize:         in.MaxFrameSize,
+	}
+
+	configId, err := handler.ModifyStream(getStreamId(in.GetStrId()), trafficSpec)
+	if err != nil {
+		//log.Errorf("Failed modifying stream: %v", err)
+		return nil, err
+	}
+
+	return &UUID{Value: configId}, nil
+}
+
+func getStreamId(in *StreamId) *configuration.StreamId {
+	return &configuration.StreamId{
+		MacAddress: in.GetMacAddress(),
+		UniqueId:   in.GetUniqueId(),
+	}
+}
+
+/* ------------------ </Stream lifecycle> -------------------------- */
@@ -0,0 +1,18 @@
+package schedule
+
+/*
+Get the window (ns) of every traffic class in the gating cycle, in the order of the schedule
+The windows are rounded where the traffic classes end in the cycle, not one by one, so they always sum exactly to the
+gating cycle when the assigned portions sum to 100%.
+*/
+func (sched *Schedule) GetIntervals() []uint64 {
+	var intervals []uint64
+	var portion, start uint64
+	for _, trafficClass := range sched.GetTrafficClasses() {
+		portion += uint64(max(trafficClass.AssignedPortion, 0))
+		end := (sched.GetGatingCycle()*portion + 50) / 100
+		intervals = append(intervals, end-start)
+		start = end
+	}
+	return intervals
+}
@@ -119,8 +119,9 @@ func (x *ConfigMap) GetSched() *Schedule {
 
 type Schedule struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	GatingCycle    float32                `protobuf:"fixed32,1,opt,name=GatingCycle,json=gating-cycle,proto3" json:"GatingCycle,omitempty"`
+	GatingCycle    uint64                 `protobuf:"varint,3,opt,name=GatingCycle,json=gating-cycle,proto3" json:"GatingCycle,omitempty"` // ns
 	TrafficClasses []*TrafficClass        `protobuf:"bytes,2,rep,name=TrafficClasses,json=traffic-classes,proto3" json:"TrafficClasses,omitempty"`
+	Mode           string                 `protobuf:"bytes,4,opt,name=Mode,json=mode,proto3" json:"Mode,omitempty"` // "gcl" (default when empty): shared gate control list, "cqf": cyclic queuing and forwarding
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -155,7 +156,7 @@ func (*Schedule) Descriptor() ([]byte, []int) {
 	return file_pkg_structures_schedule_schedule_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Schedule) GetGatingCycle() float32 {
+func (x *Schedule) GetGatingCycle() uint64 {
 	if x != nil {
 		return x.GatingCycle
 	}
@@ -169,6 +170,13 @@ func (x *Schedule) GetTrafficClasses() []*TrafficClass {
 	return nil
 }
 
+func (x *Schedule) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
 type TrafficClass struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Name            string                 `protobuf:"bytes,1,opt,name=Name,json=name,proto3" json:"Name,omitempty"`
@@ -236,20 +244,22 @@ var file_pkg_structures_schedule_schedule_proto_rawDesc = string([]byte{
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x28,
 	0x0a, 0x05, 0x53, 0x63, 0x68, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
 	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
-	0x65, 0x52, 0x05, 0x53, 0x63, 0x68, 0x65, 0x64, 0x22, 0x6e, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65,
-	0x64, 0x75, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0b, 0x47, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x43, 0x79,
-	0x63, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c, 0x67, 0x61, 0x74, 0x69, 0x6e,
-	0x67, 0x2d, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x66, 0x66,
-	0x69, 0x63, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x66, 0x66,
-	0x69, 0x63, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63,
-	0x2d, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x22, 0x4d, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x66,
-	0x66, 0x69, 0x63, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x0f,
-	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x2d,
-	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0b, 0x5a, 0x09, 0x2f, 0x73, 0x63, 0x68, 0x65,
-	0x64, 0x75, 0x6c, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x52, 0x05, 0x53, 0x63, 0x68, 0x65, 0x64, 0x22, 0x88, 0x01, 0x0a, 0x08, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0b, 0x47, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x43,
+	0x79, 0x63, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x67, 0x61, 0x74, 0x69,
+	0x6e, 0x67, 0x2d, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x66,
+	0x66, 0x69, 0x63, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x66,
+	0x66, 0x69, 0x63, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69,
+	0x63, 0x2d, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x6f, 0x64,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x4a, 0x04, 0x08,
+	0x01, 0x10, 0x02, 0x22, 0x4d, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x0f, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x10, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x2d, 0x70, 0x6f, 0x72, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x0b, 0x5a, 0x09, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
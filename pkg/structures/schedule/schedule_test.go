@@ -0,0 +1,44 @@
+package schedule
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGetIntervals(t *testing.T) {
+	tests := []struct {
+		name        string
+		gatingCycle uint64
+		portions    []int32
+		want        []uint64
+	}{
+		{name: "exact", gatingCycle: 1000000, portions: []int32{50, 30, 20}, want: []uint64{500000, 300000, 200000}},
+		{name: "thirds", gatingCycle: 1000000, portions: []int32{33, 33, 34}, want: []uint64{330000, 330000, 340000}},
+		// 330000.33, 660000.66 and 1000001 ns from the start of the cycle
+		{name: "thirds of an odd cycle", gatingCycle: 1000001, portions: []int32{33, 33, 34}, want: []uint64{330000, 330001, 340000}},
+		// 3300.33, 6600.66, 9900.99 and 10001 ns from the start of the cycle
+		{name: "rounding up and down", gatingCycle: 10001, portions: []int32{33, 33, 33, 1}, want: []uint64{3300, 3301, 3300, 100}},
+		{name: "cycle shorter than the portions", gatingCycle: 3, portions: []int32{50, 50}, want: []uint64{2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched := &Schedule{GatingCycle: tt.gatingCycle}
+			for _, portion := range tt.portions {
+				sched.TrafficClasses = append(sched.TrafficClasses, &TrafficClass{AssignedPortion: portion})
+			}
+
+			intervals := sched.GetIntervals()
+			if !slices.Equal(intervals, tt.want) {
+				t.Errorf("GetIntervals() = %v, want %v", intervals, tt.want)
+			}
+			var sum uint64
+			for _, interval := range intervals {
+				sum += interval
+			}
+			if sum != tt.gatingCycle {
+				t.Errorf("GetIntervals() = %v, sums to %d ns, want the gating cycle %d ns", intervals, sum, tt.gatingCycle)
+			}
+		})
+	}
+}